@@ -12,8 +12,10 @@ var ErrCacheMiss = errors.New("cache miss")
 type CacheService interface {
 	Set(ctx context.Context, key string, value any, ttl time.Duration) error
 	Get(ctx context.Context, key string, target any) error
+	GetAndDelete(ctx context.Context, key string, target any) error
 	Delete(ctx context.Context, key string) error
 	Exists(ctx context.Context, key string) (bool, error)
+	Increment(ctx context.Context, key string, ttl time.Duration) (int64, error)
 	AddToSet(ctx context.Context, key string, value string, ttl time.Duration) error
 	RemoveFromSet(ctx context.Context, key string, value string) error
 	GetSetMembers(ctx context.Context, key string, target any) error
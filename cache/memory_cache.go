@@ -0,0 +1,262 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/internal"
+	jsoniter "github.com/json-iterator/go"
+)
+
+const memoryCacheSweepInterval = time.Minute
+
+var errWrongType = errors.New("operation against a key holding the wrong kind of value")
+
+// memoryEntry holds either a JSON value or a set, like a Redis string or set.
+type memoryEntry struct {
+	value     []byte
+	members   map[string]struct{}
+	expiresAt time.Time
+}
+
+func (m *memoryEntry) isSet() bool {
+	return m.members != nil
+}
+
+func (m *memoryEntry) expired(now time.Time) bool {
+	return !m.expiresAt.IsZero() && !now.Before(m.expiresAt)
+}
+
+// memoryCache mirrors the behavior of redisCache inside the process: a zero
+// ttl never expires, empty sets are removed and commands on a key of the
+// other kind fail. Expired keys are removed on access and swept periodically.
+type memoryCache struct {
+	di        *internal.Di
+	mu        sync.Mutex
+	entries   map[string]*memoryEntry
+	lastSweep time.Time
+}
+
+func NewMemoryCache(di *internal.Di) (CacheService, error) {
+	return &memoryCache{
+		di:        di,
+		entries:   make(map[string]*memoryEntry),
+		lastSweep: time.Now(),
+	}, nil
+}
+
+func (m *memoryCache) Set(ctx context.Context, key string, value any, ttl time.Duration) error {
+	JSON, err := jsoniter.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.sweep(now)
+
+	m.entries[key] = &memoryEntry{
+		value:     JSON,
+		expiresAt: expiresAt(now, ttl),
+	}
+
+	return nil
+}
+
+func (m *memoryCache) Get(ctx context.Context, key string, target any) error {
+	m.mu.Lock()
+	entry := m.entry(key, time.Now())
+	m.mu.Unlock()
+
+	if entry == nil {
+		return ErrCacheMiss
+	}
+
+	if entry.isSet() {
+		return errWrongType
+	}
+
+	return jsoniter.Unmarshal(entry.value, target)
+}
+
+func (m *memoryCache) GetAndDelete(ctx context.Context, key string, target any) error {
+	m.mu.Lock()
+	entry := m.entry(key, time.Now())
+	if entry != nil && !entry.isSet() {
+		delete(m.entries, key)
+	}
+	m.mu.Unlock()
+
+	if entry == nil {
+		return ErrCacheMiss
+	}
+
+	if entry.isSet() {
+		return errWrongType
+	}
+
+	return jsoniter.Unmarshal(entry.value, target)
+}
+
+func (m *memoryCache) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.entries, key)
+	return nil
+}
+
+func (m *memoryCache) Exists(ctx context.Context, key string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.entry(key, time.Now()) != nil, nil
+}
+
+// Increment behaves like INCR on a counter that expires ttl after its first
+// increment.
+func (m *memoryCache) Increment(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.sweep(now)
+
+	var count int64
+	entry := m.entry(key, now)
+	if entry == nil {
+		entry = &memoryEntry{expiresAt: expiresAt(now, ttl)}
+		m.entries[key] = entry
+	} else if entry.isSet() || jsoniter.Unmarshal(entry.value, &count) != nil {
+		return 0, errWrongType
+	}
+
+	count++
+
+	value, err := jsoniter.Marshal(count)
+	if err != nil {
+		return 0, err
+	}
+
+	entry.value = value
+	return count, nil
+}
+
+// AddToSet refreshes the expiration of the whole set like EXPIRE does, so a
+// non-positive ttl removes the key.
+func (m *memoryCache) AddToSet(ctx context.Context, key string, value string, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.sweep(now)
+
+	entry := m.entry(key, now)
+	if entry == nil {
+		entry = &memoryEntry{members: make(map[string]struct{})}
+		m.entries[key] = entry
+	}
+
+	if !entry.isSet() {
+		return errWrongType
+	}
+
+	if ttl <= 0 {
+		delete(m.entries, key)
+		return nil
+	}
+
+	entry.members[value] = struct{}{}
+	entry.expiresAt = now.Add(ttl)
+
+	return nil
+}
+
+func (m *memoryCache) RemoveFromSet(ctx context.Context, key string, value string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := m.entry(key, time.Now())
+	if entry == nil {
+		return nil
+	}
+
+	if !entry.isSet() {
+		return errWrongType
+	}
+
+	delete(entry.members, value)
+	if len(entry.members) == 0 {
+		delete(m.entries, key)
+	}
+
+	return nil
+}
+
+// GetSetMembers returns an empty list for a missing key, as SMEMBERS does.
+func (m *memoryCache) GetSetMembers(ctx context.Context, key string, target any) error {
+	m.mu.Lock()
+	members := []string{}
+	entry := m.entry(key, time.Now())
+	if entry != nil && entry.isSet() {
+		for member := range entry.members {
+			members = append(members, member)
+		}
+	}
+	m.mu.Unlock()
+
+	if entry != nil && !entry.isSet() {
+		return errWrongType
+	}
+
+	data, err := jsoniter.Marshal(members)
+	if err != nil {
+		return err
+	}
+
+	return jsoniter.Unmarshal(data, target)
+}
+
+// entry returns the live entry of key, dropping it when it has expired. The
+// caller must hold the lock.
+func (m *memoryCache) entry(key string, now time.Time) *memoryEntry {
+	entry, ok := m.entries[key]
+	if !ok {
+		return nil
+	}
+
+	if entry.expired(now) {
+		delete(m.entries, key)
+		return nil
+	}
+
+	return entry
+}
+
+// sweep drops the expired keys that were never read again. The caller must
+// hold the lock.
+func (m *memoryCache) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < memoryCacheSweepInterval {
+		return
+	}
+
+	for key, entry := range m.entries {
+		if entry.expired(now) {
+			delete(m.entries, key)
+		}
+	}
+
+	m.lastSweep = now
+}
+
+func expiresAt(now time.Time, ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+
+	return now.Add(ttl)
+}
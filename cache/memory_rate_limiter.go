@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/internal"
+)
+
+// rateLimitWindow holds the timestamps of the requests of a key inside its
+// window, oldest first.
+type rateLimitWindow struct {
+	requests []time.Time
+	window   time.Duration
+}
+
+// trim drops the requests that fell out of the window.
+func (r *rateLimitWindow) trim(now time.Time) {
+	for len(r.requests) > 0 && !r.requests[0].After(now.Add(-r.window)) {
+		r.requests = r.requests[1:]
+	}
+}
+
+// memoryRateLimiter applies the same sliding window as redisRateLimiter,
+// keeping the timestamps of the requests of each key in memory. Keys whose
+// window is empty are removed on access and swept periodically, like keys
+// expiring in Redis.
+type memoryRateLimiter struct {
+	di        *internal.Di
+	mu        sync.Mutex
+	windows   map[string]*rateLimitWindow
+	lastSweep time.Time
+}
+
+func NewMemoryRateLimiter(di *internal.Di) (RateLimiter, error) {
+	return &memoryRateLimiter{
+		di:        di,
+		windows:   make(map[string]*rateLimitWindow),
+		lastSweep: time.Now(),
+	}, nil
+}
+
+func (m *memoryRateLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.sweep(now)
+
+	entry, ok := m.windows[key]
+	if !ok {
+		entry = &rateLimitWindow{}
+	}
+
+	entry.window = window
+	entry.trim(now)
+
+	if len(entry.requests) < limit {
+		entry.requests = append(entry.requests, now)
+		m.windows[key] = entry
+		return true, 0, nil
+	}
+
+	if len(entry.requests) == 0 {
+		delete(m.windows, key)
+		return false, window, nil
+	}
+
+	return false, max(entry.requests[0].Add(window).Sub(now), time.Millisecond), nil
+}
+
+func (m *memoryRateLimiter) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < memoryCacheSweepInterval {
+		return
+	}
+
+	for key, entry := range m.windows {
+		entry.trim(now)
+		if len(entry.requests) == 0 {
+			delete(m.windows, key)
+		}
+	}
+
+	m.lastSweep = now
+}
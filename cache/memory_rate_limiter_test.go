@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRateLimiter_Allow(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should reject requests over the limit inside the window", func(t *testing.T) {
+		rateLimiter, _ := NewMemoryRateLimiter(nil)
+
+		allowed, _, err := rateLimiter.Allow(ctx, "key", 1, time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+
+		allowed, retryAfter, err := rateLimiter.Allow(ctx, "key", 1, time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, allowed)
+		assert.Greater(t, retryAfter, time.Duration(0))
+	})
+
+	t.Run("should sweep the keys whose window is empty", func(t *testing.T) {
+		rateLimiter := &memoryRateLimiter{
+			windows:   make(map[string]*rateLimitWindow),
+			lastSweep: time.Now(),
+		}
+
+		_, _, err := rateLimiter.Allow(ctx, "old", 5, time.Millisecond)
+		assert.NoError(t, err)
+		_, _, err = rateLimiter.Allow(ctx, "recent", 5, time.Hour)
+		assert.NoError(t, err)
+
+		time.Sleep(5 * time.Millisecond)
+		rateLimiter.lastSweep = time.Now().Add(-memoryCacheSweepInterval)
+
+		_, _, err = rateLimiter.Allow(ctx, "other", 5, time.Hour)
+		assert.NoError(t, err)
+
+		assert.NotContains(t, rateLimiter.windows, "old")
+		assert.Contains(t, rateLimiter.windows, "recent")
+		assert.Contains(t, rateLimiter.windows, "other")
+	})
+
+	t.Run("should not keep a key when the rule allows no requests", func(t *testing.T) {
+		rateLimiter := &memoryRateLimiter{
+			windows:   make(map[string]*rateLimitWindow),
+			lastSweep: time.Now(),
+		}
+
+		allowed, _, err := rateLimiter.Allow(ctx, "key", 0, time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, allowed)
+		assert.Empty(t, rateLimiter.windows)
+	})
+}
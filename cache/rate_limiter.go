@@ -0,0 +1,11 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockery --name=RateLimiter --output=../mocks --outpkg=mocks
+type RateLimiter interface {
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, time.Duration, error)
+}
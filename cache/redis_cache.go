@@ -9,6 +9,16 @@ import (
 	jsoniter "github.com/json-iterator/go"
 )
 
+// incrementScript sets the expiration only on the first increment, so the
+// counter lives for ttl after it started instead of after its last increment.
+var incrementScript = redis.NewScript(`
+local count = redis.call('INCR', KEYS[1])
+if count == 1 and tonumber(ARGV[1]) > 0 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return count
+`)
+
 type redisCache struct {
 	di     *internal.Di
 	client *redis.Client
@@ -48,6 +58,19 @@ func (r *redisCache) Get(ctx context.Context, key string, target any) error {
 	return jsoniter.Unmarshal([]byte(result), target)
 }
 
+func (r *redisCache) GetAndDelete(ctx context.Context, key string, target any) error {
+	result, err := r.client.GetDel(ctx, key).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return ErrCacheMiss
+		}
+
+		return err
+	}
+
+	return jsoniter.Unmarshal([]byte(result), target)
+}
+
 func (r *redisCache) Delete(ctx context.Context, key string) error {
 	return r.client.Del(ctx, key).Err()
 }
@@ -60,6 +83,10 @@ func (r *redisCache) Exists(ctx context.Context, key string) (bool, error) {
 	return count > 0, nil
 }
 
+func (r *redisCache) Increment(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	return incrementScript.Run(ctx, r.client, []string{key}, ttl.Milliseconds()).Int64()
+}
+
 func (r *redisCache) AddToSet(ctx context.Context, key string, value string, ttl time.Duration) error {
 	pipe := r.client.TxPipeline()
 	pipe.SAdd(ctx, key, value)
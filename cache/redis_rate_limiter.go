@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+// slidingWindowScript keeps one sorted set entry per request, scored by its
+// timestamp in milliseconds. It returns 0 when the request is allowed, or the
+// milliseconds left until the oldest entry leaves the window otherwise.
+var slidingWindowScript = redis.NewScript(`
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+redis.call('ZREMRANGEBYSCORE', key, 0, now - window)
+
+if redis.call('ZCARD', key) < limit then
+	redis.call('ZADD', key, now, ARGV[4])
+	redis.call('PEXPIRE', key, window)
+	return 0
+end
+
+local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+return math.max(tonumber(oldest[2]) + window - now, 1)
+`)
+
+type redisRateLimiter struct {
+	di     *internal.Di
+	client *redis.Client
+}
+
+func NewRedisRateLimiter(di *internal.Di) (RateLimiter, error) {
+	client, err := internal.Invoke[*redis.Client](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &redisRateLimiter{
+		di:     di,
+		client: client,
+	}, nil
+}
+
+func (r *redisRateLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, time.Duration, error) {
+	now := time.Now().UnixMilli()
+	retryAfter, err := slidingWindowScript.Run(ctx, r.client, []string{key}, now, window.Milliseconds(), limit, uuid.NewString()).Int64()
+	if err != nil {
+		return false, 0, err
+	}
+
+	if retryAfter > 0 {
+		return false, time.Duration(retryAfter) * time.Millisecond, nil
+	}
+
+	return true, 0, nil
+}
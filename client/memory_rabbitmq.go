@@ -0,0 +1,266 @@
+package client
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// memoryRabbitMQClient is an in-process broker with the semantics the queue
+// service relies on: messages stay unacknowledged until Ack or Reject, a
+// rejected message is requeued or dead-lettered, and messages published to a
+// queue with a MessageTTL are moved to its DeadLetterQueue once they expire.
+type memoryRabbitMQClient struct {
+	di        *internal.Di
+	mu        sync.Mutex
+	queues    map[string]*memoryQueue
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+type memoryQueue struct {
+	client   *memoryRabbitMQClient
+	name     string
+	options  QueueOptions
+	messages []memoryMessage
+	unacked  map[uint64]memoryMessage
+	nextSeq  uint64
+	nextTag  uint64
+	// notify wakes up a consumer waiting for messages.
+	notify chan struct{}
+}
+
+// memoryMessage keeps the position of the message in the queue, so requeued
+// messages go back to where they were.
+type memoryMessage struct {
+	seq      uint64
+	delivery amqp091.Delivery
+}
+
+func NewMemoryRabbitMQClient(di *internal.Di) (RabbitMQClient, error) {
+	return &memoryRabbitMQClient{
+		di:     di,
+		queues: make(map[string]*memoryQueue),
+		done:   make(chan struct{}),
+	}, nil
+}
+
+func (m *memoryRabbitMQClient) Connect() error {
+	return nil
+}
+
+func (m *memoryRabbitMQClient) Disconnect() error {
+	m.closeOnce.Do(func() {
+		close(m.done)
+	})
+
+	return nil
+}
+
+func (m *memoryRabbitMQClient) IsConnected() bool {
+	select {
+	case <-m.done:
+		return false
+	default:
+		return true
+	}
+}
+
+func (m *memoryRabbitMQClient) DeclareQueue(queueName string, options QueueOptions) error {
+	if !m.IsConnected() {
+		return ErrRabbitMQClosed
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	queue := m.queue(queueName)
+	queue.options = options
+
+	return nil
+}
+
+func (m *memoryRabbitMQClient) Publish(queueName string, message []byte) error {
+	return m.PublishWithHeaders(queueName, message, nil)
+}
+
+func (m *memoryRabbitMQClient) PublishWithHeaders(queueName string, message []byte, headers map[string]any) error {
+	if !m.IsConnected() {
+		return ErrRabbitMQClosed
+	}
+
+	body := make([]byte, len(message))
+	copy(body, message)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.queue(queueName).push(amqp091.Delivery{Body: body, Headers: maps.Clone(headers)})
+	return nil
+}
+
+// Consume delivers the messages of the queue one at a time. The returned
+// channel is only closed by Disconnect.
+func (m *memoryRabbitMQClient) Consume(queueName string) (<-chan *Message, error) {
+	if !m.IsConnected() {
+		return nil, ErrRabbitMQClosed
+	}
+
+	m.mu.Lock()
+	queue := m.queue(queueName)
+	m.mu.Unlock()
+
+	msgChan := make(chan *Message)
+	go func() {
+		defer close(msgChan)
+
+		for {
+			m.mu.Lock()
+			delivery, ok := queue.pop()
+			m.mu.Unlock()
+
+			if !ok {
+				select {
+				case <-queue.notify:
+					continue
+				case <-m.done:
+					return
+				}
+			}
+
+			select {
+			case msgChan <- &Message{Body: delivery.Body, Headers: delivery.Headers, delivery: &delivery}:
+			case <-m.done:
+				return
+			}
+		}
+	}()
+
+	return msgChan, nil
+}
+
+func (m *memoryRabbitMQClient) Get(queueName string) (*Message, error) {
+	if !m.IsConnected() {
+		return nil, ErrRabbitMQClosed
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delivery, ok := m.queue(queueName).pop()
+	if !ok {
+		return nil, nil
+	}
+
+	return &Message{
+		Body:     delivery.Body,
+		Headers:  delivery.Headers,
+		delivery: &delivery,
+	}, nil
+}
+
+// queue returns the queue, declaring it the first time it is used. The caller
+// must hold the lock.
+func (m *memoryRabbitMQClient) queue(queueName string) *memoryQueue {
+	queue, ok := m.queues[queueName]
+	if !ok {
+		queue = &memoryQueue{
+			client:  m,
+			name:    queueName,
+			unacked: make(map[uint64]memoryMessage),
+			notify:  make(chan struct{}, 1),
+		}
+		m.queues[queueName] = queue
+	}
+
+	return queue
+}
+
+// push enqueues the delivery, or schedules its dead-lettering when the queue
+// is a delay queue. The caller must hold the client lock.
+func (q *memoryQueue) push(delivery amqp091.Delivery) {
+	if q.options.MessageTTL > 0 && q.options.DeadLetterQueue != "" {
+		time.AfterFunc(q.options.MessageTTL, func() {
+			q.client.mu.Lock()
+			defer q.client.mu.Unlock()
+
+			q.client.queue(q.options.DeadLetterQueue).push(delivery)
+		})
+		return
+	}
+
+	q.nextSeq++
+	q.messages = append(q.messages, memoryMessage{seq: q.nextSeq, delivery: delivery})
+	q.wake()
+}
+
+// pop takes the next message and keeps it unacknowledged. The caller must
+// hold the client lock.
+func (q *memoryQueue) pop() (amqp091.Delivery, bool) {
+	if len(q.messages) == 0 {
+		return amqp091.Delivery{}, false
+	}
+
+	message := q.messages[0]
+	q.messages = q.messages[1:]
+
+	q.nextTag++
+	q.unacked[q.nextTag] = message
+
+	delivery := message.delivery
+	delivery.Acknowledger = q
+	delivery.DeliveryTag = q.nextTag
+
+	return delivery, true
+}
+
+func (q *memoryQueue) wake() {
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (q *memoryQueue) Ack(tag uint64, multiple bool) error {
+	q.client.mu.Lock()
+	defer q.client.mu.Unlock()
+
+	delete(q.unacked, tag)
+	return nil
+}
+
+// Nack puts the message back at its original position when requeue is set,
+// otherwise it is dead-lettered or dropped like in RabbitMQ.
+func (q *memoryQueue) Nack(tag uint64, multiple bool, requeue bool) error {
+	q.client.mu.Lock()
+	defer q.client.mu.Unlock()
+
+	message, ok := q.unacked[tag]
+	if !ok {
+		return nil
+	}
+	delete(q.unacked, tag)
+
+	switch {
+	case requeue:
+		message.delivery.Redelivered = true
+		index, _ := slices.BinarySearchFunc(q.messages, message.seq, func(m memoryMessage, seq uint64) int {
+			return cmp.Compare(m.seq, seq)
+		})
+		q.messages = slices.Insert(q.messages, index, message)
+		q.wake()
+	case q.options.DeadLetterQueue != "":
+		q.client.queue(q.options.DeadLetterQueue).push(message.delivery)
+	}
+
+	return nil
+}
+
+func (q *memoryQueue) Reject(tag uint64, requeue bool) error {
+	return q.Nack(tag, false, requeue)
+}
@@ -1,60 +1,191 @@
 package client
 
 import (
-	"fmt"
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
 
 	"github.com/G-Villarinho/food-shop-api/config"
 	"github.com/G-Villarinho/food-shop-api/internal"
 	"github.com/rabbitmq/amqp091-go"
 )
 
+const (
+	defaultPrefetchCount  = 10
+	publishConfirmTimeout = 5 * time.Second
+	reconnectBaseDelay    = time.Second
+	reconnectMaxDelay     = 30 * time.Second
+)
+
+var (
+	ErrRabbitMQNotConnected = errors.New("rabbitMQ is not connected")
+	ErrRabbitMQClosed       = errors.New("rabbitMQ client was disconnected")
+	ErrPublishNotConfirmed  = errors.New("rabbitMQ did not confirm the published message")
+)
+
 //go:generate mockery --name=RabbitMQClient --output=../mocks --outpkg=mocks
 type RabbitMQClient interface {
 	Connect() error
+	DeclareQueue(queueName string, options QueueOptions) error
 	Publish(queueName string, message []byte) error
-	Consume(queueName string) (<-chan []byte, error)
+	PublishWithHeaders(queueName string, message []byte, headers map[string]any) error
+	Consume(queueName string) (<-chan *Message, error)
+	Get(queueName string) (*Message, error)
+	IsConnected() bool
 	Disconnect() error
 }
 
+// QueueOptions turns a queue into a delay queue when both fields are set:
+// messages expire after MessageTTL and are moved to DeadLetterQueue.
+type QueueOptions struct {
+	MessageTTL      time.Duration
+	DeadLetterQueue string
+}
+
+// Message is a message received from a queue. It must be acknowledged with
+// Ack once handled, or returned to the queue with Reject.
+type Message struct {
+	Body     []byte
+	Headers  map[string]any
+	delivery *amqp091.Delivery
+}
+
+func NewMessage(body []byte, headers map[string]any) *Message {
+	return &Message{
+		Body:    body,
+		Headers: headers,
+	}
+}
+
+func (m *Message) Ack() error {
+	if m.delivery == nil {
+		return nil
+	}
+
+	return m.delivery.Ack(false)
+}
+
+func (m *Message) Reject(requeue bool) error {
+	if m.delivery == nil {
+		return nil
+	}
+
+	return m.delivery.Nack(false, requeue)
+}
+
+// amqpConnection and amqpChannel are the parts of the amqp091 connection and
+// channel the client uses, so the reconnection and confirmation logic can be
+// tested without a broker.
+type amqpConnection interface {
+	Channel() (amqpChannel, error)
+	NotifyClose(receiver chan *amqp091.Error) chan *amqp091.Error
+	IsClosed() bool
+	Close() error
+}
+
+type amqpChannel interface {
+	Confirm(noWait bool) error
+	Qos(prefetchCount, prefetchSize int, global bool) error
+	QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp091.Table) (amqp091.Queue, error)
+	Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp091.Table) (<-chan amqp091.Delivery, error)
+	Get(queue string, autoAck bool) (amqp091.Delivery, bool, error)
+	// PublishAndConfirm publishes to the default exchange and waits for the
+	// broker to ack or nack the message.
+	PublishAndConfirm(ctx context.Context, queueName string, publishing amqp091.Publishing) (bool, error)
+	NotifyClose(receiver chan *amqp091.Error) chan *amqp091.Error
+	IsClosed() bool
+	Close() error
+}
+
+type amqpConnectionAdapter struct {
+	*amqp091.Connection
+}
+
+func (a amqpConnectionAdapter) Channel() (amqpChannel, error) {
+	channel, err := a.Connection.Channel()
+	if err != nil {
+		return nil, err
+	}
+
+	return amqpChannelAdapter{channel}, nil
+}
+
+type amqpChannelAdapter struct {
+	*amqp091.Channel
+}
+
+func (a amqpChannelAdapter) PublishAndConfirm(ctx context.Context, queueName string, publishing amqp091.Publishing) (bool, error) {
+	confirmation, err := a.PublishWithDeferredConfirmWithContext(ctx, "", queueName, false, false, publishing)
+	if err != nil {
+		return false, err
+	}
+
+	return confirmation.WaitContext(ctx)
+}
+
+func dialRabbitMQ() (amqpConnection, error) {
+	conn, err := amqp091.Dial(config.Env.RabbitMQURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return amqpConnectionAdapter{conn}, nil
+}
+
+// rabbitMQClient keeps a single connection and channel. A supervisor reopens
+// them with backoff whenever the broker drops the connection, redeclaring the
+// known queues, and consumers are resubscribed transparently.
 type rabbitMQClient struct {
 	di         *internal.Di
-	connection *amqp091.Connection
-	channel    *amqp091.Channel
+	dialer     func() (amqpConnection, error)
+	mu         sync.RWMutex
+	connection amqpConnection
+	channel    amqpChannel
+	declared   map[string]QueueOptions
+	// ready is closed while connected and replaced when the connection drops.
+	ready     chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
 }
 
 func NewRabbitMQClient(di *internal.Di) (RabbitMQClient, error) {
 	return &rabbitMQClient{
-		di: di,
+		di:       di,
+		dialer:   dialRabbitMQ,
+		declared: make(map[string]QueueOptions),
+		ready:    make(chan struct{}),
+		done:     make(chan struct{}),
 	}, nil
 }
 
 func (r *rabbitMQClient) Connect() error {
-	conn, err := amqp091.Dial(config.Env.RabbitMQURL)
-	if err != nil {
+	if err := r.dial(); err != nil {
 		return err
 	}
-	r.connection = conn
 
-	channel, err := conn.Channel()
-	if err != nil {
-		return err
-	}
-	r.channel = channel
+	go r.supervise()
 
 	return nil
 }
 
 func (r *rabbitMQClient) Disconnect() error {
-	if r.channel != nil {
-		err := r.channel.Close()
-		if err != nil {
+	r.closeOnce.Do(func() {
+		close(r.done)
+	})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.channel != nil && !r.channel.IsClosed() {
+		if err := r.channel.Close(); err != nil {
 			return err
 		}
 	}
 
-	if r.connection != nil {
-		err := r.connection.Close()
-		if err != nil {
+	if r.connection != nil && !r.connection.IsClosed() {
+		if err := r.connection.Close(); err != nil {
 			return err
 		}
 	}
@@ -62,70 +193,326 @@ func (r *rabbitMQClient) Disconnect() error {
 	return nil
 }
 
+func (r *rabbitMQClient) IsConnected() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	select {
+	case <-r.ready:
+		return r.connection != nil && !r.connection.IsClosed() && !r.channel.IsClosed()
+	default:
+		return false
+	}
+}
+
+func (r *rabbitMQClient) DeclareQueue(queueName string, options QueueOptions) error {
+	channel, err := r.currentChannel()
+	if err != nil {
+		return err
+	}
+
+	if err := declareQueue(channel, queueName, options); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.declared[queueName] = options
+	r.mu.Unlock()
+
+	return nil
+}
+
 func (r *rabbitMQClient) Publish(queueName string, message []byte) error {
-	if r.channel == nil {
-		return fmt.Errorf("rabbitMQ channel is not initialized, ensure Connect() is called before Publish")
+	return r.PublishWithHeaders(queueName, message, nil)
+}
+
+// PublishWithHeaders waits for the broker to confirm the message, so a nil
+// error means RabbitMQ took responsibility for it. While reconnecting it waits
+// up to publishConfirmTimeout for the connection to come back.
+func (r *rabbitMQClient) PublishWithHeaders(queueName string, message []byte, headers map[string]any) error {
+	ctx, cancel := context.WithTimeout(context.Background(), publishConfirmTimeout)
+	defer cancel()
+
+	if err := r.waitReady(ctx); err != nil {
+		return err
 	}
 
-	_, err := r.channel.QueueDeclare(
-		queueName,
-		true,
-		false,
-		false,
-		false,
-		nil,
-	)
+	if err := r.ensureQueue(queueName); err != nil {
+		return err
+	}
+
+	channel, err := r.currentChannel()
 	if err != nil {
 		return err
 	}
 
-	err = r.channel.Publish(
-		"",
-		queueName,
-		false,
-		false,
-		amqp091.Publishing{
-			ContentType: "text/plain",
-			Body:        message,
-		},
-	)
+	acked, err := channel.PublishAndConfirm(ctx, queueName, amqp091.Publishing{
+		ContentType:  "text/plain",
+		DeliveryMode: amqp091.Persistent,
+		Headers:      headers,
+		Body:         message,
+	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	if !acked {
+		return ErrPublishNotConfirmed
+	}
+
+	return nil
 }
 
-func (r *rabbitMQClient) Consume(queueName string) (<-chan []byte, error) {
-	_, err := r.channel.QueueDeclare(
-		queueName,
-		true,
-		false,
-		false,
-		false,
-		nil,
-	)
+// Consume keeps delivering messages across reconnections. The returned
+// channel is only closed by Disconnect.
+func (r *rabbitMQClient) Consume(queueName string) (<-chan *Message, error) {
+	deliveries, err := r.subscribe(queueName)
 	if err != nil {
 		return nil, err
 	}
 
-	msgs, err := r.channel.Consume(
+	msgChan := make(chan *Message)
+	go func() {
+		defer close(msgChan)
+
+		for {
+			for msg := range deliveries {
+				select {
+				case msgChan <- &Message{Body: msg.Body, Headers: msg.Headers, delivery: &msg}:
+				case <-r.done:
+					return
+				}
+			}
+
+			deliveries, err = r.resubscribe(queueName)
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	return msgChan, nil
+}
+
+// Get fetches a single message without subscribing to the queue. It returns
+// nil when the queue is empty.
+func (r *rabbitMQClient) Get(queueName string) (*Message, error) {
+	if err := r.ensureQueue(queueName); err != nil {
+		return nil, err
+	}
+
+	channel, err := r.currentChannel()
+	if err != nil {
+		return nil, err
+	}
+
+	msg, ok, err := channel.Get(queueName, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, nil
+	}
+
+	return &Message{
+		Body:     msg.Body,
+		Headers:  msg.Headers,
+		delivery: &msg,
+	}, nil
+}
+
+func (r *rabbitMQClient) dial() error {
+	conn, err := r.dialer()
+	if err != nil {
+		return err
+	}
+
+	channel, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return err
+	}
+
+	if err := channel.Confirm(false); err != nil {
+		conn.Close()
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for queueName, options := range r.declared {
+		if err := declareQueue(channel, queueName, options); err != nil {
+			conn.Close()
+			return err
+		}
+	}
+
+	r.connection = conn
+	r.channel = channel
+	close(r.ready)
+
+	return nil
+}
+
+func (r *rabbitMQClient) supervise() {
+	for {
+		r.mu.RLock()
+		connectionClosed := r.connection.NotifyClose(make(chan *amqp091.Error, 1))
+		channelClosed := r.channel.NotifyClose(make(chan *amqp091.Error, 1))
+		r.mu.RUnlock()
+
+		var reason *amqp091.Error
+		select {
+		case <-r.done:
+			return
+		case reason = <-connectionClosed:
+		case reason = <-channelClosed:
+		}
+
+		select {
+		case <-r.done:
+			return
+		default:
+		}
+
+		r.mu.Lock()
+		r.ready = make(chan struct{})
+		if !r.connection.IsClosed() {
+			r.connection.Close()
+		}
+		r.mu.Unlock()
+
+		slog.Warn("RabbitMQ connection lost, reconnecting", slog.Any("reason", reason))
+
+		if !r.reconnect() {
+			return
+		}
+
+		slog.Info("RabbitMQ connection restored")
+	}
+}
+
+func (r *rabbitMQClient) reconnect() bool {
+	delay := reconnectBaseDelay
+	for {
+		select {
+		case <-r.done:
+			return false
+		case <-time.After(delay):
+		}
+
+		err := r.dial()
+		if err == nil {
+			return true
+		}
+
+		slog.Warn("error reconnecting to RabbitMQ", slog.String("error", err.Error()), slog.Duration("retry_in", delay))
+		delay = min(delay*2, reconnectMaxDelay)
+	}
+}
+
+func (r *rabbitMQClient) subscribe(queueName string) (<-chan amqp091.Delivery, error) {
+	if err := r.ensureQueue(queueName); err != nil {
+		return nil, err
+	}
+
+	channel, err := r.currentChannel()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := channel.Qos(defaultPrefetchCount, 0, false); err != nil {
+		return nil, err
+	}
+
+	return channel.Consume(
 		queueName,
 		"",
-		true,
+		false,
 		false,
 		false,
 		false,
 		nil,
 	)
-	if err != nil {
-		return nil, err
+}
+
+// resubscribe waits for the supervisor to reconnect and subscribes again. It
+// only gives up when the client is disconnected.
+func (r *rabbitMQClient) resubscribe(queueName string) (<-chan amqp091.Delivery, error) {
+	for {
+		if err := r.waitReady(context.Background()); err != nil {
+			return nil, err
+		}
+
+		deliveries, err := r.subscribe(queueName)
+		if err == nil {
+			return deliveries, nil
+		}
+
+		select {
+		case <-r.done:
+			return nil, ErrRabbitMQClosed
+		case <-time.After(reconnectBaseDelay):
+		}
 	}
+}
 
-	msgChan := make(chan []byte)
-	go func() {
-		for msg := range msgs {
-			msgChan <- msg.Body
+func (r *rabbitMQClient) waitReady(ctx context.Context) error {
+	r.mu.RLock()
+	ready := r.ready
+	r.mu.RUnlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-r.done:
+		return ErrRabbitMQClosed
+	case <-ctx.Done():
+		return ErrRabbitMQNotConnected
+	}
+}
+
+func (r *rabbitMQClient) currentChannel() (amqpChannel, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.channel == nil || r.channel.IsClosed() {
+		return nil, ErrRabbitMQNotConnected
+	}
+
+	return r.channel, nil
+}
+
+// ensureQueue declares a plain durable queue the first time it is used.
+// Queues declared with options must go through DeclareQueue beforehand,
+// otherwise the broker rejects the redeclaration with different arguments.
+func (r *rabbitMQClient) ensureQueue(queueName string) error {
+	r.mu.RLock()
+	_, declared := r.declared[queueName]
+	r.mu.RUnlock()
+
+	if declared {
+		return nil
+	}
+
+	return r.DeclareQueue(queueName, QueueOptions{})
+}
+
+func declareQueue(channel amqpChannel, queueName string, options QueueOptions) error {
+	var args amqp091.Table
+	if options.DeadLetterQueue != "" {
+		args = amqp091.Table{
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": options.DeadLetterQueue,
 		}
-		close(msgChan)
-	}()
 
-	return msgChan, nil
+		if options.MessageTTL > 0 {
+			args["x-message-ttl"] = options.MessageTTL.Milliseconds()
+		}
+	}
+
+	_, err := channel.QueueDeclare(queueName, true, false, false, false, args)
+	return err
 }
@@ -0,0 +1,354 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConnection and fakeChannel stand in for a broker. drop closes them the
+// way amqp091 does when the connection is lost.
+type fakeConnection struct {
+	mu      sync.Mutex
+	closed  bool
+	notify  []chan *amqp091.Error
+	channel *fakeChannel
+}
+
+func newFakeConnection(acked bool) *fakeConnection {
+	return &fakeConnection{
+		channel: &fakeChannel{
+			acked:      acked,
+			declared:   make(map[string]amqp091.Table),
+			deliveries: make(map[string]chan amqp091.Delivery),
+		},
+	}
+}
+
+func (f *fakeConnection) Channel() (amqpChannel, error) {
+	return f.channel, nil
+}
+
+func (f *fakeConnection) NotifyClose(receiver chan *amqp091.Error) chan *amqp091.Error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// Like amqp091, a receiver registered after the close is closed at once.
+	if f.closed {
+		close(receiver)
+		return receiver
+	}
+
+	f.notify = append(f.notify, receiver)
+	return receiver
+}
+
+func (f *fakeConnection) IsClosed() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.closed
+}
+
+func (f *fakeConnection) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.close(nil)
+	return nil
+}
+
+func (f *fakeConnection) drop() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.close(&amqp091.Error{Code: amqp091.ConnectionForced, Reason: "broker restarted"})
+}
+
+func (f *fakeConnection) close(reason *amqp091.Error) {
+	if f.closed {
+		return
+	}
+
+	f.closed = true
+	for _, receiver := range f.notify {
+		if reason != nil {
+			receiver <- reason
+		}
+		close(receiver)
+	}
+
+	f.channel.close()
+}
+
+type fakeChannel struct {
+	mu         sync.Mutex
+	acked      bool
+	confirm    bool
+	closed     bool
+	notify     []chan *amqp091.Error
+	declared   map[string]amqp091.Table
+	published  []amqp091.Publishing
+	deliveries map[string]chan amqp091.Delivery
+}
+
+func (f *fakeChannel) Confirm(noWait bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.confirm = true
+	return nil
+}
+
+func (f *fakeChannel) Qos(prefetchCount, prefetchSize int, global bool) error {
+	return nil
+}
+
+func (f *fakeChannel) QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp091.Table) (amqp091.Queue, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.declared[name] = args
+	return amqp091.Queue{Name: name}, nil
+}
+
+func (f *fakeChannel) Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp091.Table) (<-chan amqp091.Delivery, error) {
+	return f.queue(queue), nil
+}
+
+func (f *fakeChannel) Get(queue string, autoAck bool) (amqp091.Delivery, bool, error) {
+	return amqp091.Delivery{}, false, nil
+}
+
+func (f *fakeChannel) PublishAndConfirm(ctx context.Context, queueName string, publishing amqp091.Publishing) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return false, amqp091.ErrClosed
+	}
+
+	f.published = append(f.published, publishing)
+	return f.confirm && f.acked, nil
+}
+
+func (f *fakeChannel) NotifyClose(receiver chan *amqp091.Error) chan *amqp091.Error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		close(receiver)
+		return receiver
+	}
+
+	f.notify = append(f.notify, receiver)
+	return receiver
+}
+
+func (f *fakeChannel) IsClosed() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.closed
+}
+
+func (f *fakeChannel) Close() error {
+	f.close()
+	return nil
+}
+
+func (f *fakeChannel) close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return
+	}
+
+	f.closed = true
+	for _, receiver := range f.notify {
+		close(receiver)
+	}
+
+	for _, deliveries := range f.deliveries {
+		close(deliveries)
+	}
+}
+
+func (f *fakeChannel) queue(name string) chan amqp091.Delivery {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	deliveries, ok := f.deliveries[name]
+	if !ok {
+		deliveries = make(chan amqp091.Delivery, 1)
+		f.deliveries[name] = deliveries
+	}
+
+	return deliveries
+}
+
+func (f *fakeChannel) declaredQueues() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	queues := make([]string, 0, len(f.declared))
+	for name := range f.declared {
+		queues = append(queues, name)
+	}
+
+	return queues
+}
+
+func (f *fakeChannel) publishedBodies() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	bodies := make([]string, 0, len(f.published))
+	for _, publishing := range f.published {
+		bodies = append(bodies, string(publishing.Body))
+	}
+
+	return bodies
+}
+
+// newTestRabbitMQClient dials the given connections in order.
+func newTestRabbitMQClient(t *testing.T, connections ...*fakeConnection) *rabbitMQClient {
+	t.Helper()
+
+	var mu sync.Mutex
+	dials := 0
+
+	rabbitMQClient := &rabbitMQClient{
+		dialer: func() (amqpConnection, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			connection := connections[min(dials, len(connections)-1)]
+			dials++
+			return connection, nil
+		},
+		declared: make(map[string]QueueOptions),
+		ready:    make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	t.Cleanup(func() {
+		rabbitMQClient.Disconnect()
+	})
+
+	return rabbitMQClient
+}
+
+func TestRabbitMQClient_Publish(t *testing.T) {
+	t.Run("should put the channel in confirm mode and return once the broker acks", func(t *testing.T) {
+		connection := newFakeConnection(true)
+		rabbitMQClient := newTestRabbitMQClient(t, connection)
+		require.NoError(t, rabbitMQClient.Connect())
+
+		err := rabbitMQClient.Publish("orders", []byte("order created"))
+
+		assert.NoError(t, err)
+		assert.True(t, connection.channel.confirm)
+		assert.Equal(t, []string{"order created"}, connection.channel.publishedBodies())
+		assert.Equal(t, []string{"orders"}, connection.channel.declaredQueues())
+	})
+
+	t.Run("should return error when the broker nacks the message", func(t *testing.T) {
+		connection := newFakeConnection(false)
+		rabbitMQClient := newTestRabbitMQClient(t, connection)
+		require.NoError(t, rabbitMQClient.Connect())
+
+		err := rabbitMQClient.Publish("orders", []byte("order created"))
+
+		assert.ErrorIs(t, err, ErrPublishNotConfirmed)
+	})
+
+	t.Run("should return error after the client is disconnected", func(t *testing.T) {
+		rabbitMQClient := newTestRabbitMQClient(t, newFakeConnection(true))
+		require.NoError(t, rabbitMQClient.Connect())
+		require.NoError(t, rabbitMQClient.Disconnect())
+
+		err := rabbitMQClient.Publish("orders", []byte("order created"))
+
+		assert.Error(t, err)
+		assert.False(t, rabbitMQClient.IsConnected())
+	})
+}
+
+func TestRabbitMQClient_Reconnect(t *testing.T) {
+	t.Run("should reconnect, redeclare the queues and publish on the new channel", func(t *testing.T) {
+		first := newFakeConnection(true)
+		second := newFakeConnection(true)
+		rabbitMQClient := newTestRabbitMQClient(t, first, second)
+		require.NoError(t, rabbitMQClient.Connect())
+
+		options := QueueOptions{MessageTTL: time.Minute, DeadLetterQueue: "orders"}
+		require.NoError(t, rabbitMQClient.DeclareQueue("orders_retry", options))
+
+		first.drop()
+		assert.False(t, rabbitMQClient.IsConnected())
+		require.Eventually(t, rabbitMQClient.IsConnected, 3*time.Second, 10*time.Millisecond)
+
+		err := rabbitMQClient.Publish("orders", []byte("after reconnect"))
+
+		assert.NoError(t, err)
+		assert.True(t, second.channel.confirm)
+		assert.Equal(t, []string{"after reconnect"}, second.channel.publishedBodies())
+		assert.Equal(t, "orders", second.channel.declared["orders_retry"]["x-dead-letter-routing-key"])
+	})
+
+	t.Run("should keep delivering to consumers across reconnections", func(t *testing.T) {
+		first := newFakeConnection(true)
+		second := newFakeConnection(true)
+		rabbitMQClient := newTestRabbitMQClient(t, first, second)
+		require.NoError(t, rabbitMQClient.Connect())
+
+		messages, err := rabbitMQClient.Consume("orders")
+		require.NoError(t, err)
+
+		first.channel.queue("orders") <- amqp091.Delivery{Body: []byte("before")}
+		assert.Equal(t, "before", string(receive(t, messages).Body))
+
+		first.drop()
+		second.channel.queue("orders") <- amqp091.Delivery{Body: []byte("after")}
+
+		assert.Equal(t, "after", string(receive(t, messages).Body))
+	})
+
+	t.Run("should close the consumers when disconnected", func(t *testing.T) {
+		connection := newFakeConnection(true)
+		rabbitMQClient := newTestRabbitMQClient(t, connection)
+		require.NoError(t, rabbitMQClient.Connect())
+
+		messages, err := rabbitMQClient.Consume("orders")
+		require.NoError(t, err)
+
+		require.NoError(t, rabbitMQClient.Disconnect())
+
+		select {
+		case _, ok := <-messages:
+			assert.False(t, ok)
+		case <-time.After(time.Second):
+			t.Fatal("consumer was not closed")
+		}
+	})
+}
+
+func receive(t *testing.T, messages <-chan *Message) *Message {
+	t.Helper()
+
+	select {
+	case message, ok := <-messages:
+		require.True(t, ok, "consumer closed")
+		return message
+	case <-time.After(3 * time.Second):
+		t.Fatal("no message received")
+		return nil
+	}
+}
@@ -0,0 +1,299 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/G-Villarinho/food-shop-api/cmd/api/responses"
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/G-Villarinho/food-shop-api/services"
+	"github.com/G-Villarinho/food-shop-api/utils"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate mockery --name=AdminHandler --output=../../../mocks --outpkg=mocks
+type AdminHandler interface {
+	GetUsers(ctx echo.Context) error
+	BlockUser(ctx echo.Context) error
+	UnblockUser(ctx echo.Context) error
+	ImpersonateUser(ctx echo.Context) error
+	GetRestaurants(ctx echo.Context) error
+	SuspendRestaurant(ctx echo.Context) error
+	ReactivateRestaurant(ctx echo.Context) error
+	GetOrder(ctx echo.Context) error
+	DeleteEvaluation(ctx echo.Context) error
+}
+
+type adminHandler struct {
+	di           *internal.Di
+	adminService services.AdminService
+}
+
+func NewAdminHandler(di *internal.Di) (AdminHandler, error) {
+	adminService, err := internal.Invoke[services.AdminService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &adminHandler{
+		di:           di,
+		adminService: adminService,
+	}, nil
+}
+
+func (a *adminHandler) GetUsers(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "admin"),
+		slog.String("func", "GetUsers"),
+	)
+
+	pagination, err := models.NewPagination(ctx.QueryParam("page"), ctx.QueryParam("limit"), ctx.QueryParam("sort"))
+	if err != nil {
+		log.Error(err.Error())
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_pagination", "Parâmetros de paginação inválidos")
+	}
+
+	userPagination := &models.UserPagination{
+		Pagination: *pagination,
+		Search:     utils.GetQueryStringPointer(ctx.QueryParam("search")),
+		Role:       utils.GetQueryStringPointer(ctx.QueryParam("role")),
+		Status:     utils.GetQueryStringPointer(ctx.QueryParam("status")),
+	}
+
+	response, err := a.adminService.GetPaginatedUsers(ctx.Request().Context(), userPagination)
+	if err != nil {
+		log.Error(err.Error())
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (a *adminHandler) BlockUser(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "admin"),
+		slog.String("func", "BlockUser"),
+	)
+
+	userID, err := uuid.Parse(ctx.Param("userId"))
+	if err != nil {
+		log.Error(err.Error())
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_user_id", "Usuário inválido")
+	}
+
+	if err := a.adminService.BlockUser(ctx.Request().Context(), userID); err != nil {
+		log.Error(err.Error())
+		return userStatusErrorResponse(ctx, err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (a *adminHandler) UnblockUser(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "admin"),
+		slog.String("func", "UnblockUser"),
+	)
+
+	userID, err := uuid.Parse(ctx.Param("userId"))
+	if err != nil {
+		log.Error(err.Error())
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_user_id", "Usuário inválido")
+	}
+
+	if err := a.adminService.UnblockUser(ctx.Request().Context(), userID); err != nil {
+		log.Error(err.Error())
+		return userStatusErrorResponse(ctx, err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (a *adminHandler) ImpersonateUser(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "admin"),
+		slog.String("func", "ImpersonateUser"),
+	)
+
+	userID, err := uuid.Parse(ctx.Param("userId"))
+	if err != nil {
+		log.Error(err.Error())
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_user_id", "Usuário inválido")
+	}
+
+	token, err := a.adminService.ImpersonateUser(ctx.Request().Context(), userID)
+	if err != nil {
+		log.Error(err.Error())
+
+		if errors.Is(err, models.ErrUserNotFoundInContext) {
+			return responses.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		if errors.Is(err, models.ErrUserNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "Usuário não encontrado")
+		}
+
+		if errors.Is(err, models.ErrCannotImpersonateUser) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusForbidden, "forbidden", "Não é permitido acessar a conta de outro administrador")
+		}
+
+		if errors.Is(err, models.ErrRestaurantNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusConflict, "no_restaurant", "O gerente não faz parte de nenhum restaurante")
+		}
+
+		if errors.Is(err, models.ErrUserBlocked) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusConflict, "account_blocked", "Não é permitido acessar a conta de um usuário bloqueado")
+		}
+
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	adminID, _ := ctx.Request().Context().Value(internal.UserIDKey).(uuid.UUID)
+	log.Info("Admin impersonating user", slog.String("userId", userID.String()), slog.String("impersonatedBy", adminID.String()))
+
+	// The token goes in the body only, so the admin's own session cookie is kept.
+	return ctx.JSON(http.StatusOK, &models.AuthTokenResponse{Token: token})
+}
+
+func (a *adminHandler) GetRestaurants(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "admin"),
+		slog.String("func", "GetRestaurants"),
+	)
+
+	pagination, err := models.NewPagination(ctx.QueryParam("page"), ctx.QueryParam("limit"), ctx.QueryParam("sort"))
+	if err != nil {
+		log.Error(err.Error())
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_pagination", "Parâmetros de paginação inválidos")
+	}
+
+	restaurantPagination := &models.RestaurantPagination{
+		Pagination: *pagination,
+		Search:     utils.GetQueryStringPointer(ctx.QueryParam("search")),
+		Status:     utils.GetQueryStringPointer(ctx.QueryParam("status")),
+	}
+
+	response, err := a.adminService.GetPaginatedRestaurants(ctx.Request().Context(), restaurantPagination)
+	if err != nil {
+		log.Error(err.Error())
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (a *adminHandler) SuspendRestaurant(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "admin"),
+		slog.String("func", "SuspendRestaurant"),
+	)
+
+	restaurantID, err := uuid.Parse(ctx.Param("restaurantId"))
+	if err != nil {
+		log.Error(err.Error())
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_restaurant_id", "Restaurante inválido")
+	}
+
+	if err := a.adminService.SuspendRestaurant(ctx.Request().Context(), restaurantID); err != nil {
+		log.Error(err.Error())
+
+		if errors.Is(err, models.ErrRestaurantNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "Restaurante não encontrado")
+		}
+
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (a *adminHandler) ReactivateRestaurant(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "admin"),
+		slog.String("func", "ReactivateRestaurant"),
+	)
+
+	restaurantID, err := uuid.Parse(ctx.Param("restaurantId"))
+	if err != nil {
+		log.Error(err.Error())
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_restaurant_id", "Restaurante inválido")
+	}
+
+	if err := a.adminService.ReactivateRestaurant(ctx.Request().Context(), restaurantID); err != nil {
+		log.Error(err.Error())
+
+		if errors.Is(err, models.ErrRestaurantNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "Restaurante não encontrado")
+		}
+
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (a *adminHandler) GetOrder(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "admin"),
+		slog.String("func", "GetOrder"),
+	)
+
+	orderID, err := uuid.Parse(ctx.Param("orderId"))
+	if err != nil {
+		log.Error(err.Error())
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_order_id", "Pedido inválido")
+	}
+
+	response, err := a.adminService.GetOrder(ctx.Request().Context(), orderID)
+	if err != nil {
+		log.Error(err.Error())
+
+		if errors.Is(err, models.ErrorOrderNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "Pedido não encontrado")
+		}
+
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (a *adminHandler) DeleteEvaluation(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "admin"),
+		slog.String("func", "DeleteEvaluation"),
+	)
+
+	evaluationID, err := uuid.Parse(ctx.Param("evaluationId"))
+	if err != nil {
+		log.Error(err.Error())
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_evaluation_id", "Avaliação inválida")
+	}
+
+	if err := a.adminService.DeleteEvaluation(ctx.Request().Context(), evaluationID); err != nil {
+		log.Error(err.Error())
+
+		if errors.Is(err, models.ErrEvaluationNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "Avaliação não encontrada")
+		}
+
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func userStatusErrorResponse(ctx echo.Context, err error) error {
+	if errors.Is(err, models.ErrUserNotFound) {
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "Usuário não encontrado")
+	}
+
+	if errors.Is(err, models.ErrCannotBlockUser) {
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusForbidden, "forbidden", "Não é permitido alterar o status de um administrador")
+	}
+
+	return responses.InternalServerAPIErrorResponse(ctx)
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/G-Villarinho/food-shop-api/cmd/api/responses"
+	"github.com/G-Villarinho/food-shop-api/cmd/api/validation"
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/G-Villarinho/food-shop-api/services"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate mockery --name=APIKeyHandler --output=../../../mocks --outpkg=mocks
+type APIKeyHandler interface {
+	CreateAPIKey(ctx echo.Context) error
+	GetAPIKeys(ctx echo.Context) error
+	RevokeAPIKey(ctx echo.Context) error
+}
+
+type apiKeyHandler struct {
+	di            *internal.Di
+	apiKeyService services.APIKeyService
+}
+
+func NewAPIKeyHandler(di *internal.Di) (APIKeyHandler, error) {
+	apiKeyService, err := internal.Invoke[services.APIKeyService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiKeyHandler{
+		di:            di,
+		apiKeyService: apiKeyService,
+	}, nil
+}
+
+func (a *apiKeyHandler) CreateAPIKey(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "api_key"),
+		slog.String("func", "CreateAPIKey"),
+	)
+
+	var payload models.CreateAPIKeyPayload
+	if err := jsoniter.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		log.Warn("Error to decode JSON payload", slog.String("error", err.Error()))
+		return responses.CannotBindPayloadAPIErrorResponse(ctx)
+	}
+
+	if err := validation.ValidateStruct(payload); err != nil {
+		log.Warn("Error to validate JSON payload")
+		return responses.NewValidationErrorResponse(ctx, err)
+	}
+
+	response, err := a.apiKeyService.CreateAPIKey(ctx.Request().Context(), payload)
+	if err != nil {
+		log.Error(err.Error())
+
+		if errors.Is(err, models.ErrRestaurantNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "Restaurante não encontrado")
+		}
+
+		if errors.Is(err, models.ErrUserNotFoundInContext) {
+			return responses.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		if errors.Is(err, models.ErrAPIKeyScopeUnknown) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_scope", "Um ou mais escopos informados não podem ser atribuídos a uma chave de API.")
+		}
+
+		if errors.Is(err, models.ErrAPIKeyScopeDenied) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusForbidden, "forbidden", "Você não pode conceder a uma chave de API permissões que não possui.")
+		}
+
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.JSON(http.StatusCreated, response)
+}
+
+func (a *apiKeyHandler) GetAPIKeys(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "api_key"),
+		slog.String("func", "GetAPIKeys"),
+	)
+
+	response, err := a.apiKeyService.GetAPIKeys(ctx.Request().Context())
+	if err != nil {
+		log.Error(err.Error())
+
+		if errors.Is(err, models.ErrRestaurantNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "Restaurante não encontrado")
+		}
+
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (a *apiKeyHandler) RevokeAPIKey(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "api_key"),
+		slog.String("func", "RevokeAPIKey"),
+	)
+
+	apiKeyID, err := uuid.Parse(ctx.Param("apiKeyId"))
+	if err != nil {
+		log.Warn("Invalid API key ID format")
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_api_key_id", "Chave de API inválida")
+	}
+
+	if err := a.apiKeyService.RevokeAPIKey(ctx.Request().Context(), apiKeyID); err != nil {
+		log.Error(err.Error())
+
+		if errors.Is(err, models.ErrRestaurantNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "Restaurante não encontrado")
+		}
+
+		if errors.Is(err, models.ErrAPIKeyNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "Chave de API não encontrada")
+		}
+
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
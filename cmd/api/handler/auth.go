@@ -2,6 +2,7 @@ package handler
 
 import (
 	"errors"
+	"io"
 	"log/slog"
 	"net/http"
 
@@ -20,12 +21,16 @@ import (
 type AuthHandler interface {
 	SignIn(ctx echo.Context) error
 	VeryfyMagicLink(ctx echo.Context) error
+	VerifySignInCode(ctx echo.Context) error
+	RefreshToken(ctx echo.Context) error
 	SignOut(ctx echo.Context) error
+	GetJWKS(ctx echo.Context) error
 }
 
 type authHandler struct {
 	di          *internal.Di
 	authService services.AuthService
+	keyRing     services.KeyRing
 }
 
 func NewAuthHandler(di *internal.Di) (AuthHandler, error) {
@@ -34,9 +39,15 @@ func NewAuthHandler(di *internal.Di) (AuthHandler, error) {
 		return nil, err
 	}
 
+	keyRing, err := internal.Invoke[services.KeyRing](di)
+	if err != nil {
+		return nil, err
+	}
+
 	return &authHandler{
 		di:          di,
 		authService: authService,
+		keyRing:     keyRing,
 	}, nil
 }
 
@@ -57,13 +68,21 @@ func (a *authHandler) SignIn(ctx echo.Context) error {
 		return responses.NewValidationErrorResponse(ctx, err)
 	}
 
-	if err := a.authService.SignIn(ctx.Request().Context(), payload.Email); err != nil {
-		log.Error(err.Error())
+	signIn := a.authService.SignIn
+	if payload.Method == models.CodeSignIn {
+		signIn = a.authService.SignInWithCode
+	}
 
-		if errors.Is(err, models.ErrUserNotFound) {
-			return responses.NewCustomValidationAPIErrorResponse(ctx, 404, "not_found", "Não foi encontrado um usuário com o e-mail informado. Por favor, verifique e tente novamente.")
+	if err := signIn(ctx.Request().Context(), payload.Email); err != nil {
+		// Unknown, blocked and locked accounts get the same answer as a
+		// successful request, so this endpoint can't be used to find out
+		// which e-mails are registered.
+		if errors.Is(err, models.ErrUserNotFound) || errors.Is(err, models.ErrUserBlocked) || errors.Is(err, models.ErrTooManySignInAttempts) {
+			log.Warn(err.Error())
+			return ctx.NoContent(http.StatusOK)
 		}
 
+		log.Error(err.Error())
 		return responses.InternalServerAPIErrorResponse(ctx)
 	}
 
@@ -81,18 +100,20 @@ func (a *authHandler) VeryfyMagicLink(ctx echo.Context) error {
 		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_request", "O código do link mágico está em um formato inválido. Verifique o link e tente novamente.")
 	}
 
+	tokenMode := ctx.QueryParam("mode") == models.TokenResponseMode
+
 	redirectURL := ctx.QueryParam("redirect")
-	if redirectURL == "" {
+	if redirectURL == "" && !tokenMode {
 		log.Warn("Redirect URL is missing")
 		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_request", "É necessário informar uma URL de redirecionamento para continuar.")
 	}
 
-	if redirectURL != config.Env.RedirectURL {
+	if redirectURL != "" && redirectURL != config.Env.RedirectURL {
 		log.Warn("Redirect URL is invalid")
 		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_request", "A URL de redirecionamento informada não é válida. Entre em contato com o suporte.")
 	}
 
-	token, err := a.authService.VeryfyMagicLink(ctx.Request().Context(), code)
+	response, err := a.authService.VeryfyMagicLink(ctx.Request().Context(), code)
 	if err != nil {
 		log.Error(err.Error())
 
@@ -104,21 +125,127 @@ func (a *authHandler) VeryfyMagicLink(ctx echo.Context) error {
 			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "Não encontramos nenhum usuário associado a este link mágico. Verifique e tente novamente.")
 		}
 
+		if errors.Is(err, models.ErrUserBlocked) {
+			return responses.AccountBlockedAPIErrorResponse(ctx)
+		}
+
+		if errors.Is(err, models.ErrRestaurantNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusForbidden, "no_restaurant", "Você não faz parte de nenhum restaurante. Peça um novo convite para acessar.")
+		}
+
 		return responses.InternalServerAPIErrorResponse(ctx)
 	}
 
-	cookie := new(http.Cookie)
-	cookie.Name = config.Env.CookieName
-	cookie.Value = token
-	cookie.Path = "/"
-	cookie.HttpOnly = true
-	cookie.Secure = false
-	cookie.SameSite = http.SameSiteLaxMode
-	ctx.SetCookie(cookie)
+	if tokenMode {
+		return ctx.JSON(http.StatusOK, response)
+	}
+
+	setAuthCookies(ctx, response)
 
 	return ctx.Redirect(http.StatusFound, redirectURL)
 }
 
+func (a *authHandler) VerifySignInCode(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "auth"),
+		slog.String("func", "VerifySignInCode"),
+	)
+
+	var payload models.VerifySignInCodePayload
+	if err := jsoniter.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		log.Warn("Error to decode JSON payload", slog.String("error", err.Error()))
+		return responses.CannotBindPayloadAPIErrorResponse(ctx)
+	}
+
+	if err := validation.ValidateStruct(payload); err != nil {
+		log.Warn("Error to validate JSON payload")
+		return responses.NewValidationErrorResponse(ctx, err)
+	}
+
+	response, err := a.authService.VerifySignInCode(ctx.Request().Context(), payload)
+	if err != nil {
+		log.Error(err.Error())
+
+		if errors.Is(err, models.ErrInvalidSignInCode) || errors.Is(err, models.ErrSignInCodeNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusUnauthorized, "invalid_code", "O código informado é inválido ou expirou. Verifique e tente novamente.")
+		}
+
+		if errors.Is(err, models.ErrTooManySignInAttempts) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusTooManyRequests, "too_many_attempts", "Muitas tentativas de acesso com código. Aguarde alguns minutos e tente novamente.")
+		}
+
+		if errors.Is(err, models.ErrUserBlocked) {
+			return responses.AccountBlockedAPIErrorResponse(ctx)
+		}
+
+		if errors.Is(err, models.ErrRestaurantNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusForbidden, "no_restaurant", "Você não faz parte de nenhum restaurante. Peça um novo convite para acessar.")
+		}
+
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	if ctx.QueryParam("mode") == models.TokenResponseMode {
+		return ctx.JSON(http.StatusOK, response)
+	}
+
+	setAuthCookies(ctx, response)
+
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (a *authHandler) RefreshToken(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "auth"),
+		slog.String("func", "RefreshToken"),
+	)
+
+	var payload models.RefreshTokenPayload
+	if err := jsoniter.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil && !errors.Is(err, io.EOF) {
+		log.Warn("Error to decode JSON payload", slog.String("error", err.Error()))
+		return responses.CannotBindPayloadAPIErrorResponse(ctx)
+	}
+
+	// Clients that keep the refresh token themselves send it in the body and
+	// get the new pair back in JSON; browsers rely on the refresh cookie.
+	tokenMode := payload.RefreshToken != ""
+	if !tokenMode {
+		if cookie, err := ctx.Cookie(config.Env.RefreshCookieName); err == nil {
+			payload.RefreshToken = cookie.Value
+		}
+	}
+
+	response, err := a.authService.RefreshToken(ctx.Request().Context(), payload.RefreshToken)
+	if err != nil {
+		log.Error(err.Error())
+
+		if errors.Is(err, models.ErrInvalidRefreshToken) {
+			clearAuthCookies(ctx)
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusUnauthorized, "invalid_refresh_token", "Sua sessão expirou. Faça login novamente.")
+		}
+
+		if errors.Is(err, models.ErrRefreshTokenReused) {
+			clearAuthCookies(ctx)
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusUnauthorized, "refresh_token_reused", "Detectamos o uso de uma sessão já renovada e ela foi encerrada por segurança. Faça login novamente.")
+		}
+
+		if errors.Is(err, models.ErrUserBlocked) {
+			clearAuthCookies(ctx)
+			return responses.AccountBlockedAPIErrorResponse(ctx)
+		}
+
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	if tokenMode {
+		return ctx.JSON(http.StatusOK, response)
+	}
+
+	setAuthCookies(ctx, response)
+
+	return ctx.NoContent(http.StatusOK)
+}
+
 func (a *authHandler) SignOut(ctx echo.Context) error {
 	log := slog.With(
 		slog.String("handler", "auth"),
@@ -127,17 +254,20 @@ func (a *authHandler) SignOut(ctx echo.Context) error {
 
 	if err := a.authService.SignOut(ctx.Request().Context()); err != nil {
 		log.Error(err.Error())
+
+		if errors.Is(err, models.ErrSessionNotFound) {
+			return responses.AccessDeniedAPIErrorResponse(ctx)
+		}
+
 		return responses.InternalServerAPIErrorResponse(ctx)
 	}
 
-	cookie := new(http.Cookie)
-	cookie.Name = config.Env.CookieName
-	cookie.Value = ""
-	cookie.Path = "/"
-	cookie.HttpOnly = true
-	cookie.Secure = false
-	cookie.SameSite = http.SameSiteLaxMode
-	ctx.SetCookie(cookie)
+	clearAuthCookies(ctx)
 
 	return ctx.NoContent(http.StatusOK)
 }
+
+func (a *authHandler) GetJWKS(ctx echo.Context) error {
+	ctx.Response().Header().Set("Cache-Control", "public, max-age=300")
+	return ctx.JSON(http.StatusOK, a.keyRing.JWKS())
+}
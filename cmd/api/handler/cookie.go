@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/G-Villarinho/food-shop-api/config"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/labstack/echo/v4"
+)
+
+const refreshCookiePath = "/v1/auth"
+
+func setAuthCookies(ctx echo.Context, response *models.AuthTokenResponse) {
+	cookie := new(http.Cookie)
+	cookie.Name = config.Env.CookieName
+	cookie.Value = response.Token
+	cookie.Path = "/"
+	cookie.HttpOnly = true
+	cookie.Secure = false
+	cookie.SameSite = http.SameSiteLaxMode
+	ctx.SetCookie(cookie)
+
+	if response.RefreshToken == "" {
+		clearRefreshCookie(ctx)
+		return
+	}
+
+	refreshCookie := new(http.Cookie)
+	refreshCookie.Name = config.Env.RefreshCookieName
+	refreshCookie.Value = response.RefreshToken
+	refreshCookie.Path = refreshCookiePath
+	refreshCookie.HttpOnly = true
+	refreshCookie.Secure = false
+	refreshCookie.SameSite = http.SameSiteStrictMode
+	ctx.SetCookie(refreshCookie)
+}
+
+func clearAuthCookies(ctx echo.Context) {
+	cookie := new(http.Cookie)
+	cookie.Name = config.Env.CookieName
+	cookie.Value = ""
+	cookie.Path = "/"
+	cookie.MaxAge = -1
+	cookie.HttpOnly = true
+	cookie.Secure = false
+	cookie.SameSite = http.SameSiteLaxMode
+	ctx.SetCookie(cookie)
+
+	clearRefreshCookie(ctx)
+}
+
+func clearRefreshCookie(ctx echo.Context) {
+	cookie := new(http.Cookie)
+	cookie.Name = config.Env.RefreshCookieName
+	cookie.Value = ""
+	cookie.Path = refreshCookiePath
+	cookie.MaxAge = -1
+	cookie.HttpOnly = true
+	cookie.Secure = false
+	cookie.SameSite = http.SameSiteStrictMode
+	ctx.SetCookie(cookie)
+}
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/G-Villarinho/food-shop-api/cmd/api/responses"
+	"github.com/G-Villarinho/food-shop-api/cmd/api/validation"
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/G-Villarinho/food-shop-api/services"
+	"github.com/G-Villarinho/food-shop-api/utils"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate mockery --name=CourierHandler --output=../../../mocks --outpkg=mocks
+type CourierHandler interface {
+	CreateCourier(ctx echo.Context) error
+	GetDeliveries(ctx echo.Context) error
+	CompleteDelivery(ctx echo.Context) error
+	UpdateLocation(ctx echo.Context) error
+}
+
+type courierHandler struct {
+	di              *internal.Di
+	orderService    services.OrderService
+	trackingService services.TrackingService
+	userService     services.UserService
+}
+
+func NewCourierHandler(di *internal.Di) (CourierHandler, error) {
+	orderService, err := internal.Invoke[services.OrderService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	trackingService, err := internal.Invoke[services.TrackingService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	userService, err := internal.Invoke[services.UserService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &courierHandler{
+		di:              di,
+		orderService:    orderService,
+		trackingService: trackingService,
+		userService:     userService,
+	}, nil
+}
+
+func (c *courierHandler) CreateCourier(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "courier"),
+		slog.String("func", "CreateCourier"),
+	)
+
+	var payload models.CreateUserPayload
+	if err := jsoniter.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		log.Warn("Error to decode JSON payload", slog.String("error", err.Error()))
+		return responses.CannotBindPayloadAPIErrorResponse(ctx)
+	}
+
+	if err := validation.ValidateStruct(payload); err != nil {
+		log.Warn("Error to validate JSON payload")
+		return responses.NewValidationErrorResponse(ctx, err)
+	}
+
+	if _, err := c.userService.CreateUser(ctx.Request().Context(), payload, models.Courier); err != nil {
+		log.Error(err.Error())
+
+		if errors.Is(err, models.ErrEmailAlreadyExists) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusConflict, "conflict", "O email informado já está em uso. Por favor, informe outro.")
+		}
+
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.NoContent(http.StatusCreated)
+}
+
+func (c *courierHandler) GetDeliveries(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "courier"),
+		slog.String("func", "GetDeliveries"),
+	)
+
+	pagination, err := models.NewPagination(ctx.QueryParam("page"), ctx.QueryParam("limit"), ctx.QueryParam("sort"))
+	if err != nil {
+		log.Error(err.Error())
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_pagination", "Invalid pagination paramenter")
+	}
+
+	deliveryPagination := &models.DeliveryPagination{
+		Pagination: *pagination,
+		Status:     utils.GetQueryStringPointer(ctx.QueryParam("status")),
+	}
+
+	response, err := c.orderService.GetPaginatedDeliveriesByCourierID(ctx.Request().Context(), deliveryPagination)
+	if err != nil {
+		log.Error(err.Error())
+
+		if errors.Is(err, models.ErrUserNotFoundInContext) {
+			return responses.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (c *courierHandler) CompleteDelivery(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "courier"),
+		slog.String("func", "CompleteDelivery"),
+	)
+
+	orderID, err := uuid.Parse(ctx.Param("orderId"))
+	if err != nil {
+		log.Error(err.Error())
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_order_id", "Pedido inválido")
+	}
+
+	if err := c.orderService.CompleteDelivery(ctx.Request().Context(), orderID); err != nil {
+		log.Error(err.Error())
+
+		if errors.Is(err, models.ErrUserNotFoundInContext) {
+			return responses.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		if errors.Is(err, models.ErrorOrderNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "Pedido não encontrado no nosso sistema")
+		}
+
+		if errors.Is(err, models.ErrOrderNotAssignedToCourier) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusForbidden, "order_not_assigned_to_courier", "O pedido não está atribuído a você")
+		}
+
+		if errors.Is(err, models.ErrOrderCannotBeDelivered) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "order_cannot_be_delivered", "O pedido só pode ser entregue se estiver com status 'Em entrega'")
+		}
+
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (c *courierHandler) UpdateLocation(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "courier"),
+		slog.String("func", "UpdateLocation"),
+	)
+
+	orderID, err := uuid.Parse(ctx.Param("orderId"))
+	if err != nil {
+		log.Error(err.Error())
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_order_id", "Pedido inválido")
+	}
+
+	var payload models.UpdateCourierLocationPayload
+	if err := jsoniter.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		log.Warn("Error to decode JSON payload", slog.String("error", err.Error()))
+		return responses.CannotBindPayloadAPIErrorResponse(ctx)
+	}
+
+	if err := validation.ValidateStruct(payload); err != nil {
+		log.Warn("Error to validate JSON payload")
+		return responses.NewValidationErrorResponse(ctx, err)
+	}
+
+	if err := c.trackingService.UpdateCourierLocation(ctx.Request().Context(), orderID, payload); err != nil {
+		log.Error(err.Error())
+
+		if errors.Is(err, models.ErrUserNotFoundInContext) {
+			return responses.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		if errors.Is(err, models.ErrorOrderNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "Pedido não encontrado no nosso sistema")
+		}
+
+		if errors.Is(err, models.ErrOrderNotAssignedToCourier) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusForbidden, "order_not_assigned_to_courier", "O pedido não está atribuído a você")
+		}
+
+		if errors.Is(err, models.ErrOrderIsNotBeingDelivered) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "order_is_not_being_delivered", "O pedido não está em rota de entrega")
+		}
+
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/services"
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate mockery --name=HealthHandler --output=../../../mocks --outpkg=mocks
+type HealthHandler interface {
+	Check(ctx echo.Context) error
+}
+
+type healthHandler struct {
+	di            *internal.Di
+	healthService services.HealthService
+}
+
+func NewHealthHandler(di *internal.Di) (HealthHandler, error) {
+	healthService, err := internal.Invoke[services.HealthService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &healthHandler{
+		di:            di,
+		healthService: healthService,
+	}, nil
+}
+
+func (h *healthHandler) Check(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "health"),
+		slog.String("func", "Check"),
+	)
+
+	response := h.healthService.Check(ctx.Request().Context())
+	if !response.IsHealthy() {
+		log.Warn("Health check failed", slog.Any("checks", response.Checks))
+		return ctx.JSON(http.StatusServiceUnavailable, response)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
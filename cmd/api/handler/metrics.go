@@ -14,6 +14,7 @@ import (
 
 type MetricsHandler interface {
 	GetMonthlyMetrics(ctx echo.Context) error
+	GetMonthlyTips(ctx echo.Context) error
 }
 
 type metricsHandler struct {
@@ -56,3 +57,23 @@ func (m *metricsHandler) GetMonthlyMetrics(ctx echo.Context) error {
 
 	return ctx.JSON(http.StatusOK, response)
 }
+
+func (m *metricsHandler) GetMonthlyTips(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "metrics"),
+		slog.String("func", "GetMonthlyTips"),
+	)
+
+	response, err := m.metricsService.GetMonthTips(ctx.Request().Context())
+	if err != nil {
+		log.Error(err.Error())
+
+		if errors.Is(err, models.ErrRestaurantNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, 404, "not_found", "Restaurante não encontrado")
+		}
+
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
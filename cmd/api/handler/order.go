@@ -2,15 +2,20 @@ package handler
 
 import (
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/G-Villarinho/food-shop-api/cmd/api/responses"
+	"github.com/G-Villarinho/food-shop-api/config"
 	"github.com/G-Villarinho/food-shop-api/internal"
 	"github.com/G-Villarinho/food-shop-api/models"
 	"github.com/G-Villarinho/food-shop-api/services"
 	"github.com/G-Villarinho/food-shop-api/utils"
 	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
 	"github.com/labstack/echo/v4"
 )
 
@@ -21,11 +26,15 @@ type OrderHandler interface {
 	ApproveOrder(ctx echo.Context) error
 	DispatchOrder(ctx echo.Context) error
 	DeliverOrder(ctx echo.Context) error
+	GetOrderTracking(ctx echo.Context) error
+	StreamOrderTracking(ctx echo.Context) error
 }
 
 type orderHandler struct {
-	di           *internal.Di
-	orderService services.OrderService
+	di              *internal.Di
+	orderService    services.OrderService
+	trackingService services.TrackingService
+	shutdown        *internal.Shutdown
 }
 
 func NewOrderHandler(di *internal.Di) (OrderHandler, error) {
@@ -34,9 +43,21 @@ func NewOrderHandler(di *internal.Di) (OrderHandler, error) {
 		return nil, err
 	}
 
+	trackingService, err := internal.Invoke[services.TrackingService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	shutdown, err := internal.Invoke[*internal.Shutdown](di)
+	if err != nil {
+		return nil, err
+	}
+
 	return &orderHandler{
-		di:           di,
-		orderService: orderService,
+		di:              di,
+		orderService:    orderService,
+		trackingService: trackingService,
+		shutdown:        shutdown,
 	}, nil
 }
 
@@ -106,7 +127,7 @@ func (o *orderHandler) CancelOrder(ctx echo.Context) error {
 		}
 
 		if errors.Is(err, models.ErrorOrderCannotBeCancelled) {
-			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "order_cannot_be_cancelled", "O pedido só pode ser cancelado se estiver com status 'Pendente' ou 'Em processamento'")
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "order_cannot_be_cancelled", "O pedido só pode ser cancelado se estiver com status 'Agendado', 'Pendente' ou 'Em processamento'")
 		}
 
 		return responses.InternalServerAPIErrorResponse(ctx)
@@ -165,7 +186,13 @@ func (o *orderHandler) DispatchOrder(ctx echo.Context) error {
 		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_order_id", "Pedido inválido")
 	}
 
-	err = o.orderService.DispatchOrder(ctx.Request().Context(), orderID)
+	var payload models.DispatchOrderPayload
+	if err := jsoniter.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil && !errors.Is(err, io.EOF) {
+		log.Warn("Error to decode JSON payload", slog.String("error", err.Error()))
+		return responses.CannotBindPayloadAPIErrorResponse(ctx)
+	}
+
+	err = o.orderService.DispatchOrder(ctx.Request().Context(), orderID, payload.CourierID)
 	if err != nil {
 		log.Error(err.Error())
 
@@ -185,6 +212,10 @@ func (o *orderHandler) DispatchOrder(ctx echo.Context) error {
 			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "order_cannot_be_dispatched", "O pedido só pode ser despachado se estiver com status 'Em processamento'")
 		}
 
+		if errors.Is(err, models.ErrCourierNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "courier_not_found", "Entregador não encontrado no nosso sistema")
+		}
+
 		return responses.InternalServerAPIErrorResponse(ctx)
 	}
 
@@ -228,3 +259,116 @@ func (o *orderHandler) DeliverOrder(ctx echo.Context) error {
 
 	return ctx.NoContent(http.StatusNoContent)
 }
+
+func (o *orderHandler) GetOrderTracking(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "order"),
+		slog.String("func", "GetOrderTracking"),
+	)
+
+	orderID, err := uuid.Parse(ctx.Param("orderId"))
+	if err != nil {
+		log.Error(err.Error())
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_order_id", "Pedido inválido")
+	}
+
+	response, err := o.trackingService.GetOrderTracking(ctx.Request().Context(), orderID)
+	if err != nil {
+		log.Error(err.Error())
+		return trackingErrorResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (o *orderHandler) StreamOrderTracking(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "order"),
+		slog.String("func", "StreamOrderTracking"),
+	)
+
+	orderID, err := uuid.Parse(ctx.Param("orderId"))
+	if err != nil {
+		log.Error(err.Error())
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_order_id", "Pedido inválido")
+	}
+
+	response, err := o.trackingService.GetOrderTracking(ctx.Request().Context(), orderID)
+	if err != nil && !errors.Is(err, models.ErrCourierLocationNotFound) {
+		log.Error(err.Error())
+		return trackingErrorResponse(ctx, err)
+	}
+
+	ctx.Response().Header().Set(echo.HeaderContentType, "text/event-stream")
+	ctx.Response().Header().Set(echo.HeaderCacheControl, "no-cache")
+	ctx.Response().Header().Set(echo.HeaderConnection, "keep-alive")
+	ctx.Response().WriteHeader(http.StatusOK)
+
+	interval := time.Duration(config.Env.Tracking.StreamInterval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if response != nil {
+			data, err := jsoniter.Marshal(response)
+			if err != nil {
+				log.Error(err.Error())
+				return nil
+			}
+
+			fmt.Fprintf(ctx.Response(), "event: location\ndata: %s\n\n", data)
+			ctx.Response().Flush()
+		}
+
+		select {
+		case <-ctx.Request().Context().Done():
+			return nil
+		case <-o.shutdown.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		response, err = o.trackingService.GetOrderTracking(ctx.Request().Context(), orderID)
+		if err != nil {
+			if errors.Is(err, models.ErrCourierLocationNotFound) {
+				continue
+			}
+
+			if !errors.Is(err, models.ErrOrderIsNotBeingDelivered) {
+				log.Error(err.Error())
+			}
+
+			fmt.Fprint(ctx.Response(), "event: end\ndata: {}\n\n")
+			ctx.Response().Flush()
+			return nil
+		}
+	}
+}
+
+func trackingErrorResponse(ctx echo.Context, err error) error {
+	if errors.Is(err, models.ErrUserNotFoundInContext) {
+		return responses.AccessDeniedAPIErrorResponse(ctx)
+	}
+
+	if errors.Is(err, models.ErrorOrderNotFound) {
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "Pedido não encontrado no nosso sistema")
+	}
+
+	if errors.Is(err, models.ErrOrderDoesNotBelongToCustomer) {
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "Pedido não encontrado no nosso sistema")
+	}
+
+	if errors.Is(err, models.ErrOrderIsNotBeingDelivered) {
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "order_is_not_being_delivered", "O pedido não está em rota de entrega")
+	}
+
+	if errors.Is(err, models.ErrCourierLocationNotFound) {
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "courier_location_not_found", "A localização do entregador ainda não está disponível")
+	}
+
+	return responses.InternalServerAPIErrorResponse(ctx)
+}
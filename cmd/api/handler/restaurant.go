@@ -98,10 +98,26 @@ func (r *restaurantHandler) CreateOrder(ctx echo.Context) error {
 			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "O restaurante especificado não foi encontrado. Verifique o ID e tente novamente.")
 		}
 
+		if errors.Is(err, models.ErrRestaurantSuspended) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusConflict, "restaurant_suspended", "O restaurante está temporariamente indisponível para novos pedidos.")
+		}
+
 		if errors.Is(err, models.ErrSomeProductsNotFound) {
 			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "bad_request", "Alguns produtos do pedido não foram encontrados. Verifique os itens do pedido e tente novamente.")
 		}
 
+		if errors.Is(err, models.ErrScheduledTimeTooSoon) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "bad_request", "O horário agendado não respeita a antecedência mínima exigida para pedidos agendados.")
+		}
+
+		if errors.Is(err, models.ErrRestaurantClosedAtScheduledTime) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "bad_request", "O restaurante estará fechado no horário agendado. Escolha outro horário.")
+		}
+
+		if errors.Is(err, models.ErrInvalidTipPercentage) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "bad_request", "A porcentagem da gorjeta deve estar entre 1 e 100.")
+		}
+
 		if errors.Is(err, models.ErrUserNotFoundInContext) {
 			return responses.AccessDeniedAPIErrorResponse(ctx)
 		}
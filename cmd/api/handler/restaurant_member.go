@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/G-Villarinho/food-shop-api/cmd/api/responses"
+	"github.com/G-Villarinho/food-shop-api/cmd/api/validation"
+	"github.com/G-Villarinho/food-shop-api/config"
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/G-Villarinho/food-shop-api/services"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate mockery --name=RestaurantMemberHandler --output=../../../mocks --outpkg=mocks
+type RestaurantMemberHandler interface {
+	InviteMember(ctx echo.Context) error
+	AcceptInvitation(ctx echo.Context) error
+	GetMembers(ctx echo.Context) error
+	RemoveMember(ctx echo.Context) error
+}
+
+type restaurantMemberHandler struct {
+	di                      *internal.Di
+	restaurantMemberService services.RestaurantMemberService
+}
+
+func NewRestaurantMemberHandler(di *internal.Di) (RestaurantMemberHandler, error) {
+	restaurantMemberService, err := internal.Invoke[services.RestaurantMemberService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &restaurantMemberHandler{
+		di:                      di,
+		restaurantMemberService: restaurantMemberService,
+	}, nil
+}
+
+func (r *restaurantMemberHandler) InviteMember(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "restaurant_member"),
+		slog.String("func", "InviteMember"),
+	)
+
+	var payload models.InviteMemberPayload
+	if err := jsoniter.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		log.Warn("Error to decode JSON payload", slog.String("error", err.Error()))
+		return responses.CannotBindPayloadAPIErrorResponse(ctx)
+	}
+
+	if err := validation.ValidateStruct(payload); err != nil {
+		log.Warn("Error to validate JSON payload")
+		return responses.NewValidationErrorResponse(ctx, err)
+	}
+
+	if err := r.restaurantMemberService.InviteMember(ctx.Request().Context(), payload); err != nil {
+		log.Error(err.Error())
+
+		if errors.Is(err, models.ErrRestaurantNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "Restaurante não encontrado")
+		}
+
+		if errors.Is(err, models.ErrUserNotFoundInContext) {
+			return responses.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		if errors.Is(err, models.ErrUserAlreadyMember) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusConflict, "conflict", "O usuário informado já faz parte de um restaurante.")
+		}
+
+		if errors.Is(err, models.ErrUserCannotJoinRestaurant) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusConflict, "conflict", "O e-mail informado pertence a uma conta que não pode fazer parte de um restaurante.")
+		}
+
+		if errors.Is(err, models.ErrUserBlocked) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusConflict, "account_blocked", "O e-mail informado pertence a uma conta suspensa.")
+		}
+
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.NoContent(http.StatusCreated)
+}
+
+func (r *restaurantMemberHandler) AcceptInvitation(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "restaurant_member"),
+		slog.String("func", "AcceptInvitation"),
+	)
+
+	code, err := uuid.Parse(ctx.QueryParam("code"))
+	if err != nil {
+		log.Warn("Invalid invitation code format")
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_request", "O código do convite está em um formato inválido. Verifique o link e tente novamente.")
+	}
+
+	redirectURL := ctx.QueryParam("redirect")
+	if redirectURL == "" {
+		log.Warn("Redirect URL is missing")
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_request", "É necessário informar uma URL de redirecionamento para continuar.")
+	}
+
+	if redirectURL != config.Env.RedirectURL {
+		log.Warn("Redirect URL is invalid")
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_request", "A URL de redirecionamento informada não é válida. Entre em contato com o suporte.")
+	}
+
+	response, err := r.restaurantMemberService.AcceptInvitation(ctx.Request().Context(), code)
+	if err != nil {
+		log.Error(err.Error())
+
+		if errors.Is(err, models.ErrInvitationNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "O convite expirou ou é inválido. Solicite um novo convite ao gerente do restaurante.")
+		}
+
+		if errors.Is(err, models.ErrUserAlreadyMember) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusConflict, "conflict", "Você já faz parte de um restaurante.")
+		}
+
+		if errors.Is(err, models.ErrUserCannotJoinRestaurant) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusConflict, "conflict", "Sua conta não pode fazer parte de um restaurante.")
+		}
+
+		if errors.Is(err, models.ErrUserBlocked) {
+			return responses.AccountBlockedAPIErrorResponse(ctx)
+		}
+
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	setAuthCookies(ctx, response)
+
+	return ctx.Redirect(http.StatusFound, redirectURL)
+}
+
+func (r *restaurantMemberHandler) GetMembers(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "restaurant_member"),
+		slog.String("func", "GetMembers"),
+	)
+
+	response, err := r.restaurantMemberService.GetMembers(ctx.Request().Context())
+	if err != nil {
+		log.Error(err.Error())
+
+		if errors.Is(err, models.ErrRestaurantNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "Restaurante não encontrado")
+		}
+
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (r *restaurantMemberHandler) RemoveMember(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "restaurant_member"),
+		slog.String("func", "RemoveMember"),
+	)
+
+	userID, err := uuid.Parse(ctx.Param("userId"))
+	if err != nil {
+		log.Error(err.Error())
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_user_id", "Usuário inválido")
+	}
+
+	if err := r.restaurantMemberService.RemoveMember(ctx.Request().Context(), userID); err != nil {
+		log.Error(err.Error())
+
+		if errors.Is(err, models.ErrRestaurantNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "Restaurante não encontrado")
+		}
+
+		if errors.Is(err, models.ErrMemberNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "Membro não encontrado no restaurante")
+		}
+
+		if errors.Is(err, models.ErrCannotRemoveRestaurantOwner) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "cannot_remove_owner", "O proprietário do restaurante não pode ser removido")
+		}
+
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/G-Villarinho/food-shop-api/cmd/api/responses"
+	"github.com/G-Villarinho/food-shop-api/cmd/api/validation"
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/G-Villarinho/food-shop-api/services"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate mockery --name=RoleHandler --output=../../../mocks --outpkg=mocks
+type RoleHandler interface {
+	GetRolePermissions(ctx echo.Context) error
+	UpdateRolePermissions(ctx echo.Context) error
+	GetRestaurantRolePermissions(ctx echo.Context) error
+	UpdateRestaurantRolePermissions(ctx echo.Context) error
+}
+
+type roleHandler struct {
+	di                *internal.Di
+	permissionService services.PermissionService
+}
+
+func NewRoleHandler(di *internal.Di) (RoleHandler, error) {
+	permissionService, err := internal.Invoke[services.PermissionService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &roleHandler{
+		di:                di,
+		permissionService: permissionService,
+	}, nil
+}
+
+func (r *roleHandler) GetRolePermissions(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "role"),
+		slog.String("func", "GetRolePermissions"),
+	)
+
+	response, err := r.permissionService.GetRolePermissions(ctx.Request().Context())
+	if err != nil {
+		log.Error(err.Error())
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (r *roleHandler) UpdateRolePermissions(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "role"),
+		slog.String("func", "UpdateRolePermissions"),
+	)
+
+	var payload models.UpdateRolePermissionsPayload
+	if err := jsoniter.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		log.Warn("Error to decode JSON payload", slog.String("error", err.Error()))
+		return responses.CannotBindPayloadAPIErrorResponse(ctx)
+	}
+
+	if err := validation.ValidateStruct(payload); err != nil {
+		log.Warn("Error to validate JSON payload")
+		return responses.NewValidationErrorResponse(ctx, err)
+	}
+
+	scope := models.RoleScope(ctx.Param("scope"))
+	role := ctx.Param("role")
+
+	if err := r.permissionService.UpdateRolePermissions(ctx.Request().Context(), scope, role, payload); err != nil {
+		log.Error(err.Error())
+
+		if errors.Is(err, models.ErrRoleNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "Papel não encontrado")
+		}
+
+		if errors.Is(err, models.ErrUnknownPermission) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "unknown_permission", "Uma ou mais permissões informadas não existem")
+		}
+
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (r *roleHandler) GetRestaurantRolePermissions(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "role"),
+		slog.String("func", "GetRestaurantRolePermissions"),
+	)
+
+	response, err := r.permissionService.GetRestaurantRolePermissions(ctx.Request().Context())
+	if err != nil {
+		log.Error(err.Error())
+
+		if errors.Is(err, models.ErrRestaurantNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "Restaurante não encontrado")
+		}
+
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (r *roleHandler) UpdateRestaurantRolePermissions(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "role"),
+		slog.String("func", "UpdateRestaurantRolePermissions"),
+	)
+
+	var payload models.UpdateRolePermissionsPayload
+	if err := jsoniter.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		log.Warn("Error to decode JSON payload", slog.String("error", err.Error()))
+		return responses.CannotBindPayloadAPIErrorResponse(ctx)
+	}
+
+	if err := validation.ValidateStruct(payload); err != nil {
+		log.Warn("Error to validate JSON payload")
+		return responses.NewValidationErrorResponse(ctx, err)
+	}
+
+	role := models.MemberRole(ctx.Param("role"))
+
+	if err := r.permissionService.UpdateRestaurantRolePermissions(ctx.Request().Context(), role, payload); err != nil {
+		log.Error(err.Error())
+
+		if errors.Is(err, models.ErrRestaurantNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "Restaurante não encontrado")
+		}
+
+		if errors.Is(err, models.ErrRoleNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "Papel não encontrado")
+		}
+
+		if errors.Is(err, models.ErrOwnerRoleNotEditable) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "owner_role_not_editable", "As permissões do dono do restaurante não podem ser alteradas")
+		}
+
+		if errors.Is(err, models.ErrUnknownPermission) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "unknown_permission", "Uma ou mais permissões informadas não existem")
+		}
+
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
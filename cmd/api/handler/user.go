@@ -10,6 +10,7 @@ import (
 	"github.com/G-Villarinho/food-shop-api/internal"
 	"github.com/G-Villarinho/food-shop-api/models"
 	"github.com/G-Villarinho/food-shop-api/services"
+	"github.com/google/uuid"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/labstack/echo/v4"
 )
@@ -18,6 +19,9 @@ import (
 type UserHandler interface {
 	CreateUser(ctx echo.Context) error
 	GetUser(ctx echo.Context) error
+	GetSessions(ctx echo.Context) error
+	RevokeSession(ctx echo.Context) error
+	RevokeOtherSessions(ctx echo.Context) error
 }
 
 type userHandler struct {
@@ -90,3 +94,71 @@ func (u *userHandler) GetUser(ctx echo.Context) error {
 
 	return ctx.JSON(http.StatusOK, response)
 }
+
+func (u *userHandler) GetSessions(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "user"),
+		slog.String("func", "GetSessions"),
+	)
+
+	response, err := u.userService.GetSessions(ctx.Request().Context())
+	if err != nil {
+		log.Error(err.Error())
+
+		if errors.Is(err, models.ErrUserNotFoundInContext) {
+			return responses.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (u *userHandler) RevokeSession(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "user"),
+		slog.String("func", "RevokeSession"),
+	)
+
+	sessionID, err := uuid.Parse(ctx.Param("sessionId"))
+	if err != nil {
+		log.Error(err.Error())
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_session_id", "Sessão inválida")
+	}
+
+	if err := u.userService.RevokeSession(ctx.Request().Context(), sessionID); err != nil {
+		log.Error(err.Error())
+
+		if errors.Is(err, models.ErrUserNotFoundInContext) {
+			return responses.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		if errors.Is(err, models.ErrSessionNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "Sessão não encontrada")
+		}
+
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (u *userHandler) RevokeOtherSessions(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "user"),
+		slog.String("func", "RevokeOtherSessions"),
+	)
+
+	if err := u.userService.RevokeOtherSessions(ctx.Request().Context()); err != nil {
+		log.Error(err.Error())
+
+		if errors.Is(err, models.ErrUserNotFoundInContext) || errors.Is(err, models.ErrSessionNotFound) {
+			return responses.AccessDeniedAPIErrorResponse(ctx)
+		}
+
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/G-Villarinho/food-shop-api/cmd/api/responses"
+	"github.com/G-Villarinho/food-shop-api/cmd/api/validation"
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/G-Villarinho/food-shop-api/services"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate mockery --name=WebhookHandler --output=../../../mocks --outpkg=mocks
+type WebhookHandler interface {
+	CreateWebhook(ctx echo.Context) error
+	GetWebhooks(ctx echo.Context) error
+	DeleteWebhook(ctx echo.Context) error
+	GetDeliveries(ctx echo.Context) error
+	PingWebhook(ctx echo.Context) error
+}
+
+type webhookHandler struct {
+	di             *internal.Di
+	webhookService services.WebhookService
+}
+
+func NewWebhookHandler(di *internal.Di) (WebhookHandler, error) {
+	webhookService, err := internal.Invoke[services.WebhookService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhookHandler{
+		di:             di,
+		webhookService: webhookService,
+	}, nil
+}
+
+func (w *webhookHandler) CreateWebhook(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "webhook"),
+		slog.String("func", "CreateWebhook"),
+	)
+
+	var payload models.CreateWebhookPayload
+	if err := jsoniter.NewDecoder(ctx.Request().Body).Decode(&payload); err != nil {
+		log.Warn("Error to decode JSON payload", slog.String("error", err.Error()))
+		return responses.CannotBindPayloadAPIErrorResponse(ctx)
+	}
+
+	if err := validation.ValidateStruct(payload); err != nil {
+		log.Warn("Error to validate JSON payload")
+		return responses.NewValidationErrorResponse(ctx, err)
+	}
+
+	response, err := w.webhookService.CreateWebhook(ctx.Request().Context(), payload)
+	if err != nil {
+		log.Error(err.Error())
+
+		if errors.Is(err, models.ErrRestaurantNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "Restaurante não encontrado")
+		}
+
+		if errors.Is(err, models.ErrWebhookURLNotAllowed) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_url", "A URL do webhook deve usar HTTPS e apontar para um endereço público.")
+		}
+
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.JSON(http.StatusCreated, response)
+}
+
+func (w *webhookHandler) GetWebhooks(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "webhook"),
+		slog.String("func", "GetWebhooks"),
+	)
+
+	response, err := w.webhookService.GetWebhooks(ctx.Request().Context())
+	if err != nil {
+		log.Error(err.Error())
+
+		if errors.Is(err, models.ErrRestaurantNotFound) {
+			return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "Restaurante não encontrado")
+		}
+
+		return responses.InternalServerAPIErrorResponse(ctx)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (w *webhookHandler) DeleteWebhook(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "webhook"),
+		slog.String("func", "DeleteWebhook"),
+	)
+
+	webhookID, err := uuid.Parse(ctx.Param("webhookId"))
+	if err != nil {
+		log.Warn("Invalid webhook ID format")
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_webhook_id", "Webhook inválido")
+	}
+
+	if err := w.webhookService.DeleteWebhook(ctx.Request().Context(), webhookID); err != nil {
+		log.Error(err.Error())
+		return w.handleWebhookError(ctx, err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (w *webhookHandler) GetDeliveries(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "webhook"),
+		slog.String("func", "GetDeliveries"),
+	)
+
+	webhookID, err := uuid.Parse(ctx.Param("webhookId"))
+	if err != nil {
+		log.Warn("Invalid webhook ID format")
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_webhook_id", "Webhook inválido")
+	}
+
+	pagination, err := models.NewPagination(ctx.QueryParam("page"), ctx.QueryParam("limit"), ctx.QueryParam("sort"))
+	if err != nil {
+		log.Error(err.Error())
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_pagination", "Parâmetros de paginação inválidos")
+	}
+
+	response, err := w.webhookService.GetPaginatedDeliveries(ctx.Request().Context(), webhookID, pagination)
+	if err != nil {
+		log.Error(err.Error())
+		return w.handleWebhookError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (w *webhookHandler) PingWebhook(ctx echo.Context) error {
+	log := slog.With(
+		slog.String("handler", "webhook"),
+		slog.String("func", "PingWebhook"),
+	)
+
+	webhookID, err := uuid.Parse(ctx.Param("webhookId"))
+	if err != nil {
+		log.Warn("Invalid webhook ID format")
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusBadRequest, "invalid_webhook_id", "Webhook inválido")
+	}
+
+	response, err := w.webhookService.PingWebhook(ctx.Request().Context(), webhookID)
+	if err != nil {
+		log.Error(err.Error())
+		return w.handleWebhookError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (w *webhookHandler) handleWebhookError(ctx echo.Context, err error) error {
+	if errors.Is(err, models.ErrRestaurantNotFound) {
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "Restaurante não encontrado")
+	}
+
+	if errors.Is(err, models.ErrWebhookNotFound) {
+		return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusNotFound, "not_found", "Webhook não encontrado")
+	}
+
+	return responses.InternalServerAPIErrorResponse(ctx)
+}
@@ -2,21 +2,25 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/G-Villarinho/food-shop-api/cache"
 	"github.com/G-Villarinho/food-shop-api/client"
-	"github.com/G-Villarinho/food-shop-api/cmd/api/handler"
+	"github.com/G-Villarinho/food-shop-api/cmd/api/providers"
 	"github.com/G-Villarinho/food-shop-api/cmd/api/router"
 	"github.com/G-Villarinho/food-shop-api/config"
 	"github.com/G-Villarinho/food-shop-api/database"
 	"github.com/G-Villarinho/food-shop-api/internal"
-	"github.com/G-Villarinho/food-shop-api/repositories"
 	"github.com/G-Villarinho/food-shop-api/services"
 	"github.com/G-Villarinho/food-shop-api/services/email"
-	"github.com/G-Villarinho/food-shop-api/templates"
+	"github.com/G-Villarinho/food-shop-api/workers"
 	"github.com/go-redis/redis/v8"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -27,6 +31,18 @@ func main() {
 	config.ConfigureLogger()
 	config.LoadEnvironments()
 
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run serves the API until SIGINT or SIGTERM is received. Then it stops
+// accepting connections, ends the order tracking streams, waits for the other
+// requests in progress and the in-process workers, and closes the connections.
+func run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	e := echo.New()
 	di := internal.NewDi()
 
@@ -40,28 +56,32 @@ func main() {
 		AllowCredentials: true,
 	}))
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	db, err := database.NewMysqlConnection(ctx)
+	db, err := database.NewMysqlConnection(connectCtx)
 	if err != nil {
-		log.Fatal("error to connect to database: ", err)
+		return fmt.Errorf("error to connect to database: %w", err)
 	}
+	defer func() {
+		if err := database.CloseMysqlConnection(db); err != nil {
+			log.Println("error closing database connection:", err)
+		}
+	}()
 
-	redisClient, err := database.NewRedisConnection(ctx)
-	if err != nil {
-		log.Fatal("error to connect to redis: ", err)
+	var rabbitMQClient client.RabbitMQClient
+	if config.Env.Queue.Driver == config.MemoryDriver {
+		rabbitMQClient, err = client.NewMemoryRabbitMQClient(di)
+	} else {
+		rabbitMQClient, err = client.NewRabbitMQClient(di)
 	}
-
-	rabbitMQClient, err := client.NewRabbitMQClient(di)
 	if err != nil {
-		log.Fatal("error initializing RabbitMQ client: ", err)
+		return fmt.Errorf("error initializing RabbitMQ client: %w", err)
 	}
 
 	if err := rabbitMQClient.Connect(); err != nil {
-		log.Fatal("error connecting to RabbitMQ: ", err)
+		return fmt.Errorf("error connecting to RabbitMQ: %w", err)
 	}
-
 	defer func() {
 		if err := rabbitMQClient.Disconnect(); err != nil {
 			log.Println("error disconnecting from RabbitMQ:", err)
@@ -76,44 +96,135 @@ func main() {
 		return db, nil
 	})
 
-	internal.Provide(di, func(d *internal.Di) (*redis.Client, error) {
-		return redisClient, nil
-	})
-
-	internal.Provide(di, client.NewMailtrapClient)
-
-	internal.Provide(di, handler.NewAuthHandler)
-	internal.Provide(di, handler.NewEvaluationHandler)
-	internal.Provide(di, handler.NewMenuHandler)
-	internal.Provide(di, handler.NewMetricsHandler)
-	internal.Provide(di, handler.NewOrderHandler)
-	internal.Provide(di, handler.NewProductHandler)
-	internal.Provide(di, handler.NewRestaurantHandler)
-	internal.Provide(di, handler.NewUserHandler)
-
-	internal.Provide(di, cache.NewRedisCache)
-	internal.Provide(di, email.NewEmailService)
-	internal.Provide(di, templates.NewTemplateService)
-
-	internal.Provide(di, services.NewAuthService)
-	internal.Provide(di, services.NewEvaluationService)
-	internal.Provide(di, services.NewMenuService)
-	internal.Provide(di, services.NewMetricsService)
-	internal.Provide(di, services.NewOrderItemService)
-	internal.Provide(di, services.NewProductService)
-	internal.Provide(di, services.NewOrderService)
-	internal.Provide(di, services.NewQueueService)
-	internal.Provide(di, services.NewRestaurantService)
-	internal.Provide(di, services.NewSessionService)
-	internal.Provide(di, services.NewTokenService)
-	internal.Provide(di, services.NewUserService)
-
-	internal.Provide(di, repositories.NewEvaluationRepository)
-	internal.Provide(di, repositories.NewOrderRepository)
-	internal.Provide(di, repositories.NewProductRepository)
-	internal.Provide(di, repositories.NewRestaurantRepository)
-	internal.Provide(di, repositories.NewUserRepository)
+	if config.Env.Cache.Driver == config.MemoryDriver {
+		internal.Provide(di, cache.NewMemoryCache)
+		internal.Provide(di, cache.NewMemoryRateLimiter)
+	} else {
+		redisClient, err := database.NewRedisConnection(connectCtx)
+		if err != nil {
+			return fmt.Errorf("error to connect to redis: %w", err)
+		}
+		defer func() {
+			if err := redisClient.Close(); err != nil {
+				log.Println("error closing redis connection:", err)
+			}
+		}()
+
+		internal.Provide(di, func(d *internal.Di) (*redis.Client, error) {
+			return redisClient, nil
+		})
+
+		internal.Provide(di, cache.NewRedisCache)
+		internal.Provide(di, cache.NewRedisRateLimiter)
+	}
 
+	providers.SetupProviders(di)
 	router.SetupRoutes(e, di)
-	e.Logger.Fatal(e.Start(fmt.Sprintf(":%d", config.Env.APIPort)))
+
+	// Nothing else can reach an in-memory queue, so the API runs the workers.
+	// They are stopped after the server, since requests in progress may still
+	// enqueue messages for them.
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	workersDone := make(chan error, 1)
+	if config.Env.Queue.Driver == config.MemoryDriver {
+		runWorkers, err := newWorkers(di)
+		if err != nil {
+			return err
+		}
+
+		go func() {
+			workersDone <- runWorkers(workersCtx)
+		}()
+	} else {
+		workersDone <- nil
+	}
+
+	// The order tracking streams never finish on their own, so they are ended
+	// as soon as the shutdown starts instead of holding it until the timeout.
+	shutdown, err := internal.Invoke[*internal.Shutdown](di)
+	if err != nil {
+		return fmt.Errorf("error to get shutdown notifier: %w", err)
+	}
+	e.Server.RegisterOnShutdown(shutdown.Close)
+
+	serverErr := make(chan error, 1)
+	go func() {
+		if err := e.Start(fmt.Sprintf(":%d", config.Env.APIPort)); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		log.Println("shutting down")
+	case err := <-serverErr:
+		stopWorkers()
+		<-workersDone
+		return fmt.Errorf("error to start server: %w", err)
+	}
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), config.ShutdownTimeout())
+	defer cancelShutdown()
+
+	// Whatever is still running when the timeout expires is closed.
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		log.Println("error shutting down server:", err)
+		if err := e.Close(); err != nil {
+			log.Println("error closing server:", err)
+		}
+	}
+
+	stopWorkers()
+	if err := <-workersDone; err != nil {
+		return fmt.Errorf("error running workers: %w", err)
+	}
+
+	return nil
+}
+
+func newWorkers(di *internal.Di) (func(ctx context.Context) error, error) {
+	queueService, err := internal.Invoke[services.QueueService](di)
+	if err != nil {
+		return nil, fmt.Errorf("error to create queue service: %w", err)
+	}
+
+	emailService, err := internal.Invoke[email.EmailService](di)
+	if err != nil {
+		return nil, fmt.Errorf("error to create email service: %w", err)
+	}
+
+	outboxService, err := internal.Invoke[services.OutboxService](di)
+	if err != nil {
+		return nil, fmt.Errorf("error to create outbox service: %w", err)
+	}
+
+	webhookService, err := internal.Invoke[services.WebhookService](di)
+	if err != nil {
+		return nil, fmt.Errorf("error to create webhook service: %w", err)
+	}
+
+	return func(ctx context.Context) error {
+		return workers.Run(ctx, config.ShutdownTimeout(),
+			func(ctx context.Context) error {
+				return workers.SendEmails(ctx, queueService, emailService)
+			},
+			func(ctx context.Context) error {
+				return workers.RelayOutbox(ctx, outboxService)
+			},
+			func(ctx context.Context) error {
+				return workers.DeletePublishedOutboxMessages(ctx, outboxService)
+			},
+			func(ctx context.Context) error {
+				return workers.DispatchWebhookEvents(ctx, queueService, webhookService)
+			},
+			func(ctx context.Context) error {
+				return workers.DeliverWebhooks(ctx, queueService, webhookService)
+			},
+			func(ctx context.Context) error {
+				return workers.RetryDueWebhookDeliveries(ctx, webhookService)
+			},
+		)
+	}, nil
 }
@@ -0,0 +1,71 @@
+package providers
+
+import (
+	"github.com/G-Villarinho/food-shop-api/client"
+	"github.com/G-Villarinho/food-shop-api/cmd/api/handler"
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/repositories"
+	"github.com/G-Villarinho/food-shop-api/services"
+	"github.com/G-Villarinho/food-shop-api/services/email"
+	"github.com/G-Villarinho/food-shop-api/templates"
+)
+
+// SetupProviders registers the handlers, services and repositories of the API.
+// The database, cache and queue clients are provided by the caller, which
+// picks their implementation.
+func SetupProviders(di *internal.Di) {
+	internal.Provide(di, client.NewMailtrapClient)
+	internal.Provide(di, internal.NewShutdown)
+
+	internal.Provide(di, handler.NewAPIKeyHandler)
+	internal.Provide(di, handler.NewAuthHandler)
+	internal.Provide(di, handler.NewCourierHandler)
+	internal.Provide(di, handler.NewEvaluationHandler)
+	internal.Provide(di, handler.NewHealthHandler)
+	internal.Provide(di, handler.NewMenuHandler)
+	internal.Provide(di, handler.NewMetricsHandler)
+	internal.Provide(di, handler.NewOrderHandler)
+	internal.Provide(di, handler.NewProductHandler)
+	internal.Provide(di, handler.NewRestaurantHandler)
+	internal.Provide(di, handler.NewRestaurantMemberHandler)
+	internal.Provide(di, handler.NewRoleHandler)
+	internal.Provide(di, handler.NewAdminHandler)
+	internal.Provide(di, handler.NewUserHandler)
+	internal.Provide(di, handler.NewWebhookHandler)
+
+	internal.Provide(di, email.NewEmailService)
+	internal.Provide(di, templates.NewTemplateService)
+
+	internal.Provide(di, services.NewAPIKeyService)
+	internal.Provide(di, services.NewAuthService)
+	internal.Provide(di, services.NewEvaluationService)
+	internal.Provide(di, services.NewHealthService)
+	internal.Provide(di, services.NewMenuService)
+	internal.Provide(di, services.NewMetricsService)
+	internal.Provide(di, services.NewOrderItemService)
+	internal.Provide(di, services.NewProductService)
+	internal.Provide(di, services.NewOrderService)
+	internal.Provide(di, services.NewOutboxService)
+	internal.Provide(di, services.NewQueueService)
+	internal.Provide(di, services.NewRestaurantService)
+	internal.Provide(di, services.NewRestaurantMemberService)
+	internal.Provide(di, services.NewPermissionService)
+	internal.Provide(di, services.NewAdminService)
+	internal.Provide(di, services.NewSessionService)
+	internal.Provide(di, services.NewTokenService)
+	internal.Provide(di, services.NewKeyRing)
+	internal.Provide(di, services.NewTrackingService)
+	internal.Provide(di, services.NewUserService)
+	internal.Provide(di, services.NewWebhookService)
+
+	internal.Provide(di, repositories.NewAPIKeyRepository)
+	internal.Provide(di, repositories.NewEvaluationRepository)
+	internal.Provide(di, repositories.NewOrderRepository)
+	internal.Provide(di, repositories.NewOutboxRepository)
+	internal.Provide(di, repositories.NewProductRepository)
+	internal.Provide(di, repositories.NewRestaurantRepository)
+	internal.Provide(di, repositories.NewRestaurantMemberRepository)
+	internal.Provide(di, repositories.NewRolePermissionRepository)
+	internal.Provide(di, repositories.NewUserRepository)
+	internal.Provide(di, repositories.NewWebhookRepository)
+}
@@ -36,6 +36,22 @@ func ForbiddenPermissionAPIErrorResponse(ctx echo.Context) error {
 	})
 }
 
+func AccountBlockedAPIErrorResponse(ctx echo.Context) error {
+	return ctx.JSON(http.StatusForbidden, ErrorResponse{
+		StatusCode: http.StatusForbidden,
+		Title:      "account_blocked",
+		Details:    "Sua conta está suspensa. Entre em contato com o suporte para mais informações.",
+	})
+}
+
+func TooManyRequestsAPIErrorResponse(ctx echo.Context) error {
+	return ctx.JSON(http.StatusTooManyRequests, ErrorResponse{
+		StatusCode: http.StatusTooManyRequests,
+		Title:      "too_many_requests",
+		Details:    "Muitas requisições em pouco tempo. Aguarde alguns instantes e tente novamente.",
+	})
+}
+
 func CannotBindPayloadAPIErrorResponse(ctx echo.Context) error {
 	errorResponse := ErrorResponse{
 		StatusCode: http.StatusUnprocessableEntity,
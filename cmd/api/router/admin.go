@@ -0,0 +1,30 @@
+package router
+
+import (
+	"log"
+
+	"github.com/G-Villarinho/food-shop-api/cmd/api/handler"
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/middleware"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/labstack/echo/v4"
+)
+
+func setupAdminRoutes(e *echo.Echo, di *internal.Di) {
+	adminHandler, err := internal.Invoke[handler.AdminHandler](di)
+	if err != nil {
+		log.Fatal("error to create admin handler: ", err)
+	}
+
+	group := e.Group("/v1/admin", middleware.EnsureAuthenticated(di))
+
+	group.GET("/users", adminHandler.GetUsers, middleware.EnsurePermission(di, models.ManageUsersPermission))
+	group.PATCH("/users/:userId/block", adminHandler.BlockUser, middleware.EnsurePermission(di, models.ManageUsersPermission))
+	group.PATCH("/users/:userId/unblock", adminHandler.UnblockUser, middleware.EnsurePermission(di, models.ManageUsersPermission))
+	group.POST("/users/:userId/impersonate", adminHandler.ImpersonateUser, middleware.EnsurePermission(di, models.ImpersonateUserPermission))
+	group.GET("/restaurants", adminHandler.GetRestaurants, middleware.EnsurePermission(di, models.ManageRestaurantsPermission))
+	group.PATCH("/restaurants/:restaurantId/suspend", adminHandler.SuspendRestaurant, middleware.EnsurePermission(di, models.ManageRestaurantsPermission))
+	group.PATCH("/restaurants/:restaurantId/reactivate", adminHandler.ReactivateRestaurant, middleware.EnsurePermission(di, models.ManageRestaurantsPermission))
+	group.GET("/orders/:orderId", adminHandler.GetOrder, middleware.EnsurePermission(di, models.ViewAnyOrderPermission))
+	group.DELETE("/evaluations/:evaluationId", adminHandler.DeleteEvaluation, middleware.EnsurePermission(di, models.ModerateEvaluationsPermission))
+}
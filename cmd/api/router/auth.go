@@ -4,7 +4,9 @@ import (
 	"log"
 
 	"github.com/G-Villarinho/food-shop-api/cmd/api/handler"
+	"github.com/G-Villarinho/food-shop-api/config"
 	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/middleware"
 	"github.com/labstack/echo/v4"
 )
 
@@ -14,8 +16,23 @@ func setupAuthRoutes(e *echo.Echo, di *internal.Di) {
 		log.Fatal("error to create auth handler: ", err)
 	}
 
+	window := config.SignInWindow()
+	signInRateLimit := middleware.RateLimit(di,
+		middleware.RateLimitRule{Name: "sign-in:ip", Limit: config.SignInIPLimit(), Window: window, Key: middleware.RateLimitByIP},
+		middleware.RateLimitRule{Name: "sign-in:email", Limit: config.SignInEmailLimit(), Window: window, Key: middleware.RateLimitByEmail},
+	)
+	verifyCodeRateLimit := middleware.RateLimit(di,
+		middleware.RateLimitRule{Name: "sign-in-code:ip", Limit: config.SignInIPLimit(), Window: window, Key: middleware.RateLimitByIP},
+		middleware.RateLimitRule{Name: "sign-in-code:email", Limit: config.SignInEmailLimit(), Window: window, Key: middleware.RateLimitByEmail},
+	)
+
+	e.GET("/.well-known/jwks.json", authHandler.GetJWKS)
+
 	group := e.Group("/v1/auth")
 
-	group.POST("/sign-in", authHandler.SignIn)
+	group.POST("/sign-in", authHandler.SignIn, signInRateLimit)
 	group.GET("/link", authHandler.VeryfyMagicLink)
+	group.POST("/code", authHandler.VerifySignInCode, verifyCodeRateLimit)
+	group.POST("/refresh", authHandler.RefreshToken)
+	group.POST("/sign-out", authHandler.SignOut, middleware.EnsureAuthenticated(di))
 }
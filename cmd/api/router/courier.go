@@ -0,0 +1,25 @@
+package router
+
+import (
+	"log"
+
+	"github.com/G-Villarinho/food-shop-api/cmd/api/handler"
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/middleware"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/labstack/echo/v4"
+)
+
+func setupCourierRoutes(e *echo.Echo, di *internal.Di) {
+	courierHandler, err := internal.Invoke[handler.CourierHandler](di)
+	if err != nil {
+		log.Fatal("error to create courier handler: ", err)
+	}
+
+	group := e.Group("/v1/couriers")
+
+	group.POST("", courierHandler.CreateCourier)
+	group.GET("/me/deliveries", courierHandler.GetDeliveries, middleware.EnsureAuthenticated(di), middleware.EnsurePermission(di, models.ListDeliveriesPermission))
+	group.POST("/me/deliveries/:orderId/location", courierHandler.UpdateLocation, middleware.EnsureAuthenticated(di), middleware.EnsurePermission(di, models.UpdateCourierLocationPermission))
+	group.PATCH("/me/deliveries/:orderId/complete", courierHandler.CompleteDelivery, middleware.EnsureAuthenticated(di), middleware.EnsurePermission(di, models.CompleteDeliveryPermission))
+}
@@ -18,8 +18,8 @@ func setupEvaluationRoutes(e *echo.Echo, di *internal.Di) {
 
 	group := e.Group("/v1/evaluations", middleware.EnsureAuthenticated(di))
 
-	group.POST("", evaluationHandler.CreateEvaluation, middleware.EnsurePermission(models.CreateEvaluationPermission))
-	group.GET("", evaluationHandler.GetEvaluations, middleware.EnsurePermission(models.ListEvaluationsPermission))
-	group.PATCH("/answer", evaluationHandler.UpdateAnswer, middleware.EnsurePermission(models.UpdateEvaluationAnswerPermission))
-	group.GET("/summary", evaluationHandler.GetEvaluationSumary, middleware.EnsurePermission(models.GetEvaluationSummaryPermission))
+	group.POST("", evaluationHandler.CreateEvaluation, middleware.EnsurePermission(di, models.CreateEvaluationPermission))
+	group.GET("", evaluationHandler.GetEvaluations, middleware.EnsurePermission(di, models.ListEvaluationsPermission))
+	group.PATCH("/answer", evaluationHandler.UpdateAnswer, middleware.EnsurePermission(di, models.UpdateEvaluationAnswerPermission))
+	group.GET("/summary", evaluationHandler.GetEvaluationSumary, middleware.EnsurePermission(di, models.GetEvaluationSummaryPermission))
 }
@@ -0,0 +1,18 @@
+package router
+
+import (
+	"log"
+
+	"github.com/G-Villarinho/food-shop-api/cmd/api/handler"
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/labstack/echo/v4"
+)
+
+func setupHealthRoutes(e *echo.Echo, di *internal.Di) {
+	healthHandler, err := internal.Invoke[handler.HealthHandler](di)
+	if err != nil {
+		log.Fatal("error to create health handler: ", err)
+	}
+
+	e.GET("/health", healthHandler.Check)
+}
@@ -17,5 +17,5 @@ func setupMenuRoutes(e *echo.Echo, di *internal.Di) {
 	}
 
 	group := e.Group("/v1/menus", middleware.EnsureAuthenticated(di))
-	group.PUT("", menuHandler.UpdateMenu, middleware.EnsurePermission(models.UpdateMenuPermission))
+	group.PUT("", menuHandler.UpdateMenu, middleware.EnsurePermission(di, models.UpdateMenuPermission))
 }
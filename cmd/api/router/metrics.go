@@ -18,5 +18,6 @@ func setupMetricsRouter(e *echo.Echo, di *internal.Di) {
 
 	group := e.Group("/v1/metrics", middleware.EnsureAuthenticated(di))
 
-	group.GET("/orders/monthly-amount", metricsRouter.GetMonthlyMetrics, middleware.EnsurePermission(models.GetMonthlyMetricsPermission))
+	group.GET("/orders/monthly-amount", metricsRouter.GetMonthlyMetrics, middleware.EnsurePermission(di, models.GetMonthlyMetricsPermission))
+	group.GET("/orders/monthly-tips", metricsRouter.GetMonthlyTips, middleware.EnsurePermission(di, models.GetMonthlyTipsPermission))
 }
@@ -18,9 +18,11 @@ func setupOrderRoutes(e *echo.Echo, di *internal.Di) {
 
 	group := e.Group("/v1/orders", middleware.EnsureAuthenticated(di))
 
-	group.GET("", orderHandler.GetOrders, middleware.EnsurePermission(models.ListOrdersPermission))
-	group.PATCH("/:orderId/cancel", orderHandler.CancelOrder, middleware.EnsurePermission(models.CancelOrderPermission))
-	group.PATCH("/:orderId/approve", orderHandler.ApproveOrder, middleware.EnsurePermission(models.ApproveOrderPermission))
-	group.PATCH("/:orderId/dispatch", orderHandler.DispatchOrder, middleware.EnsurePermission(models.DispatchOrderPermission))
-	group.PATCH("/:orderId/deliver", orderHandler.DeliverOrder, middleware.EnsurePermission(models.DeliverOrderPermission))
+	group.GET("", orderHandler.GetOrders, middleware.EnsurePermission(di, models.ListOrdersPermission))
+	group.PATCH("/:orderId/cancel", orderHandler.CancelOrder, middleware.EnsurePermission(di, models.CancelOrderPermission))
+	group.PATCH("/:orderId/approve", orderHandler.ApproveOrder, middleware.EnsurePermission(di, models.ApproveOrderPermission))
+	group.PATCH("/:orderId/dispatch", orderHandler.DispatchOrder, middleware.EnsurePermission(di, models.DispatchOrderPermission))
+	group.PATCH("/:orderId/deliver", orderHandler.DeliverOrder, middleware.EnsurePermission(di, models.DeliverOrderPermission))
+	group.GET("/:orderId/tracking", orderHandler.GetOrderTracking, middleware.EnsurePermission(di, models.TrackOrderPermission))
+	group.GET("/:orderId/tracking/stream", orderHandler.StreamOrderTracking, middleware.EnsurePermission(di, models.TrackOrderPermission))
 }
@@ -16,8 +16,45 @@ func setupRestaurantRoutes(e *echo.Echo, di *internal.Di) {
 		log.Fatal("error to create auth handler: ", err)
 	}
 
+	restaurantMemberHandler, err := internal.Invoke[handler.RestaurantMemberHandler](di)
+	if err != nil {
+		log.Fatal("error to create restaurant member handler: ", err)
+	}
+
+	apiKeyHandler, err := internal.Invoke[handler.APIKeyHandler](di)
+	if err != nil {
+		log.Fatal("error to create api key handler: ", err)
+	}
+
+	webhookHandler, err := internal.Invoke[handler.WebhookHandler](di)
+	if err != nil {
+		log.Fatal("error to create webhook handler: ", err)
+	}
+
+	roleHandler, err := internal.Invoke[handler.RoleHandler](di)
+	if err != nil {
+		log.Fatal("error to create role handler: ", err)
+	}
+
 	group := e.Group("/v1/restaurants")
 
 	group.POST("", restaurantHandler.CreateRestaurant)
-	group.POST("/:restaurantID/order", restaurantHandler.CreateOrder, middleware.EnsureAuthenticated(di), middleware.EnsurePermission(models.CreateOrderPermission))
+	group.POST("/:restaurantID/order", restaurantHandler.CreateOrder, middleware.EnsureAuthenticated(di), middleware.EnsurePermission(di, models.CreateOrderPermission))
+
+	group.GET("/invitations/accept", restaurantMemberHandler.AcceptInvitation)
+	group.GET("/members", restaurantMemberHandler.GetMembers, middleware.EnsureAuthenticated(di), middleware.EnsurePermission(di, models.ManageMembersPermission))
+	group.POST("/members/invitations", restaurantMemberHandler.InviteMember, middleware.EnsureAuthenticated(di), middleware.EnsurePermission(di, models.ManageMembersPermission))
+	group.DELETE("/members/:userId", restaurantMemberHandler.RemoveMember, middleware.EnsureAuthenticated(di), middleware.EnsurePermission(di, models.ManageMembersPermission))
+	group.GET("/members/roles", roleHandler.GetRestaurantRolePermissions, middleware.EnsureAuthenticated(di), middleware.EnsurePermission(di, models.ManageMembersPermission))
+	group.PUT("/members/roles/:role/permissions", roleHandler.UpdateRestaurantRolePermissions, middleware.EnsureAuthenticated(di), middleware.EnsurePermission(di, models.ManageMembersPermission))
+
+	group.GET("/api-keys", apiKeyHandler.GetAPIKeys, middleware.EnsureAuthenticated(di), middleware.EnsurePermission(di, models.ManageAPIKeysPermission))
+	group.POST("/api-keys", apiKeyHandler.CreateAPIKey, middleware.EnsureAuthenticated(di), middleware.EnsurePermission(di, models.ManageAPIKeysPermission))
+	group.DELETE("/api-keys/:apiKeyId", apiKeyHandler.RevokeAPIKey, middleware.EnsureAuthenticated(di), middleware.EnsurePermission(di, models.ManageAPIKeysPermission))
+
+	group.GET("/webhooks", webhookHandler.GetWebhooks, middleware.EnsureAuthenticated(di), middleware.EnsurePermission(di, models.ManageWebhooksPermission))
+	group.POST("/webhooks", webhookHandler.CreateWebhook, middleware.EnsureAuthenticated(di), middleware.EnsurePermission(di, models.ManageWebhooksPermission))
+	group.DELETE("/webhooks/:webhookId", webhookHandler.DeleteWebhook, middleware.EnsureAuthenticated(di), middleware.EnsurePermission(di, models.ManageWebhooksPermission))
+	group.GET("/webhooks/:webhookId/deliveries", webhookHandler.GetDeliveries, middleware.EnsureAuthenticated(di), middleware.EnsurePermission(di, models.ManageWebhooksPermission))
+	group.POST("/webhooks/:webhookId/ping", webhookHandler.PingWebhook, middleware.EnsureAuthenticated(di), middleware.EnsurePermission(di, models.ManageWebhooksPermission))
 }
@@ -0,0 +1,23 @@
+package router
+
+import (
+	"log"
+
+	"github.com/G-Villarinho/food-shop-api/cmd/api/handler"
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/middleware"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/labstack/echo/v4"
+)
+
+func setupRoleRoutes(e *echo.Echo, di *internal.Di) {
+	roleHandler, err := internal.Invoke[handler.RoleHandler](di)
+	if err != nil {
+		log.Fatal("error to create role handler: ", err)
+	}
+
+	group := e.Group("/v1/roles", middleware.EnsureAuthenticated(di), middleware.EnsurePermission(di, models.ManageRolesPermission))
+
+	group.GET("", roleHandler.GetRolePermissions)
+	group.PUT("/:scope/:role/permissions", roleHandler.UpdateRolePermissions)
+}
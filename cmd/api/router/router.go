@@ -1,11 +1,20 @@
 package router
 
 import (
+	"log"
+
+	"github.com/G-Villarinho/food-shop-api/config"
 	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/middleware"
 	"github.com/labstack/echo/v4"
 )
 
 func SetupRoutes(e *echo.Echo, di *internal.Di) {
+	e.IPExtractor = newIPExtractor()
+
+	e.Use(middleware.RequestMetadata())
+
+	setupHealthRoutes(e, di)
 	setupUserRoutes(e, di)
 	setupAuthRoutes(e, di)
 	setupRestaurantRoutes(e, di)
@@ -14,4 +23,28 @@ func SetupRoutes(e *echo.Echo, di *internal.Di) {
 	setupEvaluationRoutes(e, di)
 	setupMenuRoutes(e, di)
 	setupMetricsRouter(e, di)
+	setupCourierRoutes(e, di)
+	setupRoleRoutes(e, di)
+	setupAdminRoutes(e, di)
+}
+
+// newIPExtractor reads the client IP from X-Forwarded-For only behind the
+// trusted proxies. Otherwise any client could pick the IP that the rate limits
+// and the sessions see.
+func newIPExtractor() echo.IPExtractor {
+	proxies, err := config.TrustedProxies()
+	if err != nil {
+		log.Fatal("error to read trusted proxies: ", err)
+	}
+
+	if len(proxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, proxy := range proxies {
+		options = append(options, echo.TrustIPRange(proxy))
+	}
+
+	return echo.ExtractIPFromXFFHeader(options...)
 }
@@ -19,4 +19,7 @@ func setupUserRoutes(e *echo.Echo, di *internal.Di) {
 
 	group.POST("", userHandler.CreateUser)
 	group.GET("/me", userHandler.GetUser, middleware.EnsureAuthenticated(di))
+	group.GET("/me/sessions", userHandler.GetSessions, middleware.EnsureAuthenticated(di))
+	group.DELETE("/me/sessions", userHandler.RevokeOtherSessions, middleware.EnsureAuthenticated(di))
+	group.DELETE("/me/sessions/:sessionId", userHandler.RevokeSession, middleware.EnsureAuthenticated(di))
 }
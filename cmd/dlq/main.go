@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/G-Villarinho/food-shop-api/client"
+	"github.com/G-Villarinho/food-shop-api/config"
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/services"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Inspects and replays dead-lettered messages:
+//
+//	go run cmd/dlq/main.go -queue send_email_queue -limit 10
+//	go run cmd/dlq/main.go -queue send_email_queue -replay
+func main() {
+	queueName := flag.String("queue", services.QueueSendEmail, "queue whose dead letters are read")
+	limit := flag.Int("limit", 20, "maximum number of messages")
+	replay := flag.Bool("replay", false, "move the dead letters back to the queue instead of listing them")
+	flag.Parse()
+
+	config.LoadEnvironments()
+
+	di := internal.NewDi()
+
+	rabbitMQClient, err := client.NewRabbitMQClient(di)
+	if err != nil {
+		log.Fatal("error initializing RabbitMQ client: ", err)
+	}
+
+	if err := rabbitMQClient.Connect(); err != nil {
+		log.Fatal("error connecting to RabbitMQ: ", err)
+	}
+	defer func() {
+		if err := rabbitMQClient.Disconnect(); err != nil {
+			log.Println("error disconnecting from RabbitMQ:", err)
+		}
+	}()
+
+	internal.Provide(di, func(d *internal.Di) (client.RabbitMQClient, error) {
+		return rabbitMQClient, nil
+	})
+
+	internal.Provide(di, services.NewQueueService)
+
+	queueService, err := internal.Invoke[services.QueueService](di)
+	if err != nil {
+		log.Fatal("error to create queue service: ", err)
+	}
+
+	if *replay {
+		replayed, err := queueService.ReplayDeadLetters(*queueName, *limit)
+		if err != nil {
+			log.Println("error replaying dead letters: ", err)
+		}
+
+		fmt.Printf("%d messages replayed to %s\n", replayed, *queueName)
+		return
+	}
+
+	deadLetters, err := queueService.GetDeadLetters(*queueName, *limit)
+	if err != nil {
+		log.Fatal("error reading dead letters: ", err)
+	}
+
+	encoder := jsoniter.NewEncoder(os.Stdout)
+	for _, deadLetter := range deadLetters {
+		if err := encoder.Encode(deadLetter); err != nil {
+			log.Fatal("error encoding dead letter: ", err)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/config"
+	"github.com/G-Villarinho/food-shop-api/database"
+	"github.com/G-Villarinho/food-shop-api/database/migrations"
+)
+
+const usage = `usage: go run cmd/migrate/main.go <command>
+
+commands:
+  up                      apply the pending migrations
+  down [-steps N]         roll back the last N migrations (default 1)
+  status                  list the migrations and whether they were applied
+  create [-dir D] <name>  create the files of a new migration
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	command, args := os.Args[1], os.Args[2:]
+
+	if command == "create" {
+		create(args)
+		return
+	}
+
+	config.LoadEnvironments()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	db, err := database.NewMysqlConnection(ctx)
+	if err != nil {
+		log.Fatal("error to connect to mysql: ", err)
+	}
+
+	migrator, err := migrations.NewMigrator(db, migrations.Files)
+	if err != nil {
+		log.Fatal("error to load migrations: ", err)
+	}
+
+	// Migrations may take longer than the connection, so they get their own context.
+	ctx = context.Background()
+
+	switch command {
+	case "up":
+		migrated, err := migrator.Up(ctx)
+		for _, migration := range migrated {
+			fmt.Printf("applied %06d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			log.Fatal("error to migrate: ", err)
+		}
+
+		if err := migrations.SyncRolePermissions(ctx, db); err != nil {
+			log.Fatal("error to sync role permissions: ", err)
+		}
+
+		log.Println("Migration executed successfully")
+
+	case "down":
+		flags := flag.NewFlagSet("down", flag.ExitOnError)
+		steps := flags.Int("steps", 1, "number of migrations to roll back")
+		flags.Parse(args)
+
+		if *steps < 1 {
+			log.Fatal("steps must be at least 1")
+		}
+
+		rolledBack, err := migrator.Down(ctx, *steps)
+		for _, migration := range rolledBack {
+			fmt.Printf("rolled back %06d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			if errors.Is(err, migrations.ErrNoMigrationToRollback) {
+				log.Println("No migration to roll back")
+				return
+			}
+
+			log.Fatal("error to roll back: ", err)
+		}
+
+	case "status":
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatal("error to get migrations status: ", err)
+		}
+
+		for _, migration := range status {
+			appliedAt := "pending"
+			if migration.AppliedAt != nil {
+				appliedAt = migration.AppliedAt.Format(time.RFC3339)
+			}
+
+			fmt.Printf("%06d_%-40s %s\n", migration.Version, migration.Name, appliedAt)
+		}
+
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+func create(args []string) {
+	flags := flag.NewFlagSet("create", flag.ExitOnError)
+	dir := flags.String("dir", "database/migrations", "directory of the migration files")
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	paths, err := migrations.Create(*dir, flags.Arg(0))
+	if err != nil {
+		log.Fatal("error to create migration: ", err)
+	}
+
+	for _, path := range paths {
+		fmt.Println("created", path)
+	}
+}
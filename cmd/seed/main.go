@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/config"
+	"github.com/G-Villarinho/food-shop-api/database"
+)
+
+// defaultSeedReference is the day the generated data ends on when -now isn't
+// given, so the output doesn't depend on when the command runs.
+const defaultSeedReference = "2026-01-01"
+
+// Fills the database with demo data. The same seed and reference day always
+// generate the same rows, and running it again only creates the rows that are
+// missing:
+//
+//	go run cmd/seed/main.go
+//	go run cmd/seed/main.go -seed 42 -restaurants 10 -orders 500 -months 12 -now 2026-10-01
+func main() {
+	var options seedOptions
+	var reference string
+	flag.Int64Var(&options.Seed, "seed", 1, "value that determines the generated data")
+	flag.IntVar(&options.Restaurants, "restaurants", 5, "number of restaurants, each one with its own manager")
+	flag.IntVar(&options.Customers, "customers", 50, "number of customers")
+	flag.IntVar(&options.Products, "products", 8, "number of products per restaurant, limited to the size of the demo menu")
+	flag.IntVar(&options.Orders, "orders", 120, "number of orders per restaurant")
+	flag.IntVar(&options.Evaluations, "evaluations", 20, "number of evaluations per restaurant")
+	flag.IntVar(&options.Months, "months", 6, "number of months the orders are spread across, up to the reference day")
+	flag.StringVar(&reference, "now", defaultSeedReference, "reference day (YYYY-MM-DD) the generated data ends on")
+	flag.Parse()
+
+	if err := options.validate(); err != nil {
+		log.Fatal("invalid options: ", err)
+	}
+
+	now, err := time.Parse(time.DateOnly, reference)
+	if err != nil {
+		log.Fatal("invalid reference day: ", err)
+	}
+
+	config.LoadEnvironments()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	db, err := database.NewMysqlConnection(ctx)
+	if err != nil {
+		log.Fatal("error to connect to mysql: ", err)
+	}
+
+	data := newSeeder(options, now).generate()
+
+	created, err := data.insert(context.Background(), db)
+	if err != nil {
+		log.Fatal("error to seed database: ", err)
+	}
+
+	for _, table := range created {
+		fmt.Printf("%-18s %d of %d created\n", table.Name, table.Created, table.Total)
+	}
+
+	fmt.Printf("\nSign in as %s (manager) or %s (customer).\n", data.users[0].Email, data.customers()[0].Email)
+}
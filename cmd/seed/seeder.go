@@ -0,0 +1,362 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const insertBatchSize = 500
+
+// seedNamespace derives the IDs of the generated rows, so the same seed always
+// produces the same IDs and rows that already exist are skipped.
+var seedNamespace = uuid.MustParse("5b0e9e8c-3f41-4c8e-9a59-7d0f6f1b8a21")
+
+var (
+	firstNames = []string{"Ana", "Bruno", "Carla", "Diego", "Eduarda", "Felipe", "Gabriela", "Henrique", "Isabela", "Lucas",
+		"Mariana", "Rafael", "Beatriz", "Thiago", "Larissa", "Gustavo", "Camila", "Pedro", "Juliana", "Mateus"}
+	lastNames = []string{"Silva", "Santos", "Oliveira", "Souza", "Lima", "Pereira", "Costa", "Ferreira", "Almeida", "Ribeiro",
+		"Carvalho", "Gomes", "Martins", "Rocha", "Barbosa"}
+
+	restaurantNames  = []string{"Cantina", "Bistrô", "Empório", "Casa", "Cozinha", "Sabor", "Recanto", "Estação"}
+	restaurantPlaces = []string{"da Vila", "do Centro", "da Praça", "do Porto", "da Serra", "do Bairro", "da Esquina", "do Mercado"}
+
+	menu = []seedProduct{
+		{"Pizza Margherita", "Molho de tomate, mussarela e manjericão", 4890},
+		{"Pizza Calabresa", "Calabresa fatiada, cebola e azeitonas", 4590},
+		{"Hambúrguer Artesanal", "Blend de 180g, queijo prato e salada", 3290},
+		{"Cheeseburger Duplo", "Dois blends de 120g com cheddar", 3790},
+		{"Feijoada", "Acompanha arroz, couve, farofa e laranja", 4290},
+		{"Strogonoff de Frango", "Acompanha arroz e batata palha", 3490},
+		{"Parmegiana de Carne", "Acompanha arroz e fritas", 4190},
+		{"Salada Caesar", "Alface, frango grelhado, croutons e parmesão", 2890},
+		{"Temaki de Salmão", "Salmão, cream cheese e cebolinha", 2990},
+		{"Yakisoba", "Macarrão, legumes e carne ao molho shoyu", 3690},
+		{"Batata Frita", "Porção de 400g", 1990},
+		{"Coxinha", "Porção com 6 unidades", 1790},
+		{"Pastel de Queijo", "Massa crocante recheada com mussarela", 990},
+		{"Açaí 500ml", "Com granola, banana e leite condensado", 2190},
+		{"Pudim", "Fatia de pudim de leite", 1290},
+		{"Brownie", "Com calda de chocolate", 1490},
+		{"Refrigerante Lata", "350ml", 690},
+		{"Suco Natural", "Laranja, limão ou maracujá, 500ml", 990},
+	}
+
+	// ratingWeights makes good evaluations more common, as in real apps.
+	ratingWeights = []int{5, 5, 15, 35, 40}
+
+	comments = map[int][]string{
+		1: {"O pedido chegou frio e atrasado.", "Veio faltando item e ninguém respondeu."},
+		2: {"Demorou bastante e a comida estava sem sal.", "Embalagem chegou aberta."},
+		3: {"Comida ok, mas poderia ser mais quente.", "Bom, mas a porção é pequena pelo preço."},
+		4: {"Muito gostoso, só demorou um pouco.", "Boa comida e entrega rápida."},
+		5: {"Perfeito! Peço sempre.", "Melhor da região, chegou quentinho.", "Atendimento excelente e comida maravilhosa."},
+	}
+
+	answers = map[int][]string{
+		1: {"Sentimos muito! Entre em contato para resolvermos."},
+		2: {"Obrigado pelo retorno, vamos melhorar."},
+		3: {"Agradecemos a avaliação, vamos caprichar mais!"},
+		4: {"Que bom que gostou! Volte sempre."},
+		5: {"Muito obrigado! Esperamos você de novo."},
+	}
+)
+
+type seedOptions struct {
+	Seed        int64
+	Restaurants int
+	Customers   int
+	Products    int
+	Orders      int
+	Evaluations int
+	Months      int
+}
+
+func (o seedOptions) validate() error {
+	if o.Restaurants < 1 || o.Customers < 1 || o.Products < 1 || o.Months < 1 {
+		return errors.New("restaurants, customers, products and months must be at least 1")
+	}
+
+	if o.Orders < 0 || o.Evaluations < 0 {
+		return errors.New("orders and evaluations can't be negative")
+	}
+
+	return nil
+}
+
+type seedProduct struct {
+	Name         string
+	Description  string
+	PriceInCents int
+}
+
+type seeder struct {
+	options seedOptions
+	rand    *rand.Rand
+	now     time.Time
+}
+
+// seedData holds the generated rows. The managers come first in users,
+// followed by the customers.
+type seedData struct {
+	users       []models.User
+	restaurants []models.Restaurant
+	members     []models.RestaurantMember
+	products    []models.Product
+	orders      []models.Order
+	orderItems  []models.OrderItem
+	evaluations []models.Evaluation
+
+	managers int
+}
+
+type seedTable struct {
+	Name    string
+	Created int64
+	Total   int
+}
+
+// newSeeder generates the data relative to now, which must be a fixed moment
+// for the output to be the same on every run. Passing the current day puts the
+// orders in the current and previous months the metrics compare.
+func newSeeder(options seedOptions, now time.Time) *seeder {
+	return &seeder{
+		options: options,
+		rand:    rand.New(rand.NewPCG(uint64(options.Seed), 0)),
+		now:     now.UTC(),
+	}
+}
+
+func (s *seeder) generate() *seedData {
+	data := &seedData{managers: s.options.Restaurants}
+
+	// Accounts and menus exist before the oldest order.
+	since := s.now.AddDate(0, -s.options.Months, 0)
+	createdAt := since.AddDate(0, 0, -7)
+
+	for i := range s.options.Restaurants {
+		data.users = append(data.users, s.user("manager", i, models.Manager, createdAt))
+	}
+
+	for i := range s.options.Customers {
+		data.users = append(data.users, s.user("customer", i, models.Customer, createdAt))
+	}
+
+	customers := data.customers()
+
+	for i := range s.options.Restaurants {
+		restaurant := models.Restaurant{
+			BaseModel: s.baseModel(createdAt, "restaurant", i),
+			Name:      fmt.Sprintf("%s %s", pick(s.rand, restaurantNames), pick(s.rand, restaurantPlaces)),
+			Description: sql.NullString{
+				String: "Restaurante de demonstração criado pelo seed.",
+				Valid:  true,
+			},
+			ManagerID: data.users[i].ID,
+			OpensAt:   "10:00",
+			ClosesAt:  "23:00",
+			Status:    models.ActiveRestaurant,
+		}
+		data.restaurants = append(data.restaurants, restaurant)
+
+		data.members = append(data.members, models.RestaurantMember{
+			BaseModel:    s.baseModel(createdAt, "member", i),
+			RestaurantID: restaurant.ID,
+			UserID:       restaurant.ManagerID,
+			Role:         models.OwnerMember,
+		})
+
+		products := s.products(restaurant.ID, i, createdAt)
+		data.products = append(data.products, products...)
+
+		for j := range s.options.Orders {
+			order, items := s.order(restaurant.ID, i, j, pick(s.rand, customers).ID, products, since)
+			data.orders = append(data.orders, order)
+			data.orderItems = append(data.orderItems, items...)
+		}
+
+		for j := range s.options.Evaluations {
+			data.evaluations = append(data.evaluations, s.evaluation(restaurant.ID, i, j, pick(s.rand, customers).ID, since))
+		}
+	}
+
+	return data
+}
+
+func (s *seeder) user(kind string, i int, role models.Role, createdAt time.Time) models.User {
+	return models.User{
+		BaseModel: s.baseModel(createdAt, kind, i),
+		FullName:  fmt.Sprintf("%s %s", pick(s.rand, firstNames), pick(s.rand, lastNames)),
+		Email:     fmt.Sprintf("%s%d@seed%d.foodshop.dev", kind, i+1, s.options.Seed),
+		Status:    models.Active,
+		Role:      role,
+		Phone:     sql.NullString{String: fmt.Sprintf("119%08d", s.rand.IntN(100_000_000)), Valid: true},
+	}
+}
+
+// products picks a different set of dishes from the menu for each restaurant.
+func (s *seeder) products(restaurantID uuid.UUID, restaurant int, createdAt time.Time) []models.Product {
+	var products []models.Product
+	for j, index := range s.rand.Perm(len(menu)) {
+		if j == s.options.Products {
+			break
+		}
+
+		item := menu[index]
+		products = append(products, models.Product{
+			BaseModel:    s.baseModel(createdAt, "product", restaurant, j),
+			Name:         item.Name,
+			Description:  sql.NullString{String: item.Description, Valid: true},
+			PriceInCents: item.PriceInCents,
+			RestaurantID: restaurantID,
+		})
+	}
+
+	return products
+}
+
+// order places the order at a random time since the first month. Recent orders
+// are still in progress, older ones were delivered or canceled.
+func (s *seeder) order(restaurantID uuid.UUID, restaurant, i int, customerID uuid.UUID, products []models.Product, since time.Time) (models.Order, []models.OrderItem) {
+	createdAt := s.timeSince(since)
+	order := models.Order{
+		BaseModel:    s.baseModel(createdAt, "order", restaurant, i),
+		CustommerID:  customerID,
+		RestaurantID: restaurantID,
+	}
+
+	var items []models.OrderItem
+	for j, index := range s.rand.Perm(len(products))[:1+s.rand.IntN(min(3, len(products)))] {
+		product := products[index]
+		item := models.OrderItem{
+			BaseModel:    s.baseModel(createdAt, "order-item", restaurant, i, j),
+			OrderID:      order.ID,
+			ProductID:    product.ID,
+			Quantity:     1 + s.rand.IntN(3),
+			PriceInCents: product.PriceInCents,
+		}
+
+		order.TotalInCents += item.PriceInCents * item.Quantity
+		items = append(items, item)
+	}
+
+	switch age := s.now.Sub(createdAt); {
+	case age < 20*time.Minute:
+		order.Status = models.Pending
+	case age < 40*time.Minute:
+		order.Status = models.Processing
+	case age < time.Hour:
+		order.Status = models.Delivering
+	case s.rand.IntN(10) == 0:
+		order.Status = models.Canceled
+	default:
+		order.Status = models.Delivered
+	}
+
+	if s.rand.IntN(3) == 0 {
+		order.TipInCents = order.TotalInCents * (5 + 5*s.rand.IntN(3)) / 100
+	}
+
+	return order, items
+}
+
+func (s *seeder) evaluation(restaurantID uuid.UUID, restaurant, i int, customerID uuid.UUID, since time.Time) models.Evaluation {
+	rating := weightedRating(s.rand)
+	evaluation := models.Evaluation{
+		BaseModel:    s.baseModel(s.timeSince(since), "evaluation", restaurant, i),
+		CustommerID:  customerID,
+		RestaurantID: restaurantID,
+		Rating:       rating,
+		Comment:      pick(s.rand, comments[rating]),
+	}
+
+	if s.rand.IntN(2) == 0 {
+		evaluation.Answer = sql.NullString{String: pick(s.rand, answers[rating]), Valid: true}
+	}
+
+	return evaluation
+}
+
+func (s *seeder) baseModel(createdAt time.Time, kind string, indexes ...int) models.BaseModel {
+	return models.BaseModel{
+		ID:        uuid.NewSHA1(seedNamespace, fmt.Appendf(nil, "%d/%s/%v", s.options.Seed, kind, indexes)),
+		CreatedAt: createdAt,
+	}
+}
+
+// timeSince returns a random moment between since and now, to the second.
+func (s *seeder) timeSince(since time.Time) time.Time {
+	return since.Add(time.Duration(s.rand.Int64N(int64(s.now.Sub(since)/time.Second))) * time.Second)
+}
+
+func (d *seedData) customers() []models.User {
+	return d.users[d.managers:]
+}
+
+// insert writes the data in a single transaction, skipping the rows whose ID
+// already exists. The model hooks are skipped so the generated creation dates
+// are kept.
+func (d *seedData) insert(ctx context.Context, db *gorm.DB) ([]seedTable, error) {
+	var tables []seedTable
+
+	err := db.WithContext(ctx).Session(&gorm.Session{SkipHooks: true}).Transaction(func(tx *gorm.DB) error {
+		for _, table := range []struct {
+			name  string
+			rows  any
+			total int
+		}{
+			{"Users", &d.users, len(d.users)},
+			{"Restaurants", &d.restaurants, len(d.restaurants)},
+			{"RestaurantMembers", &d.members, len(d.members)},
+			{"Products", &d.products, len(d.products)},
+			{"Orders", &d.orders, len(d.orders)},
+			{"OrderItems", &d.orderItems, len(d.orderItems)},
+			{"Evaluations", &d.evaluations, len(d.evaluations)},
+		} {
+			if table.total == 0 {
+				tables = append(tables, seedTable{Name: table.name})
+				continue
+			}
+
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).
+				Omit(clause.Associations).
+				CreateInBatches(table.rows, insertBatchSize)
+			if result.Error != nil {
+				return fmt.Errorf("insert %s: %w", table.name, result.Error)
+			}
+
+			tables = append(tables, seedTable{Name: table.name, Created: result.RowsAffected, Total: table.total})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tables, nil
+}
+
+func pick[T any](r *rand.Rand, values []T) T {
+	return values[r.IntN(len(values))]
+}
+
+func weightedRating(r *rand.Rand) int {
+	n := r.IntN(100)
+	for i, weight := range ratingWeights {
+		if n < weight {
+			return i + 1
+		}
+		n -= weight
+	}
+
+	return len(ratingWeights)
+}
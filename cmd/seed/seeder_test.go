@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeeder_Generate(t *testing.T) {
+	options := seedOptions{
+		Seed:        7,
+		Restaurants: 2,
+		Customers:   5,
+		Products:    4,
+		Orders:      10,
+		Evaluations: 3,
+		Months:      2,
+	}
+
+	reference, err := time.Parse(time.DateOnly, defaultSeedReference)
+	require.NoError(t, err)
+
+	t.Run("should generate the same data on every run", func(t *testing.T) {
+		first := newSeeder(options, reference).generate()
+		second := newSeeder(options, reference).generate()
+
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("should generate the data up to the reference day", func(t *testing.T) {
+		data := newSeeder(options, reference).generate()
+
+		require.Len(t, data.orders, options.Restaurants*options.Orders)
+		for _, order := range data.orders {
+			assert.False(t, order.CreatedAt.After(reference))
+			assert.False(t, order.CreatedAt.Before(reference.AddDate(0, -options.Months, 0)))
+		}
+	})
+}
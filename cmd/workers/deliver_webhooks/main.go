@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/client"
+	"github.com/G-Villarinho/food-shop-api/config"
+	"github.com/G-Villarinho/food-shop-api/database"
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/repositories"
+	"github.com/G-Villarinho/food-shop-api/services"
+	"github.com/G-Villarinho/food-shop-api/workers"
+	"gorm.io/gorm"
+)
+
+func main() {
+	config.ConfigureLogger()
+	config.LoadEnvironments()
+
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run returns once SIGINT or SIGTERM is received and the deliveries in
+// progress are done, closing the connections on the way out.
+func run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	di := internal.NewDi()
+
+	connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	db, err := database.NewMysqlConnection(connectCtx)
+	if err != nil {
+		return fmt.Errorf("error to connect to database: %w", err)
+	}
+	defer func() {
+		if err := database.CloseMysqlConnection(db); err != nil {
+			log.Println("error closing database connection:", err)
+		}
+	}()
+
+	internal.Provide(di, func(d *internal.Di) (*gorm.DB, error) {
+		return db, nil
+	})
+
+	rabbitMQClient, err := client.NewRabbitMQClient(di)
+	if err != nil {
+		return fmt.Errorf("error initializing RabbitMQ client: %w", err)
+	}
+
+	if err := rabbitMQClient.Connect(); err != nil {
+		return fmt.Errorf("error connecting to RabbitMQ: %w", err)
+	}
+	defer func() {
+		if err := rabbitMQClient.Disconnect(); err != nil {
+			log.Println("error disconnecting from RabbitMQ:", err)
+		}
+	}()
+
+	internal.Provide(di, func(d *internal.Di) (client.RabbitMQClient, error) {
+		return rabbitMQClient, nil
+	})
+
+	internal.Provide(di, services.NewQueueService)
+	internal.Provide(di, services.NewWebhookService)
+	internal.Provide(di, repositories.NewWebhookRepository)
+
+	webhookService, err := internal.Invoke[services.WebhookService](di)
+	if err != nil {
+		return fmt.Errorf("error to create webhook service: %w", err)
+	}
+
+	queueService, err := internal.Invoke[services.QueueService](di)
+	if err != nil {
+		return fmt.Errorf("error to create queue service: %w", err)
+	}
+
+	return workers.Run(ctx, config.ShutdownTimeout(),
+		func(ctx context.Context) error {
+			return workers.RetryDueWebhookDeliveries(ctx, webhookService)
+		},
+		func(ctx context.Context) error {
+			return workers.DispatchWebhookEvents(ctx, queueService, webhookService)
+		},
+		func(ctx context.Context) error {
+			return workers.DeliverWebhooks(ctx, queueService, webhookService)
+		},
+	)
+}
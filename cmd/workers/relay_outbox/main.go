@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/client"
+	"github.com/G-Villarinho/food-shop-api/config"
+	"github.com/G-Villarinho/food-shop-api/database"
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/repositories"
+	"github.com/G-Villarinho/food-shop-api/services"
+	"github.com/G-Villarinho/food-shop-api/workers"
+	"gorm.io/gorm"
+)
+
+func main() {
+	config.ConfigureLogger()
+	config.LoadEnvironments()
+
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run returns once SIGINT or SIGTERM is received and the batch being relayed
+// is done, closing the connections on the way out.
+func run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	di := internal.NewDi()
+
+	connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	db, err := database.NewMysqlConnection(connectCtx)
+	if err != nil {
+		return fmt.Errorf("error to connect to database: %w", err)
+	}
+	defer func() {
+		if err := database.CloseMysqlConnection(db); err != nil {
+			log.Println("error closing database connection:", err)
+		}
+	}()
+
+	internal.Provide(di, func(d *internal.Di) (*gorm.DB, error) {
+		return db, nil
+	})
+
+	rabbitMQClient, err := client.NewRabbitMQClient(di)
+	if err != nil {
+		return fmt.Errorf("error initializing RabbitMQ client: %w", err)
+	}
+
+	if err := rabbitMQClient.Connect(); err != nil {
+		return fmt.Errorf("error connecting to RabbitMQ: %w", err)
+	}
+	defer func() {
+		if err := rabbitMQClient.Disconnect(); err != nil {
+			log.Println("error disconnecting from RabbitMQ:", err)
+		}
+	}()
+
+	internal.Provide(di, func(d *internal.Di) (client.RabbitMQClient, error) {
+		return rabbitMQClient, nil
+	})
+
+	internal.Provide(di, services.NewOutboxService)
+	internal.Provide(di, services.NewQueueService)
+	internal.Provide(di, repositories.NewOutboxRepository)
+
+	outboxService, err := internal.Invoke[services.OutboxService](di)
+	if err != nil {
+		return fmt.Errorf("error to create outbox service: %w", err)
+	}
+
+	return workers.Run(ctx, config.ShutdownTimeout(),
+		func(ctx context.Context) error {
+			return workers.DeletePublishedOutboxMessages(ctx, outboxService)
+		},
+		func(ctx context.Context) error {
+			return workers.RelayOutbox(ctx, outboxService)
+		},
+	)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/config"
+	"github.com/G-Villarinho/food-shop-api/database"
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/repositories"
+	"github.com/G-Villarinho/food-shop-api/services"
+	"github.com/G-Villarinho/food-shop-api/workers"
+	"gorm.io/gorm"
+)
+
+func main() {
+	config.ConfigureLogger()
+	config.LoadEnvironments()
+
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run returns once SIGINT or SIGTERM is received and the release in progress
+// is done, closing the database connection on the way out.
+func run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	di := internal.NewDi()
+
+	connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	db, err := database.NewMysqlConnection(connectCtx)
+	if err != nil {
+		return fmt.Errorf("error to connect to database: %w", err)
+	}
+	defer func() {
+		if err := database.CloseMysqlConnection(db); err != nil {
+			log.Println("error closing database connection:", err)
+		}
+	}()
+
+	internal.Provide(di, func(d *internal.Di) (*gorm.DB, error) {
+		return db, nil
+	})
+
+	internal.Provide(di, services.NewOrderItemService)
+	internal.Provide(di, services.NewOrderService)
+
+	internal.Provide(di, repositories.NewOrderRepository)
+	internal.Provide(di, repositories.NewProductRepository)
+	internal.Provide(di, repositories.NewRestaurantRepository)
+	internal.Provide(di, repositories.NewUserRepository)
+
+	orderService, err := internal.Invoke[services.OrderService](di)
+	if err != nil {
+		return fmt.Errorf("error to create order service: %w", err)
+	}
+
+	return workers.Run(ctx, config.ShutdownTimeout(), func(ctx context.Context) error {
+		return workers.ReleaseScheduledOrders(ctx, orderService)
+	})
+}
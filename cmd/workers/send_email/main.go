@@ -2,34 +2,53 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/G-Villarinho/food-shop-api/client"
 	"github.com/G-Villarinho/food-shop-api/config"
 	"github.com/G-Villarinho/food-shop-api/database"
 	"github.com/G-Villarinho/food-shop-api/internal"
-	"github.com/G-Villarinho/food-shop-api/models"
 	"github.com/G-Villarinho/food-shop-api/services"
 	"github.com/G-Villarinho/food-shop-api/services/email"
 	"github.com/G-Villarinho/food-shop-api/templates"
+	"github.com/G-Villarinho/food-shop-api/workers"
 	"github.com/go-redis/redis/v8"
-	jsoniter "github.com/json-iterator/go"
 )
 
 func main() {
 	config.ConfigureLogger()
 	config.LoadEnvironments()
 
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run returns once SIGINT or SIGTERM is received and the email being sent is
+// done, closing the connections on the way out.
+func run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	di := internal.NewDi()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	redisClient, err := database.NewRedisConnection(ctx)
+	redisClient, err := database.NewRedisConnection(connectCtx)
 	if err != nil {
-		log.Fatal("error to connect to redis: ", err)
+		return fmt.Errorf("error to connect to redis: %w", err)
 	}
+	defer func() {
+		if err := redisClient.Close(); err != nil {
+			log.Println("error closing redis connection:", err)
+		}
+	}()
 
 	internal.Provide(di, func(d *internal.Di) (*redis.Client, error) {
 		return redisClient, nil
@@ -37,11 +56,11 @@ func main() {
 
 	rabbitMQClient, err := client.NewRabbitMQClient(di)
 	if err != nil {
-		log.Fatal("error initializing RabbitMQ client: ", err)
+		return fmt.Errorf("error initializing RabbitMQ client: %w", err)
 	}
 
 	if err := rabbitMQClient.Connect(); err != nil {
-		log.Fatal("error connecting to RabbitMQ: ", err)
+		return fmt.Errorf("error connecting to RabbitMQ: %w", err)
 	}
 	defer func() {
 		if err := rabbitMQClient.Disconnect(); err != nil {
@@ -60,34 +79,15 @@ func main() {
 
 	emailService, err := internal.Invoke[email.EmailService](di)
 	if err != nil {
-		log.Fatal("error to create like service: ", err)
+		return fmt.Errorf("error to create email service: %w", err)
 	}
 
 	queueService, err := internal.Invoke[services.QueueService](di)
 	if err != nil {
-		log.Fatal("error to create queue service: ", err)
-	}
-
-	for {
-		messages, err := queueService.Consume(services.QueueSendEmail)
-		if err != nil {
-			log.Fatal("error to consume message from queue: ", err)
-		}
-
-		for message := range messages {
-			var task models.EmailQueueTask
-			if err := jsoniter.Unmarshal(message, &task); err != nil {
-				log.Println("error unmarshalling email task: ", err)
-				continue
-			}
-
-			if err := emailService.SendEmail(context.Background(), task); err != nil {
-				log.Println("error sending email: ", err)
-				continue
-			}
-
-			log.Println("email sent successfully")
-		}
+		return fmt.Errorf("error to create queue service: %w", err)
 	}
 
+	return workers.Run(ctx, config.ShutdownTimeout(), func(ctx context.Context) error {
+		return workers.SendEmails(ctx, queueService, emailService)
+	})
 }
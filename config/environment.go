@@ -1,11 +1,32 @@
 package config
 
 import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
 	"github.com/G-Villarinho/food-shop-api/config/models"
 	"github.com/Netflix/go-env"
 	"github.com/joho/godotenv"
 )
 
+// MemoryDriver keeps the cache or the queue inside the process, so the API can
+// run without Redis or RabbitMQ. Nothing survives a restart.
+const MemoryDriver = "memory"
+
+const (
+	defaultShutdownTimeout    = 30 * time.Second
+	defaultCourierLocationExp = 5 * time.Minute
+	defaultInvitationExp      = 72 * time.Hour
+	defaultAccessTokenExp     = 15 * time.Minute
+	defaultSignInCodeExp      = 10 * time.Minute
+	defaultSignInCodeLockout  = 15 * time.Minute
+	defaultSignInIPLimit      = 10
+	defaultSignInEmailLimit   = 5
+	defaultSignInWindow       = 15 * time.Minute
+)
+
 var Env models.Environment
 
 func LoadEnvironments() {
@@ -20,3 +41,128 @@ func LoadEnvironments() {
 	}
 
 }
+
+// ShutdownTimeout is how long the API and the workers wait for the requests
+// and messages in progress after SIGINT or SIGTERM, from SHUTDOWN_TIMEOUT in
+// seconds.
+func ShutdownTimeout() time.Duration {
+	if Env.ShutdownTimeout <= 0 {
+		return defaultShutdownTimeout
+	}
+
+	return time.Duration(Env.ShutdownTimeout) * time.Second
+}
+
+// CourierLocationExp is how long the last position sent by a courier is kept,
+// from COURIER_LOCATION_EXP in seconds. It always expires, so a courier who
+// stops sending updates doesn't leave a stale position behind.
+func CourierLocationExp() time.Duration {
+	if Env.Tracking.LocationExp <= 0 {
+		return defaultCourierLocationExp
+	}
+
+	return time.Duration(Env.Tracking.LocationExp) * time.Second
+}
+
+// AccessTokenExp is how long an access token is valid, from ACCESS_TOKEN_EXP in
+// minutes. It is kept short, since the refresh token renews it.
+func AccessTokenExp() time.Duration {
+	if Env.Cache.AccessTokenExp <= 0 {
+		return defaultAccessTokenExp
+	}
+
+	return time.Duration(Env.Cache.AccessTokenExp) * time.Minute
+}
+
+// SignInCodeExp is how long a sign-in code sent by e-mail is valid, from
+// CODE_2FA_DURATION in minutes.
+func SignInCodeExp() time.Duration {
+	if Env.Cache.Code2FADuration <= 0 {
+		return defaultSignInCodeExp
+	}
+
+	return time.Duration(Env.Cache.Code2FADuration) * time.Minute
+}
+
+// SignInCodeLockout is how long the failed sign-in code attempts of a user are
+// counted, from SIGN_IN_CODE_LOCKOUT in minutes. It always expires, so a user
+// who hits the limit can sign in with a code again afterwards.
+func SignInCodeLockout() time.Duration {
+	if Env.Cache.SignInLockout <= 0 {
+		return defaultSignInCodeLockout
+	}
+
+	return time.Duration(Env.Cache.SignInLockout) * time.Minute
+}
+
+// SignInIPLimit and SignInEmailLimit are how many sign-in requests an IP or an
+// e-mail can make per SignInWindow, from RATE_LIMIT_SIGN_IN_IP and
+// RATE_LIMIT_SIGN_IN_EMAIL. Unset uses the default and a negative value turns
+// the limit off.
+func SignInIPLimit() int {
+	if Env.RateLimit.SignInIPLimit == 0 {
+		return defaultSignInIPLimit
+	}
+
+	return Env.RateLimit.SignInIPLimit
+}
+
+func SignInEmailLimit() int {
+	if Env.RateLimit.SignInEmailLimit == 0 {
+		return defaultSignInEmailLimit
+	}
+
+	return Env.RateLimit.SignInEmailLimit
+}
+
+// SignInWindow is the window of the sign-in rate limits, from
+// RATE_LIMIT_SIGN_IN_WINDOW in seconds.
+func SignInWindow() time.Duration {
+	if Env.RateLimit.SignInWindow <= 0 {
+		return defaultSignInWindow
+	}
+
+	return time.Duration(Env.RateLimit.SignInWindow) * time.Second
+}
+
+// InvitationExp is how long an invitation to join a restaurant can be
+// accepted, from RESTAURANT_INVITATION_EXP in hours.
+func InvitationExp() time.Duration {
+	if Env.Cache.InvitationExp <= 0 {
+		return defaultInvitationExp
+	}
+
+	return time.Duration(Env.Cache.InvitationExp) * time.Hour
+}
+
+// TrustedProxies are the networks of the reverse proxies in front of the API,
+// from TRUSTED_PROXIES as a comma separated list of IPs or CIDRs. Only they
+// are trusted to tell the client IP in X-Forwarded-For.
+func TrustedProxies() ([]*net.IPNet, error) {
+	var proxies []*net.IPNet
+	for _, proxy := range strings.Split(Env.TrustedProxies, ",") {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+
+		if ip := net.ParseIP(proxy); ip != nil {
+			bits := 8 * len(ip.To16())
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("parse trusted proxy %q: %w", proxy, err)
+		}
+
+		proxies = append(proxies, network)
+	}
+
+	return proxies, nil
+}
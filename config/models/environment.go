@@ -1,19 +1,28 @@
 package models
 
 type Environment struct {
-	PrivateKey       string `env:"PRIVATE_KEY"`
-	PublicKey        string `env:"PUBLIC_KEY"`
-	Redis            RedisEnvironment
-	CloudFlare       CloudFlareEnvironment
-	Cache            CacheEnvironment
-	Email            EmailEnvironment
-	APIBaseURL       string `env:"API_BASE_URL"`
-	RedirectURL      string `env:"REDIRECT_URL"`
-	CookieName       string `env:"COOKIE_NAME"`
-	RabbitMQURL      string `env:"RABBITMQ_URL"`
-	APIPort          int    `env:"API_PORT"`
-	ConnectionString string `env:"CONNECTION_STRING"`
-	FrontURL         string `env:"FRONT_URL"`
+	PrivateKey         string `env:"PRIVATE_KEY"`
+	PublicKey          string `env:"PUBLIC_KEY"`
+	PreviousPublicKeys string `env:"PREVIOUS_PUBLIC_KEYS"`
+	Redis              RedisEnvironment
+	CloudFlare         CloudFlareEnvironment
+	Cache              CacheEnvironment
+	Email              EmailEnvironment
+	Scheduling         SchedulingEnvironment
+	Tracking           TrackingEnvironment
+	RateLimit          RateLimitEnvironment
+	Queue              QueueEnvironment
+	Outbox             OutboxEnvironment
+	APIBaseURL         string `env:"API_BASE_URL"`
+	RedirectURL        string `env:"REDIRECT_URL"`
+	CookieName         string `env:"COOKIE_NAME"`
+	RefreshCookieName  string `env:"REFRESH_COOKIE_NAME"`
+	RabbitMQURL        string `env:"RABBITMQ_URL"`
+	APIPort            int    `env:"API_PORT"`
+	ShutdownTimeout    int    `env:"SHUTDOWN_TIMEOUT"`
+	ConnectionString   string `env:"CONNECTION_STRING"`
+	FrontURL           string `env:"FRONT_URL"`
+	TrustedProxies     string `env:"TRUSTED_PROXIES"`
 }
 
 type RedisEnvironment struct {
@@ -28,10 +37,14 @@ type CloudFlareEnvironment struct {
 }
 
 type CacheEnvironment struct {
-	SessionExp      int `env:"SESSION_EXP"`
-	CacheExp        int `env:"CACHE_EXP"`
-	Hash2FADuration int `env:"HASH_2FA_DURATION"`
-	Code2FADuration int `env:"CODE_2FA_DURATION"`
+	Driver          string `env:"CACHE_DRIVER"`
+	SessionExp      int    `env:"SESSION_EXP"`
+	AccessTokenExp  int    `env:"ACCESS_TOKEN_EXP"`
+	CacheExp        int    `env:"CACHE_EXP"`
+	Hash2FADuration int    `env:"HASH_2FA_DURATION"`
+	Code2FADuration int    `env:"CODE_2FA_DURATION"`
+	SignInLockout   int    `env:"SIGN_IN_CODE_LOCKOUT"`
+	InvitationExp   int    `env:"RESTAURANT_INVITATION_EXP"`
 }
 
 type EmailEnvironment struct {
@@ -39,3 +52,34 @@ type EmailEnvironment struct {
 	EmailClientBaseURL string `env:"EMAIL_CLIENT_BASE_URL"`
 	EmailSender        string `env:"EMAIL_SENDER"`
 }
+
+type SchedulingEnvironment struct {
+	MinLeadTime   int    `env:"SCHEDULED_ORDER_MIN_LEAD_TIME"`
+	ReleaseBefore int    `env:"SCHEDULED_ORDER_RELEASE_BEFORE"`
+	JobInterval   int    `env:"SCHEDULED_ORDER_JOB_INTERVAL"`
+	Timezone      string `env:"RESTAURANT_TIMEZONE"`
+}
+
+type TrackingEnvironment struct {
+	LocationExp    int `env:"COURIER_LOCATION_EXP"`
+	AverageSpeed   int `env:"COURIER_AVERAGE_SPEED"`
+	StreamInterval int `env:"TRACKING_STREAM_INTERVAL"`
+}
+
+type RateLimitEnvironment struct {
+	SignInIPLimit    int `env:"RATE_LIMIT_SIGN_IN_IP"`
+	SignInEmailLimit int `env:"RATE_LIMIT_SIGN_IN_EMAIL"`
+	SignInWindow     int `env:"RATE_LIMIT_SIGN_IN_WINDOW"`
+}
+
+type QueueEnvironment struct {
+	Driver     string `env:"QUEUE_DRIVER"`
+	MaxRetries int    `env:"QUEUE_MAX_RETRIES"`
+	RetryDelay int    `env:"QUEUE_RETRY_DELAY"`
+}
+
+type OutboxEnvironment struct {
+	RelayInterval int `env:"OUTBOX_RELAY_INTERVAL"`
+	Retention     int `env:"OUTBOX_RETENTION"`
+	MaxAttempts   int `env:"OUTBOX_MAX_ATTEMPTS"`
+}
@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS `OutboxMessages`;
+DROP TABLE IF EXISTS `WebhookDeliveries`;
+DROP TABLE IF EXISTS `Webhooks`;
+DROP TABLE IF EXISTS `APIKeys`;
+DROP TABLE IF EXISTS `RolePermissions`;
+DROP TABLE IF EXISTS `RestaurantMembers`;
+DROP TABLE IF EXISTS `Evaluations`;
+DROP TABLE IF EXISTS `OrderItems`;
+DROP TABLE IF EXISTS `Orders`;
+DROP TABLE IF EXISTS `Products`;
+DROP TABLE IF EXISTS `Restaurants`;
+DROP TABLE IF EXISTS `Users`;
@@ -0,0 +1,213 @@
+-- Schema created by GORM AutoMigrate before versioned migrations were
+-- introduced. IF NOT EXISTS makes it a no-op on databases created that way.
+
+CREATE TABLE IF NOT EXISTS `Users` (
+  `Id` char(36),
+  `CreatedAt` datetime(3) NOT NULL,
+  `UpdatedAt` datetime(3) NULL DEFAULT null,
+  `DeletedAt` datetime(3) NULL,
+  `FullName` varchar(255) NOT NULL,
+  `Email` varchar(255) NOT NULL,
+  `Status` enum('active', 'blocked') NOT NULL DEFAULT 'active',
+  `Role` enum('manager', 'customer', 'courier', 'admin') NOT NULL DEFAULT 'customer',
+  `Phone` varchar(20),
+  `Avatar` varchar(255),
+  PRIMARY KEY (`Id`),
+  INDEX `idx_Users_deleted_at` (`DeletedAt`),
+  INDEX `idx_Users_role` (`Role`),
+  CONSTRAINT `uni_Users_email` UNIQUE (`Email`)
+);
+
+CREATE TABLE IF NOT EXISTS `Restaurants` (
+  `Id` char(36),
+  `CreatedAt` datetime(3) NOT NULL,
+  `UpdatedAt` datetime(3) NULL DEFAULT null,
+  `DeletedAt` datetime(3) NULL,
+  `Name` varchar(255) NOT NULL,
+  `Description` text DEFAULT null,
+  `ManagerID` char(36) NOT NULL,
+  `OpensAt` char(5) NOT NULL DEFAULT '08:00',
+  `ClosesAt` char(5) NOT NULL DEFAULT '22:00',
+  `Status` enum('active', 'suspended') NOT NULL DEFAULT 'active',
+  PRIMARY KEY (`Id`),
+  INDEX `idx_Restaurants_status` (`Status`),
+  INDEX `idx_Restaurants_deleted_at` (`DeletedAt`),
+  CONSTRAINT `fk_Restaurants_manager` FOREIGN KEY (`ManagerID`) REFERENCES `Users`(`Id`)
+);
+
+CREATE TABLE IF NOT EXISTS `Products` (
+  `Id` char(36),
+  `CreatedAt` datetime(3) NOT NULL,
+  `UpdatedAt` datetime(3) NULL DEFAULT null,
+  `DeletedAt` datetime(3) NULL,
+  `Name` varchar(255) NOT NULL,
+  `Description` varchar(400) DEFAULT null,
+  `PriceInCents` int NOT NULL,
+  `RestaurantID` char(36) NOT NULL,
+  PRIMARY KEY (`Id`),
+  INDEX `idx_Products_deleted_at` (`DeletedAt`),
+  CONSTRAINT `fk_Products_restaurant` FOREIGN KEY (`RestaurantID`) REFERENCES `Restaurants`(`Id`)
+);
+
+CREATE TABLE IF NOT EXISTS `Orders` (
+  `Id` char(36),
+  `CreatedAt` datetime(3) NOT NULL,
+  `UpdatedAt` datetime(3) NULL DEFAULT null,
+  `DeletedAt` datetime(3) NULL,
+  `CustommerID` char(36) NOT NULL,
+  `RestaurantID` char(36) NOT NULL,
+  `Status` enum('scheduled', 'pending', 'canceled', 'processing', 'delivering', 'delivered') NOT NULL DEFAULT 'pending',
+  `TotalInCents` int NOT NULL,
+  `TipInCents` int NOT NULL DEFAULT 0,
+  `ScheduledFor` datetime(3) NULL DEFAULT null,
+  `CourierID` char(36) DEFAULT null,
+  `DeliveryLatitude` decimal(10,8) DEFAULT null,
+  `DeliveryLongitude` decimal(11,8) DEFAULT null,
+  PRIMARY KEY (`Id`),
+  INDEX `idx_Orders_status` (`Status`),
+  INDEX `idx_Orders_scheduled_for` (`ScheduledFor`),
+  INDEX `idx_Orders_courier_id` (`CourierID`),
+  INDEX `idx_Orders_deleted_at` (`DeletedAt`),
+  CONSTRAINT `fk_Orders_custommer` FOREIGN KEY (`CustommerID`) REFERENCES `Users`(`Id`),
+  CONSTRAINT `fk_Orders_restaurant` FOREIGN KEY (`RestaurantID`) REFERENCES `Restaurants`(`Id`)
+);
+
+CREATE TABLE IF NOT EXISTS `OrderItems` (
+  `Id` char(36),
+  `CreatedAt` datetime(3) NOT NULL,
+  `UpdatedAt` datetime(3) NULL DEFAULT null,
+  `DeletedAt` datetime(3) NULL,
+  `OrderID` char(36) NOT NULL,
+  `ProductID` char(36) NOT NULL,
+  `Quantity` int NOT NULL,
+  `PriceInCents` int NOT NULL,
+  PRIMARY KEY (`Id`),
+  INDEX `idx_OrderItems_deleted_at` (`DeletedAt`),
+  CONSTRAINT `fk_OrderItems_order` FOREIGN KEY (`OrderID`) REFERENCES `Orders`(`Id`),
+  CONSTRAINT `fk_OrderItems_product` FOREIGN KEY (`ProductID`) REFERENCES `Products`(`Id`)
+);
+
+CREATE TABLE IF NOT EXISTS `Evaluations` (
+  `Id` char(36),
+  `CreatedAt` datetime(3) NOT NULL,
+  `UpdatedAt` datetime(3) NULL DEFAULT null,
+  `DeletedAt` datetime(3) NULL,
+  `CustommerID` char(36) NOT NULL,
+  `RestaurantID` char(36) NOT NULL,
+  `Rating` int NOT NULL,
+  `Comment` text NOT NULL,
+  `Answer` text DEFAULT null,
+  PRIMARY KEY (`Id`),
+  INDEX `idx_Evaluations_deleted_at` (`DeletedAt`),
+  CONSTRAINT `fk_Evaluations_custommer` FOREIGN KEY (`CustommerID`) REFERENCES `Users`(`Id`),
+  CONSTRAINT `fk_Evaluations_restaurant` FOREIGN KEY (`RestaurantID`) REFERENCES `Restaurants`(`Id`)
+);
+
+CREATE TABLE IF NOT EXISTS `RestaurantMembers` (
+  `Id` char(36),
+  `CreatedAt` datetime(3) NOT NULL,
+  `UpdatedAt` datetime(3) NULL DEFAULT null,
+  `DeletedAt` datetime(3) NULL,
+  `RestaurantID` char(36) NOT NULL,
+  `UserID` char(36) NOT NULL,
+  `Role` enum('owner', 'manager', 'kitchen', 'cashier') NOT NULL DEFAULT 'manager',
+  PRIMARY KEY (`Id`),
+  UNIQUE INDEX `idx_RestaurantMembers_user_id` (`UserID`),
+  INDEX `idx_RestaurantMembers_deleted_at` (`DeletedAt`),
+  INDEX `idx_RestaurantMembers_restaurant_id` (`RestaurantID`),
+  CONSTRAINT `fk_RestaurantMembers_user` FOREIGN KEY (`UserID`) REFERENCES `Users`(`Id`),
+  CONSTRAINT `fk_Restaurants_members` FOREIGN KEY (`RestaurantID`) REFERENCES `Restaurants`(`Id`) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS `RolePermissions` (
+  `Id` char(36),
+  `CreatedAt` datetime(3) NOT NULL,
+  `UpdatedAt` datetime(3) NULL DEFAULT null,
+  `DeletedAt` datetime(3) NULL,
+  `Scope` enum('platform', 'member') NOT NULL,
+  `Role` varchar(50) NOT NULL,
+  `Permission` varchar(100) NOT NULL,
+  PRIMARY KEY (`Id`),
+  INDEX `idx_RolePermissions_deleted_at` (`DeletedAt`),
+  UNIQUE INDEX `idx_role_permission` (`Scope`,`Role`,`Permission`)
+);
+
+CREATE TABLE IF NOT EXISTS `APIKeys` (
+  `Id` char(36),
+  `CreatedAt` datetime(3) NOT NULL,
+  `UpdatedAt` datetime(3) NULL DEFAULT null,
+  `DeletedAt` datetime(3) NULL,
+  `RestaurantID` char(36) NOT NULL,
+  `CreatedBy` char(36) NOT NULL,
+  `Name` varchar(100) NOT NULL,
+  `Prefix` varchar(16) NOT NULL,
+  `Hash` char(64) NOT NULL,
+  `Scopes` varchar(1000) NOT NULL,
+  `ExpiresAt` datetime(3) NULL,
+  `LastUsedAt` datetime(3) NULL,
+  PRIMARY KEY (`Id`),
+  INDEX `idx_APIKeys_deleted_at` (`DeletedAt`),
+  INDEX `idx_APIKeys_restaurant_id` (`RestaurantID`),
+  UNIQUE INDEX `idx_APIKeys_prefix` (`Prefix`),
+  CONSTRAINT `fk_APIKeys_restaurant` FOREIGN KEY (`RestaurantID`) REFERENCES `Restaurants`(`Id`)
+);
+
+CREATE TABLE IF NOT EXISTS `Webhooks` (
+  `Id` char(36),
+  `CreatedAt` datetime(3) NOT NULL,
+  `UpdatedAt` datetime(3) NULL DEFAULT null,
+  `DeletedAt` datetime(3) NULL,
+  `RestaurantID` char(36) NOT NULL,
+  `URL` varchar(2048) NOT NULL,
+  `Secret` varchar(64) NOT NULL,
+  `Events` varchar(255) NOT NULL,
+  PRIMARY KEY (`Id`),
+  INDEX `idx_Webhooks_deleted_at` (`DeletedAt`),
+  INDEX `idx_Webhooks_restaurant_id` (`RestaurantID`),
+  CONSTRAINT `fk_Webhooks_restaurant` FOREIGN KEY (`RestaurantID`) REFERENCES `Restaurants`(`Id`)
+);
+
+CREATE TABLE IF NOT EXISTS `WebhookDeliveries` (
+  `Id` char(36),
+  `CreatedAt` datetime(3) NOT NULL,
+  `UpdatedAt` datetime(3) NULL DEFAULT null,
+  `DeletedAt` datetime(3) NULL,
+  `WebhookID` char(36) NOT NULL,
+  `EventID` char(36) NOT NULL,
+  `Event` varchar(50) NOT NULL,
+  `Payload` text NOT NULL,
+  `Status` enum('pending', 'succeeded', 'failed') NOT NULL DEFAULT 'pending',
+  `Attempts` bigint NOT NULL DEFAULT 0,
+  `ResponseStatus` int,
+  `Error` varchar(1000),
+  `NextAttemptAt` datetime(3) NULL,
+  `DeliveredAt` datetime(3) NULL,
+  PRIMARY KEY (`Id`),
+  INDEX `idx_WebhookDeliveries_deleted_at` (`DeletedAt`),
+  UNIQUE INDEX `idx_webhook_delivery_event` (`WebhookID`,`EventID`),
+  INDEX `idx_webhook_delivery_retry` (`Status`,`NextAttemptAt`),
+  CONSTRAINT `fk_WebhookDeliveries_webhook` FOREIGN KEY (`WebhookID`) REFERENCES `Webhooks`(`Id`)
+);
+
+CREATE TABLE IF NOT EXISTS `OutboxMessages` (
+  `Id` char(36),
+  `CreatedAt` datetime(3) NOT NULL,
+  `UpdatedAt` datetime(3) NULL DEFAULT null,
+  `DeletedAt` datetime(3) NULL,
+  `Queue` varchar(100) NOT NULL,
+  `Payload` mediumtext NOT NULL,
+  `Attempts` bigint NOT NULL DEFAULT 0,
+  `LastError` varchar(1000),
+  `PublishedAt` datetime(3) NULL,
+  PRIMARY KEY (`Id`),
+  INDEX `idx_OutboxMessages_deleted_at` (`DeletedAt`),
+  INDEX `idx_OutboxMessages_published_at` (`PublishedAt`)
+);
+
+-- Restaurants created before members existed get their manager as owner.
+INSERT INTO RestaurantMembers (Id, RestaurantID, UserID, Role, CreatedAt)
+SELECT UUID(), Restaurants.Id, Restaurants.ManagerID, 'owner', UTC_TIMESTAMP()
+FROM Restaurants
+WHERE NOT EXISTS (
+  SELECT 1 FROM RestaurantMembers WHERE RestaurantMembers.UserID = Restaurants.ManagerID
+);
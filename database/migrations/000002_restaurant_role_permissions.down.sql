@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `SeededRolePermissions`;
+DROP TABLE IF EXISTS `RestaurantRolePermissions`;
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS `RestaurantRolePermissions` (
+  `Id` char(36),
+  `CreatedAt` datetime(3) NOT NULL,
+  `UpdatedAt` datetime(3) NULL DEFAULT null,
+  `DeletedAt` datetime(3) NULL,
+  `RestaurantID` char(36) NOT NULL,
+  `Role` varchar(50) NOT NULL,
+  `Permission` varchar(100) NOT NULL,
+  `Granted` boolean NOT NULL,
+  PRIMARY KEY (`Id`),
+  INDEX `idx_RestaurantRolePermissions_deleted_at` (`DeletedAt`),
+  UNIQUE INDEX `idx_restaurant_role_permission` (`RestaurantID`,`Role`,`Permission`),
+  CONSTRAINT `fk_RestaurantRolePermissions_restaurant` FOREIGN KEY (`RestaurantID`) REFERENCES `Restaurants`(`Id`) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS `SeededRolePermissions` (
+  `Id` char(36),
+  `CreatedAt` datetime(3) NOT NULL,
+  `UpdatedAt` datetime(3) NULL DEFAULT null,
+  `DeletedAt` datetime(3) NULL,
+  `Scope` enum('platform', 'member') NOT NULL,
+  `Role` varchar(50) NOT NULL,
+  `Permission` varchar(100) NOT NULL,
+  PRIMARY KEY (`Id`),
+  INDEX `idx_SeededRolePermissions_deleted_at` (`DeletedAt`),
+  UNIQUE INDEX `idx_seeded_role_permission` (`Scope`,`Role`,`Permission`)
+);
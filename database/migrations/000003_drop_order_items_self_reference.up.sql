@@ -0,0 +1,16 @@
+-- Databases created by GORM AutoMigrate have a foreign key from
+-- OrderItems.OrderID to OrderItems.Id, which rejects every order item. MySQL
+-- has no DROP FOREIGN KEY IF EXISTS, so the statement is built only when the
+-- constraint is there.
+
+SET @drop_order_items_self_reference = (
+  SELECT IF(COUNT(*) > 0, 'ALTER TABLE `OrderItems` DROP FOREIGN KEY `fk_OrderItems_order_items`', 'DO 0')
+  FROM information_schema.TABLE_CONSTRAINTS
+  WHERE CONSTRAINT_SCHEMA = DATABASE()
+    AND TABLE_NAME = 'OrderItems'
+    AND CONSTRAINT_NAME = 'fk_OrderItems_order_items'
+);
+
+PREPARE drop_order_items_self_reference FROM @drop_order_items_self_reference;
+EXECUTE drop_order_items_self_reference;
+DEALLOCATE PREPARE drop_order_items_self_reference;
@@ -0,0 +1,255 @@
+package migrations
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+func newTestMigrator(t *testing.T) (*Migrator, *gorm.DB) {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() {
+		sqlDB.Close()
+	})
+
+	migrator, err := NewMigrator(db, fstest.MapFS{
+		"000001_create_products.up.sql": {Data: []byte(`-- products
+CREATE TABLE Products (
+  Id char(36) PRIMARY KEY,
+  Name varchar(255) NOT NULL
+);
+
+INSERT INTO Products (Id, Name) VALUES ('1', 'Pizza');
+`)},
+		"000001_create_products.down.sql": {Data: []byte("DROP TABLE Products;\n")},
+		"000002_add_price.up.sql":         {Data: []byte("ALTER TABLE Products ADD COLUMN PriceInCents int NOT NULL DEFAULT 0;\n")},
+		"000002_add_price.down.sql":       {Data: []byte("ALTER TABLE Products DROP COLUMN PriceInCents;\n")},
+		"README.md":                       {Data: []byte("not a migration")},
+	})
+	require.NoError(t, err)
+
+	return migrator, db
+}
+
+func TestMigrator_Up(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should apply the pending migrations in order and record them", func(t *testing.T) {
+		migrator, db := newTestMigrator(t)
+
+		migrated, err := migrator.Up(ctx)
+
+		require.NoError(t, err)
+		require.Len(t, migrated, 2)
+		assert.Equal(t, int64(1), migrated[0].Version)
+		assert.Equal(t, "add_price", migrated[1].Name)
+		assert.True(t, db.Migrator().HasColumn("Products", "PriceInCents"))
+
+		var count int64
+		require.NoError(t, db.Table("Products").Count(&count).Error)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("should not apply a migration twice", func(t *testing.T) {
+		migrator, _ := newTestMigrator(t)
+
+		_, err := migrator.Up(ctx)
+		require.NoError(t, err)
+
+		migrated, err := migrator.Up(ctx)
+
+		assert.NoError(t, err)
+		assert.Empty(t, migrated)
+	})
+}
+
+func TestMigrator_Down(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should roll back the last migrations", func(t *testing.T) {
+		migrator, db := newTestMigrator(t)
+
+		_, err := migrator.Up(ctx)
+		require.NoError(t, err)
+
+		rolledBack, err := migrator.Down(ctx, 1)
+
+		require.NoError(t, err)
+		require.Len(t, rolledBack, 1)
+		assert.Equal(t, int64(2), rolledBack[0].Version)
+		assert.False(t, db.Migrator().HasColumn("Products", "PriceInCents"))
+
+		status, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		assert.NotNil(t, status[0].AppliedAt)
+		assert.Nil(t, status[1].AppliedAt)
+	})
+
+	t.Run("should return error when there is no migration to roll back", func(t *testing.T) {
+		migrator, _ := newTestMigrator(t)
+
+		_, err := migrator.Down(ctx, 1)
+
+		assert.ErrorIs(t, err, ErrNoMigrationToRollback)
+	})
+}
+
+func TestMigrator_Status(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should list applied versions that have no files", func(t *testing.T) {
+		migrator, db := newTestMigrator(t)
+
+		_, err := migrator.Up(ctx)
+		require.NoError(t, err)
+		require.NoError(t, db.Create(&schemaMigration{Version: 3, Name: "removed"}).Error)
+
+		status, err := migrator.Status(ctx)
+
+		require.NoError(t, err)
+		require.Len(t, status, 3)
+		assert.Equal(t, "removed", status[2].Name)
+		assert.NotNil(t, status[2].AppliedAt)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	t.Run("should create the files with the next version", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "000007_baseline.up.sql"), nil, 0o644))
+
+		paths, err := Create(dir, "Add Orders Index")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			filepath.Join(dir, "000008_add_orders_index.up.sql"),
+			filepath.Join(dir, "000008_add_orders_index.down.sql"),
+		}, paths)
+	})
+
+	t.Run("should return error when the name is empty", func(t *testing.T) {
+		_, err := Create(t.TempDir(), "  ")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestFiles(t *testing.T) {
+	t.Run("should have up and down files for every embedded migration", func(t *testing.T) {
+		migrations, err := loadMigrations(Files)
+
+		require.NoError(t, err)
+		require.NotEmpty(t, migrations)
+		assert.Equal(t, "baseline", migrations[0].Name)
+		for _, migration := range migrations {
+			assert.NotEmpty(t, migration.Up, migration.Name)
+			assert.NotEmpty(t, migration.Down, migration.Name)
+		}
+	})
+}
+
+// newTestRolePermissionsDatabase creates the role permission tables on SQLite,
+// which has no enum type, so the enum columns are created as text.
+func newTestRolePermissionsDatabase(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	_, db := newTestMigrator(t)
+
+	rolePermissionModels := []any{&models.RolePermission{}, &models.SeededRolePermission{}}
+	for _, model := range rolePermissionModels {
+		statement := &gorm.Statement{DB: db}
+		require.NoError(t, statement.Parse(model))
+
+		for _, field := range statement.Schema.Fields {
+			if strings.HasPrefix(strings.ToLower(string(field.DataType)), "enum") {
+				field.DataType = schema.String
+			}
+		}
+	}
+
+	require.NoError(t, db.AutoMigrate(rolePermissionModels...))
+
+	return db
+}
+
+func countGrants(t *testing.T, db *gorm.DB, scope models.RoleScope, role string, permission models.Permission) int64 {
+	t.Helper()
+
+	var count int64
+	require.NoError(t, db.Model(&models.RolePermission{}).
+		Where("Scope = ? AND Role = ? AND Permission = ?", scope, role, permission).
+		Count(&count).Error)
+
+	return count
+}
+
+func TestSyncRolePermissions(t *testing.T) {
+	ctx := context.Background()
+	manager := string(models.Manager)
+
+	t.Run("should seed the default grants", func(t *testing.T) {
+		db := newTestRolePermissionsDatabase(t)
+
+		require.NoError(t, SyncRolePermissions(ctx, db))
+
+		assert.Equal(t, int64(1), countGrants(t, db, models.PlatformScope, manager, models.ManageAPIKeysPermission))
+	})
+
+	t.Run("should not grant again a default that was revoked", func(t *testing.T) {
+		db := newTestRolePermissionsDatabase(t)
+		require.NoError(t, SyncRolePermissions(ctx, db))
+
+		require.NoError(t, db.Unscoped().Where("Permission = ?", models.ManageAPIKeysPermission).Delete(&models.RolePermission{}).Error)
+
+		require.NoError(t, SyncRolePermissions(ctx, db))
+
+		assert.Zero(t, countGrants(t, db, models.PlatformScope, manager, models.ManageAPIKeysPermission))
+	})
+
+	t.Run("should grant the defaults that were never seeded", func(t *testing.T) {
+		db := newTestRolePermissionsDatabase(t)
+		require.NoError(t, SyncRolePermissions(ctx, db))
+
+		// As if the grant had been added to the defaults after the first seed.
+		require.NoError(t, db.Unscoped().Where("Permission = ?", models.ManageWebhooksPermission).Delete(&models.RolePermission{}).Error)
+		require.NoError(t, db.Unscoped().Where("Permission = ?", models.ManageWebhooksPermission).Delete(&models.SeededRolePermission{}).Error)
+
+		require.NoError(t, SyncRolePermissions(ctx, db))
+
+		assert.Equal(t, int64(1), countGrants(t, db, models.PlatformScope, manager, models.ManageWebhooksPermission))
+	})
+
+	t.Run("should keep the grants of databases seeded before the seeds were recorded", func(t *testing.T) {
+		db := newTestRolePermissionsDatabase(t)
+		require.NoError(t, db.Create(models.NewRolePermission(models.PlatformScope, manager, models.ListOrdersPermission)).Error)
+
+		require.NoError(t, SyncRolePermissions(ctx, db))
+
+		assert.Zero(t, countGrants(t, db, models.PlatformScope, manager, models.ManageAPIKeysPermission))
+		assert.Equal(t, int64(1), countGrants(t, db, models.PlatformScope, string(models.Admin), models.ManageRolesPermission))
+
+		var seeded int64
+		require.NoError(t, db.Model(&models.SeededRolePermission{}).Count(&seeded).Error)
+		assert.NotZero(t, seeded)
+	})
+}
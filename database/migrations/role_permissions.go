@@ -0,0 +1,113 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/G-Villarinho/food-shop-api/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SyncRolePermissions seeds the default grants of models.DefaultRolePermissions.
+// It follows the permissions declared in code rather than a migration history,
+// so it runs after every migration and is safe to run more than once.
+//
+// Each default grant is seeded once and recorded in SeededRolePermissions, so
+// grants added to the defaults reach existing databases while the ones an admin
+// revoked stay revoked.
+func SyncRolePermissions(ctx context.Context, db *gorm.DB) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var seeded []models.SeededRolePermission
+		if err := tx.Find(&seeded).Error; err != nil {
+			return fmt.Errorf("get seeded role permissions: %w", err)
+		}
+
+		isSeeded := make(map[grantKey]bool, len(seeded))
+		for _, grant := range seeded {
+			isSeeded[grantKey{grant.Scope, grant.Role, grant.Permission}] = true
+		}
+
+		defaults := defaultRolePermissions()
+
+		// Databases seeded before the seeds were recorded already hold the
+		// defaults of that time, minus what an admin revoked since.
+		if len(seeded) == 0 {
+			var rolePermissionsCount int64
+			if err := tx.Model(&models.RolePermission{}).Count(&rolePermissionsCount).Error; err != nil {
+				return fmt.Errorf("count role permissions: %w", err)
+			}
+
+			if rolePermissionsCount > 0 {
+				if err := recordSeededRolePermissions(tx, defaults); err != nil {
+					return err
+				}
+
+				for _, grant := range defaults {
+					isSeeded[grantKey{grant.Scope, grant.Role, grant.Permission}] = true
+				}
+			}
+		}
+
+		var pending []models.RolePermission
+		for _, grant := range defaults {
+			if !isSeeded[grantKey{grant.Scope, grant.Role, grant.Permission}] {
+				pending = append(pending, grant)
+			}
+		}
+
+		if len(pending) > 0 {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&pending).Error; err != nil {
+				return fmt.Errorf("seed role permissions: %w", err)
+			}
+
+			if err := recordSeededRolePermissions(tx, pending); err != nil {
+				return err
+			}
+		}
+
+		// The admin role always keeps its default grants so the back-office can't be locked out.
+		var adminPermissions []models.RolePermission
+		for _, permission := range models.DefaultRolePermissions[models.PlatformScope][string(models.Admin)] {
+			adminPermissions = append(adminPermissions, *models.NewRolePermission(models.PlatformScope, string(models.Admin), permission))
+		}
+
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&adminPermissions).Error; err != nil {
+			return fmt.Errorf("seed admin permissions: %w", err)
+		}
+
+		return nil
+	})
+}
+
+type grantKey struct {
+	scope      models.RoleScope
+	role       string
+	permission models.Permission
+}
+
+func defaultRolePermissions() []models.RolePermission {
+	var rolePermissions []models.RolePermission
+	for scope, roles := range models.DefaultRolePermissions {
+		for role, permissions := range roles {
+			for _, permission := range permissions {
+				rolePermissions = append(rolePermissions, *models.NewRolePermission(scope, role, permission))
+			}
+		}
+	}
+
+	return rolePermissions
+}
+
+func recordSeededRolePermissions(tx *gorm.DB, rolePermissions []models.RolePermission) error {
+	seeded := make([]models.SeededRolePermission, 0, len(rolePermissions))
+	for _, grant := range rolePermissions {
+		seeded = append(seeded, *models.NewSeededRolePermission(grant.Scope, grant.Role, grant.Permission))
+	}
+
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&seeded).Error; err != nil {
+		return fmt.Errorf("record seeded role permissions: %w", err)
+	}
+
+	return nil
+}
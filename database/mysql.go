@@ -26,3 +26,12 @@ func NewMysqlConnection(ctx context.Context) (*gorm.DB, error) {
 
 	return db, nil
 }
+
+func CloseMysqlConnection(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.Close()
+}
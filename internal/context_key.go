@@ -7,4 +7,8 @@ const (
 	SessionIDKey    ContextKey = "session_id"
 	RestaurantIDKey ContextKey = "restaurant_id"
 	RoleKey         ContextKey = "role"
+	MemberRoleKey   ContextKey = "member_role"
+	UserAgentKey    ContextKey = "user_agent"
+	ClientIPKey     ContextKey = "client_ip"
+	APIKeyScopesKey ContextKey = "api_key_scopes"
 )
@@ -0,0 +1,28 @@
+package internal
+
+import "sync"
+
+// Shutdown tells long-lived requests, such as the order tracking stream, that
+// the server is shutting down, so they can end without waiting for the
+// shutdown timeout. Other requests keep their context and finish normally.
+type Shutdown struct {
+	once sync.Once
+	done chan struct{}
+}
+
+func NewShutdown(d *Di) (*Shutdown, error) {
+	return &Shutdown{
+		done: make(chan struct{}),
+	}, nil
+}
+
+// Done is closed once the shutdown starts.
+func (s *Shutdown) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *Shutdown) Close() {
+	s.once.Do(func() {
+		close(s.done)
+	})
+}
@@ -1,24 +1,73 @@
 package middleware
 
 import (
+	"log/slog"
+	"slices"
+
 	"github.com/G-Villarinho/food-shop-api/cmd/api/responses"
 	"github.com/G-Villarinho/food-shop-api/internal"
 	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/G-Villarinho/food-shop-api/services"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
-func EnsurePermission(requiredPermission models.Permission) echo.MiddlewareFunc {
+func EnsurePermission(di *internal.Di, requiredPermission models.Permission) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(ctx echo.Context) error {
+			permissionService, err := internal.Invoke[services.PermissionService](di)
+			if err != nil {
+				slog.Error(err.Error())
+				return responses.InternalServerAPIErrorResponse(ctx)
+			}
+
+			// An API key is limited to its scopes and still needs the grants of
+			// the member role of its creator.
+			if scopes, ok := ctx.Request().Context().Value(internal.APIKeyScopesKey).([]models.Permission); ok {
+				if !slices.Contains(scopes, requiredPermission) {
+					return responses.ForbiddenPermissionAPIErrorResponse(ctx)
+				}
+			}
+
 			role, ok := ctx.Request().Context().Value(internal.RoleKey).(models.Role)
 			if !ok || role == "" {
 				return responses.AccessDeniedAPIErrorResponse(ctx)
 			}
 
-			if hasPermission := models.CheckPermission(role, requiredPermission); !hasPermission {
+			hasPermission, err := permissionService.HasPermission(ctx.Request().Context(), models.PlatformScope, string(role), requiredPermission)
+			if err != nil {
+				slog.Error(err.Error())
+				return responses.InternalServerAPIErrorResponse(ctx)
+			}
+
+			if !hasPermission {
 				return responses.ForbiddenPermissionAPIErrorResponse(ctx)
 			}
 
+			// A manager only gets the grants of their member role, so a session
+			// without one has no restaurant to act on.
+			memberRole, ok := ctx.Request().Context().Value(internal.MemberRoleKey).(models.MemberRole)
+			if role == models.Manager && !ok {
+				return responses.ForbiddenPermissionAPIErrorResponse(ctx)
+			}
+
+			if ok {
+				restaurantID, ok := ctx.Request().Context().Value(internal.RestaurantIDKey).(*uuid.UUID)
+				if !ok || restaurantID == nil {
+					return responses.ForbiddenPermissionAPIErrorResponse(ctx)
+				}
+
+				hasPermission, err := permissionService.HasMemberPermission(ctx.Request().Context(), *restaurantID, memberRole, requiredPermission)
+				if err != nil {
+					slog.Error(err.Error())
+					return responses.InternalServerAPIErrorResponse(ctx)
+				}
+
+				if !hasPermission {
+					return responses.ForbiddenPermissionAPIErrorResponse(ctx)
+				}
+			}
+
 			return next(ctx)
 		}
 	}
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/cache"
+	"github.com/G-Villarinho/food-shop-api/cmd/api/responses"
+	"github.com/G-Villarinho/food-shop-api/internal"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+)
+
+// rateLimitMaxBodySize caps the body RateLimitByEmail reads, which is far more
+// than any sign-in payload needs.
+const rateLimitMaxBodySize = 16 << 10
+
+type RateLimitKeyFunc func(ctx echo.Context) (string, error)
+
+type RateLimitRule struct {
+	Name   string
+	Limit  int
+	Window time.Duration
+	Key    RateLimitKeyFunc
+}
+
+// RateLimit rejects the request with 429 as soon as any of the rules exceeds
+// its limit. Rules with a non-positive limit are disabled. If Redis is not
+// available the request is let through, so the limiter never takes the API down.
+func RateLimit(di *internal.Di, rules ...RateLimitRule) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			rateLimiter, err := internal.Invoke[cache.RateLimiter](di)
+			if err != nil {
+				slog.Error(err.Error())
+				return responses.InternalServerAPIErrorResponse(ctx)
+			}
+
+			for _, rule := range rules {
+				if rule.Limit <= 0 || rule.Window <= 0 {
+					continue
+				}
+
+				key, err := rule.Key(ctx)
+				if maxBytesErr := (*http.MaxBytesError)(nil); errors.As(err, &maxBytesErr) {
+					return responses.NewCustomValidationAPIErrorResponse(ctx, http.StatusRequestEntityTooLarge, "payload_too_large", "O corpo da requisição é grande demais.")
+				}
+
+				if err != nil {
+					slog.Warn("Error to build rate limit key", slog.String("rule", rule.Name), slog.String("error", err.Error()))
+					continue
+				}
+
+				if key == "" {
+					continue
+				}
+
+				allowed, retryAfter, err := rateLimiter.Allow(ctx.Request().Context(), fmt.Sprintf("rate-limit:%s:%s", rule.Name, key), rule.Limit, rule.Window)
+				if err != nil {
+					slog.Error(err.Error(), slog.String("rule", rule.Name))
+					continue
+				}
+
+				if !allowed {
+					ctx.Response().Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+					return responses.TooManyRequestsAPIErrorResponse(ctx)
+				}
+			}
+
+			return next(ctx)
+		}
+	}
+}
+
+func RateLimitByIP(ctx echo.Context) (string, error) {
+	return ctx.RealIP(), nil
+}
+
+// RateLimitByEmail reads the "email" field of the JSON body and puts the body
+// back, so the handler can still decode it. Bodies larger than
+// rateLimitMaxBodySize are refused.
+func RateLimitByEmail(ctx echo.Context) (string, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(ctx.Response(), ctx.Request().Body, rateLimitMaxBodySize))
+	if err != nil {
+		return "", err
+	}
+	ctx.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+	var payload struct {
+		Email string `json:"email"`
+	}
+	if err := jsoniter.Unmarshal(body, &payload); err != nil {
+		return "", err
+	}
+
+	return strings.ToLower(strings.TrimSpace(payload.Email)), nil
+}
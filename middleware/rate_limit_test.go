@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/cache"
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRateLimit(t *testing.T) {
+	newRequest := func(body string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/auth/code", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.RemoteAddr = "203.0.113.10:4321"
+		rec := httptest.NewRecorder()
+
+		return echo.New().NewContext(req, rec), rec
+	}
+
+	ok := func(ctx echo.Context) error {
+		return ctx.NoContent(http.StatusOK)
+	}
+
+	t.Run("should reject with Retry-After once the limit is exceeded", func(t *testing.T) {
+		di := internal.NewDi()
+		rateLimiter := &mocks.RateLimiter{}
+		internal.Provide(di, func(d *internal.Di) (cache.RateLimiter, error) {
+			return rateLimiter, nil
+		})
+
+		rateLimiter.On("Allow", mock.Anything, "rate-limit:sign-in:ip:203.0.113.10", 5, time.Minute).Return(false, 1500*time.Millisecond, nil)
+
+		handler := RateLimit(di, RateLimitRule{Name: "sign-in:ip", Limit: 5, Window: time.Minute, Key: RateLimitByIP})(ok)
+
+		ctx, rec := newRequest(`{"email":"user@example.com"}`)
+		err := handler(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+	})
+
+	t.Run("should skip disabled rules", func(t *testing.T) {
+		di := internal.NewDi()
+		rateLimiter := &mocks.RateLimiter{}
+		internal.Provide(di, func(d *internal.Di) (cache.RateLimiter, error) {
+			return rateLimiter, nil
+		})
+
+		handler := RateLimit(di,
+			RateLimitRule{Name: "sign-in:ip", Limit: 0, Window: time.Minute, Key: RateLimitByIP},
+			RateLimitRule{Name: "sign-in:email", Limit: 5, Window: 0, Key: RateLimitByEmail},
+		)(ok)
+
+		ctx, rec := newRequest(`{"email":"user@example.com"}`)
+		err := handler(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		rateLimiter.AssertNotCalled(t, "Allow", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should limit by e-mail and give the body back to the handler", func(t *testing.T) {
+		di := internal.NewDi()
+		internal.Provide(di, cache.NewMemoryRateLimiter)
+
+		body := `{"email":" User@Example.com ","code":"123456"}`
+
+		var received []string
+		handler := RateLimit(di, RateLimitRule{Name: "sign-in-code:email", Limit: 1, Window: time.Minute, Key: RateLimitByEmail})(func(ctx echo.Context) error {
+			data, err := io.ReadAll(ctx.Request().Body)
+			if err != nil {
+				return err
+			}
+			received = append(received, string(data))
+
+			return ctx.NoContent(http.StatusOK)
+		})
+
+		ctx, rec := newRequest(body)
+		assert.NoError(t, handler(ctx))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, []string{body}, received)
+
+		ctx, rec = newRequest(`{"email":"user@example.com"}`)
+		assert.NoError(t, handler(ctx))
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Len(t, received, 1)
+
+		ctx, rec = newRequest(`{"email":"other@example.com"}`)
+		assert.NoError(t, handler(ctx))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("should refuse a body too large to read the e-mail from", func(t *testing.T) {
+		di := internal.NewDi()
+		rateLimiter := &mocks.RateLimiter{}
+		internal.Provide(di, func(d *internal.Di) (cache.RateLimiter, error) {
+			return rateLimiter, nil
+		})
+
+		handler := RateLimit(di, RateLimitRule{Name: "sign-in:email", Limit: 5, Window: time.Minute, Key: RateLimitByEmail})(ok)
+
+		ctx, rec := newRequest(`{"email":"user@example.com","padding":"` + strings.Repeat("a", rateLimitMaxBodySize) + `"}`)
+		err := handler(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		rateLimiter.AssertNotCalled(t, "Allow", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should let the request through when the limiter fails", func(t *testing.T) {
+		di := internal.NewDi()
+		rateLimiter := &mocks.RateLimiter{}
+		internal.Provide(di, func(d *internal.Di) (cache.RateLimiter, error) {
+			return rateLimiter, nil
+		})
+
+		rateLimiter.On("Allow", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(false, time.Duration(0), assert.AnError)
+
+		handler := RateLimit(di, RateLimitRule{Name: "sign-in:ip", Limit: 5, Window: time.Minute, Key: RateLimitByIP})(ok)
+
+		ctx, rec := newRequest(`{}`)
+		err := handler(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
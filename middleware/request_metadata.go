@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/labstack/echo/v4"
+)
+
+func RequestMetadata() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			ctx.SetRequest(ctx.Request().WithContext(context.WithValue(ctx.Request().Context(), internal.UserAgentKey, ctx.Request().UserAgent())))
+			ctx.SetRequest(ctx.Request().WithContext(context.WithValue(ctx.Request().Context(), internal.ClientIPKey, ctx.RealIP())))
+
+			return next(ctx)
+		}
+	}
+}
@@ -13,6 +13,42 @@ type AuthHandler struct {
 	mock.Mock
 }
 
+// GetJWKS provides a mock function with given fields: ctx
+func (_m *AuthHandler) GetJWKS(ctx echo.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJWKS")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefreshToken provides a mock function with given fields: ctx
+func (_m *AuthHandler) RefreshToken(ctx echo.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SignIn provides a mock function with given fields: ctx
 func (_m *AuthHandler) SignIn(ctx echo.Context) error {
 	ret := _m.Called(ctx)
@@ -49,6 +85,24 @@ func (_m *AuthHandler) SignOut(ctx echo.Context) error {
 	return r0
 }
 
+// VerifySignInCode provides a mock function with given fields: ctx
+func (_m *AuthHandler) VerifySignInCode(ctx echo.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifySignInCode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // VeryfyMagicLink provides a mock function with given fields: ctx
 func (_m *AuthHandler) VeryfyMagicLink(ctx echo.Context) error {
 	ret := _m.Called(ctx)
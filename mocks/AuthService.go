@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 
+	models "github.com/G-Villarinho/food-shop-api/models"
 	mock "github.com/stretchr/testify/mock"
 
 	uuid "github.com/google/uuid"
@@ -15,6 +16,36 @@ type AuthService struct {
 	mock.Mock
 }
 
+// RefreshToken provides a mock function with given fields: ctx, refreshToken
+func (_m *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*models.AuthTokenResponse, error) {
+	ret := _m.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshToken")
+	}
+
+	var r0 *models.AuthTokenResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.AuthTokenResponse, error)); ok {
+		return rf(ctx, refreshToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.AuthTokenResponse); ok {
+		r0 = rf(ctx, refreshToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthTokenResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SignIn provides a mock function with given fields: ctx, email
 func (_m *AuthService) SignIn(ctx context.Context, email string) error {
 	ret := _m.Called(ctx, email)
@@ -33,6 +64,24 @@ func (_m *AuthService) SignIn(ctx context.Context, email string) error {
 	return r0
 }
 
+// SignInWithCode provides a mock function with given fields: ctx, email
+func (_m *AuthService) SignInWithCode(ctx context.Context, email string) error {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignInWithCode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SignOut provides a mock function with given fields: ctx
 func (_m *AuthService) SignOut(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -51,23 +100,55 @@ func (_m *AuthService) SignOut(ctx context.Context) error {
 	return r0
 }
 
+// VerifySignInCode provides a mock function with given fields: ctx, payload
+func (_m *AuthService) VerifySignInCode(ctx context.Context, payload models.VerifySignInCodePayload) (*models.AuthTokenResponse, error) {
+	ret := _m.Called(ctx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifySignInCode")
+	}
+
+	var r0 *models.AuthTokenResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.VerifySignInCodePayload) (*models.AuthTokenResponse, error)); ok {
+		return rf(ctx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.VerifySignInCodePayload) *models.AuthTokenResponse); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthTokenResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.VerifySignInCodePayload) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // VeryfyMagicLink provides a mock function with given fields: ctx, code
-func (_m *AuthService) VeryfyMagicLink(ctx context.Context, code uuid.UUID) (string, error) {
+func (_m *AuthService) VeryfyMagicLink(ctx context.Context, code uuid.UUID) (*models.AuthTokenResponse, error) {
 	ret := _m.Called(ctx, code)
 
 	if len(ret) == 0 {
 		panic("no return value specified for VeryfyMagicLink")
 	}
 
-	var r0 string
+	var r0 *models.AuthTokenResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.AuthTokenResponse, error)); ok {
 		return rf(ctx, code)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) string); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.AuthTokenResponse); ok {
 		r0 = rf(ctx, code)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthTokenResponse)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
@@ -79,7 +79,7 @@ func (_m *CacheService) Exists(ctx context.Context, key string) (bool, error) {
 }
 
 // Get provides a mock function with given fields: ctx, key, target
-func (_m *CacheService) Get(ctx context.Context, key string, target any) error {
+func (_m *CacheService) Get(ctx context.Context, key string, target interface{}) error {
 	ret := _m.Called(ctx, key, target)
 
 	if len(ret) == 0 {
@@ -87,7 +87,25 @@ func (_m *CacheService) Get(ctx context.Context, key string, target any) error {
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, any) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) error); ok {
+		r0 = rf(ctx, key, target)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAndDelete provides a mock function with given fields: ctx, key, target
+func (_m *CacheService) GetAndDelete(ctx context.Context, key string, target interface{}) error {
+	ret := _m.Called(ctx, key, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAndDelete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) error); ok {
 		r0 = rf(ctx, key, target)
 	} else {
 		r0 = ret.Error(0)
@@ -97,7 +115,7 @@ func (_m *CacheService) Get(ctx context.Context, key string, target any) error {
 }
 
 // GetSetMembers provides a mock function with given fields: ctx, key, target
-func (_m *CacheService) GetSetMembers(ctx context.Context, key string, target any) error {
+func (_m *CacheService) GetSetMembers(ctx context.Context, key string, target interface{}) error {
 	ret := _m.Called(ctx, key, target)
 
 	if len(ret) == 0 {
@@ -105,7 +123,7 @@ func (_m *CacheService) GetSetMembers(ctx context.Context, key string, target an
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, any) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) error); ok {
 		r0 = rf(ctx, key, target)
 	} else {
 		r0 = ret.Error(0)
@@ -114,6 +132,34 @@ func (_m *CacheService) GetSetMembers(ctx context.Context, key string, target an
 	return r0
 }
 
+// Increment provides a mock function with given fields: ctx, key, ttl
+func (_m *CacheService) Increment(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	ret := _m.Called(ctx, key, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Increment")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (int64, error)); ok {
+		return rf(ctx, key, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) int64); ok {
+		r0 = rf(ctx, key, ttl)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, key, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RemoveFromSet provides a mock function with given fields: ctx, key, value
 func (_m *CacheService) RemoveFromSet(ctx context.Context, key string, value string) error {
 	ret := _m.Called(ctx, key, value)
@@ -133,7 +179,7 @@ func (_m *CacheService) RemoveFromSet(ctx context.Context, key string, value str
 }
 
 // Set provides a mock function with given fields: ctx, key, value, ttl
-func (_m *CacheService) Set(ctx context.Context, key string, value any, ttl time.Duration) error {
+func (_m *CacheService) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
 	ret := _m.Called(ctx, key, value, ttl)
 
 	if len(ret) == 0 {
@@ -141,7 +187,7 @@ func (_m *CacheService) Set(ctx context.Context, key string, value any, ttl time
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, any, time.Duration) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) error); ok {
 		r0 = rf(ctx, key, value, ttl)
 	} else {
 		r0 = ret.Error(0)
@@ -16,17 +16,35 @@ type EvaluationRepository struct {
 	mock.Mock
 }
 
-// CreateEvaluation provides a mock function with given fields: ctx, evaluation
-func (_m *EvaluationRepository) CreateEvaluation(ctx context.Context, evaluation models.Evaluation) error {
-	ret := _m.Called(ctx, evaluation)
+// CreateEvaluation provides a mock function with given fields: ctx, evaluation, outbox
+func (_m *EvaluationRepository) CreateEvaluation(ctx context.Context, evaluation models.Evaluation, outbox []models.OutboxMessage) error {
+	ret := _m.Called(ctx, evaluation, outbox)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateEvaluation")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.Evaluation) error); ok {
-		r0 = rf(ctx, evaluation)
+	if rf, ok := ret.Get(0).(func(context.Context, models.Evaluation, []models.OutboxMessage) error); ok {
+		r0 = rf(ctx, evaluation, outbox)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteEvaluation provides a mock function with given fields: ctx, evaluationID
+func (_m *EvaluationRepository) DeleteEvaluation(ctx context.Context, evaluationID uuid.UUID) error {
+	ret := _m.Called(ctx, evaluationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEvaluation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, evaluationID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -85,6 +85,24 @@ func (_m *OrderHandler) DispatchOrder(ctx echo.Context) error {
 	return r0
 }
 
+// GetOrderTracking provides a mock function with given fields: ctx
+func (_m *OrderHandler) GetOrderTracking(ctx echo.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrderTracking")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetOrders provides a mock function with given fields: ctx
 func (_m *OrderHandler) GetOrders(ctx echo.Context) error {
 	ret := _m.Called(ctx)
@@ -103,6 +121,24 @@ func (_m *OrderHandler) GetOrders(ctx echo.Context) error {
 	return r0
 }
 
+// StreamOrderTracking provides a mock function with given fields: ctx
+func (_m *OrderHandler) StreamOrderTracking(ctx echo.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamOrderTracking")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewOrderHandler creates a new instance of OrderHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewOrderHandler(t interface {
@@ -8,6 +8,8 @@ import (
 	models "github.com/G-Villarinho/food-shop-api/models"
 	mock "github.com/stretchr/testify/mock"
 
+	time "time"
+
 	uuid "github.com/google/uuid"
 )
 
@@ -16,17 +18,35 @@ type OrderRepository struct {
 	mock.Mock
 }
 
-// CreateOrderWithItems provides a mock function with given fields: ctx, order, items
-func (_m *OrderRepository) CreateOrderWithItems(ctx context.Context, order *models.Order, items []models.OrderItem) error {
-	ret := _m.Called(ctx, order, items)
+// AssignCourier provides a mock function with given fields: ctx, orderID, courierID, outbox
+func (_m *OrderRepository) AssignCourier(ctx context.Context, orderID uuid.UUID, courierID uuid.UUID, outbox []models.OutboxMessage) error {
+	ret := _m.Called(ctx, orderID, courierID, outbox)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignCourier")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, []models.OutboxMessage) error); ok {
+		r0 = rf(ctx, orderID, courierID, outbox)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateOrderWithItems provides a mock function with given fields: ctx, order, items, outbox
+func (_m *OrderRepository) CreateOrderWithItems(ctx context.Context, order *models.Order, items []models.OrderItem, outbox []models.OutboxMessage) error {
+	ret := _m.Called(ctx, order, items, outbox)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateOrderWithItems")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.Order, []models.OrderItem) error); ok {
-		r0 = rf(ctx, order, items)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Order, []models.OrderItem, []models.OutboxMessage) error); ok {
+		r0 = rf(ctx, order, items, outbox)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -34,6 +54,36 @@ func (_m *OrderRepository) CreateOrderWithItems(ctx context.Context, order *mode
 	return r0
 }
 
+// GetDueScheduledOrders provides a mock function with given fields: ctx, until
+func (_m *OrderRepository) GetDueScheduledOrders(ctx context.Context, until time.Time) ([]models.Order, error) {
+	ret := _m.Called(ctx, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDueScheduledOrders")
+	}
+
+	var r0 []models.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]models.Order, error)); ok {
+		return rf(ctx, until)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []models.Order); ok {
+		r0 = rf(ctx, until)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetOrderByID provides a mock function with given fields: ctx, orderID, preload
 func (_m *OrderRepository) GetOrderByID(ctx context.Context, orderID uuid.UUID, preload bool) (*models.Order, error) {
 	ret := _m.Called(ctx, orderID, preload)
@@ -94,6 +144,36 @@ func (_m *OrderRepository) GetOrderPerMonth(ctx context.Context, restaurantID uu
 	return r0, r1
 }
 
+// GetPaginatedOrdersByCourierID provides a mock function with given fields: ctx, courierID, pagination
+func (_m *OrderRepository) GetPaginatedOrdersByCourierID(ctx context.Context, courierID uuid.UUID, pagination *models.DeliveryPagination) (*models.PaginatedResponse[models.Order], error) {
+	ret := _m.Called(ctx, courierID, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaginatedOrdersByCourierID")
+	}
+
+	var r0 *models.PaginatedResponse[models.Order]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *models.DeliveryPagination) (*models.PaginatedResponse[models.Order], error)); ok {
+		return rf(ctx, courierID, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *models.DeliveryPagination) *models.PaginatedResponse[models.Order]); ok {
+		r0 = rf(ctx, courierID, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PaginatedResponse[models.Order])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *models.DeliveryPagination) error); ok {
+		r1 = rf(ctx, courierID, pagination)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPaginatedOrdersByRestaurantID provides a mock function with given fields: ctx, restaurantID, pagination
 func (_m *OrderRepository) GetPaginatedOrdersByRestaurantID(ctx context.Context, restaurantID uuid.UUID, pagination *models.OrderPagination) (*models.PaginatedResponse[models.Order], error) {
 	ret := _m.Called(ctx, restaurantID, pagination)
@@ -124,17 +204,75 @@ func (_m *OrderRepository) GetPaginatedOrdersByRestaurantID(ctx context.Context,
 	return r0, r1
 }
 
-// UpdateStatus provides a mock function with given fields: ctx, orderID, status
-func (_m *OrderRepository) UpdateStatus(ctx context.Context, orderID uuid.UUID, status models.OrderStatus) error {
-	ret := _m.Called(ctx, orderID, status)
+// GetTipsPerMonth provides a mock function with given fields: ctx, restaurantID
+func (_m *OrderRepository) GetTipsPerMonth(ctx context.Context, restaurantID uuid.UUID) ([]models.TipsPerMonth, error) {
+	ret := _m.Called(ctx, restaurantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTipsPerMonth")
+	}
+
+	var r0 []models.TipsPerMonth
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]models.TipsPerMonth, error)); ok {
+		return rf(ctx, restaurantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []models.TipsPerMonth); ok {
+		r0 = rf(ctx, restaurantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.TipsPerMonth)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, restaurantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseScheduledOrder provides a mock function with given fields: ctx, orderID, outbox
+func (_m *OrderRepository) ReleaseScheduledOrder(ctx context.Context, orderID uuid.UUID, outbox []models.OutboxMessage) (bool, error) {
+	ret := _m.Called(ctx, orderID, outbox)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseScheduledOrder")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []models.OutboxMessage) (bool, error)); ok {
+		return rf(ctx, orderID, outbox)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []models.OutboxMessage) bool); ok {
+		r0 = rf(ctx, orderID, outbox)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, []models.OutboxMessage) error); ok {
+		r1 = rf(ctx, orderID, outbox)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, orderID, status, outbox
+func (_m *OrderRepository) UpdateStatus(ctx context.Context, orderID uuid.UUID, status models.OrderStatus, outbox []models.OutboxMessage) error {
+	ret := _m.Called(ctx, orderID, status, outbox)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateStatus")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, models.OrderStatus) error); ok {
-		r0 = rf(ctx, orderID, status)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, models.OrderStatus, []models.OutboxMessage) error); ok {
+		r0 = rf(ctx, orderID, status, outbox)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -52,6 +52,24 @@ func (_m *OrderService) CancelOrder(ctx context.Context, orderID uuid.UUID) erro
 	return r0
 }
 
+// CompleteDelivery provides a mock function with given fields: ctx, orderID
+func (_m *OrderService) CompleteDelivery(ctx context.Context, orderID uuid.UUID) error {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateOrder provides a mock function with given fields: ctx, custommerID, restaurantID, payload
 func (_m *OrderService) CreateOrder(ctx context.Context, custommerID uuid.UUID, restaurantID uuid.UUID, payload models.CreateOrderPayload) error {
 	ret := _m.Called(ctx, custommerID, restaurantID, payload)
@@ -88,17 +106,17 @@ func (_m *OrderService) DeliverOrder(ctx context.Context, orderID uuid.UUID) err
 	return r0
 }
 
-// DispatchOrder provides a mock function with given fields: ctx, orderID
-func (_m *OrderService) DispatchOrder(ctx context.Context, orderID uuid.UUID) error {
-	ret := _m.Called(ctx, orderID)
+// DispatchOrder provides a mock function with given fields: ctx, orderID, courierID
+func (_m *OrderService) DispatchOrder(ctx context.Context, orderID uuid.UUID, courierID *uuid.UUID) error {
+	ret := _m.Called(ctx, orderID, courierID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DispatchOrder")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(ctx, orderID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *uuid.UUID) error); ok {
+		r0 = rf(ctx, orderID, courierID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -106,6 +124,36 @@ func (_m *OrderService) DispatchOrder(ctx context.Context, orderID uuid.UUID) er
 	return r0
 }
 
+// GetPaginatedDeliveriesByCourierID provides a mock function with given fields: ctx, pagination
+func (_m *OrderService) GetPaginatedDeliveriesByCourierID(ctx context.Context, pagination *models.DeliveryPagination) (*models.PaginatedResponse[*models.DeliveryResponse], error) {
+	ret := _m.Called(ctx, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaginatedDeliveriesByCourierID")
+	}
+
+	var r0 *models.PaginatedResponse[*models.DeliveryResponse]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeliveryPagination) (*models.PaginatedResponse[*models.DeliveryResponse], error)); ok {
+		return rf(ctx, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeliveryPagination) *models.PaginatedResponse[*models.DeliveryResponse]); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PaginatedResponse[*models.DeliveryResponse])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.DeliveryPagination) error); ok {
+		r1 = rf(ctx, pagination)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPaginatedOrdersByRestaurantID provides a mock function with given fields: ctx, pagination
 func (_m *OrderService) GetPaginatedOrdersByRestaurantID(ctx context.Context, pagination *models.OrderPagination) (*models.PaginatedResponse[*models.OrderResponse], error) {
 	ret := _m.Called(ctx, pagination)
@@ -136,6 +184,34 @@ func (_m *OrderService) GetPaginatedOrdersByRestaurantID(ctx context.Context, pa
 	return r0, r1
 }
 
+// ReleaseScheduledOrders provides a mock function with given fields: ctx
+func (_m *OrderService) ReleaseScheduledOrders(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseScheduledOrders")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewOrderService creates a new instance of OrderService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewOrderService(t interface {
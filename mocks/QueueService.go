@@ -2,7 +2,12 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	client "github.com/G-Villarinho/food-shop-api/client"
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/G-Villarinho/food-shop-api/models"
+)
 
 // QueueService is an autogenerated mock type for the QueueService type
 type QueueService struct {
@@ -10,23 +15,23 @@ type QueueService struct {
 }
 
 // Consume provides a mock function with given fields: queueName
-func (_m *QueueService) Consume(queueName string) (<-chan []byte, error) {
+func (_m *QueueService) Consume(queueName string) (<-chan *client.Message, error) {
 	ret := _m.Called(queueName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Consume")
 	}
 
-	var r0 <-chan []byte
+	var r0 <-chan *client.Message
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (<-chan []byte, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) (<-chan *client.Message, error)); ok {
 		return rf(queueName)
 	}
-	if rf, ok := ret.Get(0).(func(string) <-chan []byte); ok {
+	if rf, ok := ret.Get(0).(func(string) <-chan *client.Message); ok {
 		r0 = rf(queueName)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(<-chan []byte)
+			r0 = ret.Get(0).(<-chan *client.Message)
 		}
 	}
 
@@ -39,6 +44,54 @@ func (_m *QueueService) Consume(queueName string) (<-chan []byte, error) {
 	return r0, r1
 }
 
+// DeadLetter provides a mock function with given fields: queueName, message, cause
+func (_m *QueueService) DeadLetter(queueName string, message *client.Message, cause error) error {
+	ret := _m.Called(queueName, message, cause)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeadLetter")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *client.Message, error) error); ok {
+		r0 = rf(queueName, message, cause)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetDeadLetters provides a mock function with given fields: queueName, limit
+func (_m *QueueService) GetDeadLetters(queueName string, limit int) ([]models.DeadLetter, error) {
+	ret := _m.Called(queueName, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeadLetters")
+	}
+
+	var r0 []models.DeadLetter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int) ([]models.DeadLetter, error)); ok {
+		return rf(queueName, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int) []models.DeadLetter); ok {
+		r0 = rf(queueName, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeadLetter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(queueName, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Publish provides a mock function with given fields: queueName, message
 func (_m *QueueService) Publish(queueName string, message []byte) error {
 	ret := _m.Called(queueName, message)
@@ -57,6 +110,52 @@ func (_m *QueueService) Publish(queueName string, message []byte) error {
 	return r0
 }
 
+// ReplayDeadLetters provides a mock function with given fields: queueName, limit
+func (_m *QueueService) ReplayDeadLetters(queueName string, limit int) (int, error) {
+	ret := _m.Called(queueName, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplayDeadLetters")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int) (int, error)); ok {
+		return rf(queueName, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int) int); ok {
+		r0 = rf(queueName, limit)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(queueName, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Retry provides a mock function with given fields: queueName, message, cause
+func (_m *QueueService) Retry(queueName string, message *client.Message, cause error) error {
+	ret := _m.Called(queueName, message, cause)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Retry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *client.Message, error) error); ok {
+		r0 = rf(queueName, message, cause)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewQueueService creates a new instance of QueueService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewQueueService(t interface {
@@ -2,14 +2,17 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	client "github.com/G-Villarinho/food-shop-api/client"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // RabbitMQClient is an autogenerated mock type for the RabbitMQClient type
 type RabbitMQClient struct {
 	mock.Mock
 }
 
-// Connect provides a mock function with given fields:
+// Connect provides a mock function with no fields
 func (_m *RabbitMQClient) Connect() error {
 	ret := _m.Called()
 
@@ -28,23 +31,23 @@ func (_m *RabbitMQClient) Connect() error {
 }
 
 // Consume provides a mock function with given fields: queueName
-func (_m *RabbitMQClient) Consume(queueName string) (<-chan []byte, error) {
+func (_m *RabbitMQClient) Consume(queueName string) (<-chan *client.Message, error) {
 	ret := _m.Called(queueName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Consume")
 	}
 
-	var r0 <-chan []byte
+	var r0 <-chan *client.Message
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (<-chan []byte, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) (<-chan *client.Message, error)); ok {
 		return rf(queueName)
 	}
-	if rf, ok := ret.Get(0).(func(string) <-chan []byte); ok {
+	if rf, ok := ret.Get(0).(func(string) <-chan *client.Message); ok {
 		r0 = rf(queueName)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(<-chan []byte)
+			r0 = ret.Get(0).(<-chan *client.Message)
 		}
 	}
 
@@ -57,7 +60,25 @@ func (_m *RabbitMQClient) Consume(queueName string) (<-chan []byte, error) {
 	return r0, r1
 }
 
-// Disconnect provides a mock function with given fields:
+// DeclareQueue provides a mock function with given fields: queueName, options
+func (_m *RabbitMQClient) DeclareQueue(queueName string, options client.QueueOptions) error {
+	ret := _m.Called(queueName, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeclareQueue")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, client.QueueOptions) error); ok {
+		r0 = rf(queueName, options)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Disconnect provides a mock function with no fields
 func (_m *RabbitMQClient) Disconnect() error {
 	ret := _m.Called()
 
@@ -75,6 +96,54 @@ func (_m *RabbitMQClient) Disconnect() error {
 	return r0
 }
 
+// Get provides a mock function with given fields: queueName
+func (_m *RabbitMQClient) Get(queueName string) (*client.Message, error) {
+	ret := _m.Called(queueName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *client.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*client.Message, error)); ok {
+		return rf(queueName)
+	}
+	if rf, ok := ret.Get(0).(func(string) *client.Message); ok {
+		r0 = rf(queueName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(queueName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsConnected provides a mock function with no fields
+func (_m *RabbitMQClient) IsConnected() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsConnected")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // Publish provides a mock function with given fields: queueName, message
 func (_m *RabbitMQClient) Publish(queueName string, message []byte) error {
 	ret := _m.Called(queueName, message)
@@ -93,6 +162,24 @@ func (_m *RabbitMQClient) Publish(queueName string, message []byte) error {
 	return r0
 }
 
+// PublishWithHeaders provides a mock function with given fields: queueName, message, headers
+func (_m *RabbitMQClient) PublishWithHeaders(queueName string, message []byte, headers map[string]interface{}) error {
+	ret := _m.Called(queueName, message, headers)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishWithHeaders")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []byte, map[string]interface{}) error); ok {
+		r0 = rf(queueName, message, headers)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewRabbitMQClient creates a new instance of RabbitMQClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRabbitMQClient(t interface {
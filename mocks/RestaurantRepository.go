@@ -34,6 +34,36 @@ func (_m *RestaurantRepository) CreateRestaurant(ctx context.Context, restaurant
 	return r0
 }
 
+// GetPaginatedRestaurants provides a mock function with given fields: ctx, pagination
+func (_m *RestaurantRepository) GetPaginatedRestaurants(ctx context.Context, pagination *models.RestaurantPagination) (*models.PaginatedResponse[models.Restaurant], error) {
+	ret := _m.Called(ctx, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaginatedRestaurants")
+	}
+
+	var r0 *models.PaginatedResponse[models.Restaurant]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.RestaurantPagination) (*models.PaginatedResponse[models.Restaurant], error)); ok {
+		return rf(ctx, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.RestaurantPagination) *models.PaginatedResponse[models.Restaurant]); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PaginatedResponse[models.Restaurant])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.RestaurantPagination) error); ok {
+		r1 = rf(ctx, pagination)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRestaurantByID provides a mock function with given fields: ctx, ID
 func (_m *RestaurantRepository) GetRestaurantByID(ctx context.Context, ID uuid.UUID) (*models.Restaurant, error) {
 	ret := _m.Called(ctx, ID)
@@ -94,34 +124,22 @@ func (_m *RestaurantRepository) GetRestaurantByUserID(ctx context.Context, userI
 	return r0, r1
 }
 
-// GetRestaurantIDByUserID provides a mock function with given fields: ctx, userID
-func (_m *RestaurantRepository) GetRestaurantIDByUserID(ctx context.Context, userID uuid.UUID) (*uuid.UUID, error) {
-	ret := _m.Called(ctx, userID)
+// UpdateStatus provides a mock function with given fields: ctx, restaurantID, status
+func (_m *RestaurantRepository) UpdateStatus(ctx context.Context, restaurantID uuid.UUID, status models.RestaurantStatus) error {
+	ret := _m.Called(ctx, restaurantID, status)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetRestaurantIDByUserID")
+		panic("no return value specified for UpdateStatus")
 	}
 
-	var r0 *uuid.UUID
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*uuid.UUID, error)); ok {
-		return rf(ctx, userID)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *uuid.UUID); ok {
-		r0 = rf(ctx, userID)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*uuid.UUID)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, userID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, models.RestaurantStatus) error); ok {
+		r0 = rf(ctx, restaurantID, status)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
 // NewRestaurantRepository creates a new instance of RestaurantRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -16,9 +16,39 @@ type SessionService struct {
 	mock.Mock
 }
 
-// CreateSession provides a mock function with given fields: ctx, userID, restaurantID, role
-func (_m *SessionService) CreateSession(ctx context.Context, userID uuid.UUID, restaurantID *uuid.UUID, role models.Role) (*models.Session, error) {
-	ret := _m.Called(ctx, userID, restaurantID, role)
+// CreateImpersonationSession provides a mock function with given fields: ctx, userID, restaurantID, role, memberRole, impersonatedBy
+func (_m *SessionService) CreateImpersonationSession(ctx context.Context, userID uuid.UUID, restaurantID *uuid.UUID, role models.Role, memberRole *models.MemberRole, impersonatedBy uuid.UUID) (*models.Session, error) {
+	ret := _m.Called(ctx, userID, restaurantID, role, memberRole, impersonatedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateImpersonationSession")
+	}
+
+	var r0 *models.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *uuid.UUID, models.Role, *models.MemberRole, uuid.UUID) (*models.Session, error)); ok {
+		return rf(ctx, userID, restaurantID, role, memberRole, impersonatedBy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *uuid.UUID, models.Role, *models.MemberRole, uuid.UUID) *models.Session); ok {
+		r0 = rf(ctx, userID, restaurantID, role, memberRole, impersonatedBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *uuid.UUID, models.Role, *models.MemberRole, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, restaurantID, role, memberRole, impersonatedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateSession provides a mock function with given fields: ctx, userID, restaurantID, role, memberRole
+func (_m *SessionService) CreateSession(ctx context.Context, userID uuid.UUID, restaurantID *uuid.UUID, role models.Role, memberRole *models.MemberRole) (*models.Session, error) {
+	ret := _m.Called(ctx, userID, restaurantID, role, memberRole)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateSession")
@@ -26,19 +56,19 @@ func (_m *SessionService) CreateSession(ctx context.Context, userID uuid.UUID, r
 
 	var r0 *models.Session
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *uuid.UUID, models.Role) (*models.Session, error)); ok {
-		return rf(ctx, userID, restaurantID, role)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *uuid.UUID, models.Role, *models.MemberRole) (*models.Session, error)); ok {
+		return rf(ctx, userID, restaurantID, role, memberRole)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *uuid.UUID, models.Role) *models.Session); ok {
-		r0 = rf(ctx, userID, restaurantID, role)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *uuid.UUID, models.Role, *models.MemberRole) *models.Session); ok {
+		r0 = rf(ctx, userID, restaurantID, role, memberRole)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Session)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *uuid.UUID, models.Role) error); ok {
-		r1 = rf(ctx, userID, restaurantID, role)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *uuid.UUID, models.Role, *models.MemberRole) error); ok {
+		r1 = rf(ctx, userID, restaurantID, role, memberRole)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -64,6 +94,24 @@ func (_m *SessionService) DeleteAllSessions(ctx context.Context, userID uuid.UUI
 	return r0
 }
 
+// DeleteOtherSessions provides a mock function with given fields: ctx, userID, currentSessionID
+func (_m *SessionService) DeleteOtherSessions(ctx context.Context, userID uuid.UUID, currentSessionID uuid.UUID) error {
+	ret := _m.Called(ctx, userID, currentSessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteOtherSessions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID, currentSessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteSession provides a mock function with given fields: ctx, sessionID
 func (_m *SessionService) DeleteSession(ctx context.Context, sessionID uuid.UUID) error {
 	ret := _m.Called(ctx, sessionID)
@@ -142,6 +190,54 @@ func (_m *SessionService) GetSessionsByUserID(ctx context.Context, userID uuid.U
 	return r0, r1
 }
 
+// RefreshSession provides a mock function with given fields: ctx, refreshToken
+func (_m *SessionService) RefreshSession(ctx context.Context, refreshToken string) (*models.Session, error) {
+	ret := _m.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshSession")
+	}
+
+	var r0 *models.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Session, error)); ok {
+		return rf(ctx, refreshToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Session); ok {
+		r0 = rf(ctx, refreshToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TouchSession provides a mock function with given fields: ctx, session
+func (_m *SessionService) TouchSession(ctx context.Context, session *models.Session) error {
+	ret := _m.Called(ctx, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TouchSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Session) error); ok {
+		r0 = rf(ctx, session)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewSessionService creates a new instance of SessionService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewSessionService(t interface {
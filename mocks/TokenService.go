@@ -71,6 +71,36 @@ func (_m *TokenService) ExtractSessionID(token string) (uuid.UUID, error) {
 	return r0, r1
 }
 
+// ExtractUserID provides a mock function with given fields: token
+func (_m *TokenService) ExtractUserID(token string) (uuid.UUID, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExtractUserID")
+	}
+
+	var r0 uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (uuid.UUID, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) uuid.UUID); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewTokenService creates a new instance of TokenService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTokenService(t interface {
@@ -31,6 +31,24 @@ func (_m *UserHandler) CreateUser(ctx echo.Context) error {
 	return r0
 }
 
+// GetSessions provides a mock function with given fields: ctx
+func (_m *UserHandler) GetSessions(ctx echo.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSessions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetUser provides a mock function with given fields: ctx
 func (_m *UserHandler) GetUser(ctx echo.Context) error {
 	ret := _m.Called(ctx)
@@ -49,6 +67,42 @@ func (_m *UserHandler) GetUser(ctx echo.Context) error {
 	return r0
 }
 
+// RevokeOtherSessions provides a mock function with given fields: ctx
+func (_m *UserHandler) RevokeOtherSessions(ctx echo.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeOtherSessions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeSession provides a mock function with given fields: ctx
+func (_m *UserHandler) RevokeSession(ctx echo.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewUserHandler creates a new instance of UserHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserHandler(t interface {
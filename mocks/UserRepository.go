@@ -34,6 +34,36 @@ func (_m *UserRepository) CreateUser(ctx context.Context, user models.User) erro
 	return r0
 }
 
+// GetPaginatedUsers provides a mock function with given fields: ctx, pagination
+func (_m *UserRepository) GetPaginatedUsers(ctx context.Context, pagination *models.UserPagination) (*models.PaginatedResponse[models.User], error) {
+	ret := _m.Called(ctx, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaginatedUsers")
+	}
+
+	var r0 *models.PaginatedResponse[models.User]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserPagination) (*models.PaginatedResponse[models.User], error)); ok {
+		return rf(ctx, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserPagination) *models.PaginatedResponse[models.User]); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PaginatedResponse[models.User])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.UserPagination) error); ok {
+		r1 = rf(ctx, pagination)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserByEmail provides a mock function with given fields: ctx, email
 func (_m *UserRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
 	ret := _m.Called(ctx, email)
@@ -94,6 +124,24 @@ func (_m *UserRepository) GetUserByID(ctx context.Context, ID uuid.UUID) (*model
 	return r0, r1
 }
 
+// UpdateStatus provides a mock function with given fields: ctx, userID, status
+func (_m *UserRepository) UpdateStatus(ctx context.Context, userID uuid.UUID, status models.Status) error {
+	ret := _m.Called(ctx, userID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, models.Status) error); ok {
+		r0 = rf(ctx, userID, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewUserRepository creates a new instance of UserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserRepository(t interface {
@@ -46,6 +46,36 @@ func (_m *UserService) CreateUser(ctx context.Context, payload models.CreateUser
 	return r0, r1
 }
 
+// GetSessions provides a mock function with given fields: ctx
+func (_m *UserService) GetSessions(ctx context.Context) ([]*models.SessionResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSessions")
+	}
+
+	var r0 []*models.SessionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.SessionResponse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.SessionResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.SessionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUser provides a mock function with given fields: ctx
 func (_m *UserService) GetUser(ctx context.Context) (*models.UserResponse, error) {
 	ret := _m.Called(ctx)
@@ -76,6 +106,42 @@ func (_m *UserService) GetUser(ctx context.Context) (*models.UserResponse, error
 	return r0, r1
 }
 
+// RevokeOtherSessions provides a mock function with given fields: ctx
+func (_m *UserService) RevokeOtherSessions(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeOtherSessions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeSession provides a mock function with given fields: ctx, sessionID
+func (_m *UserService) RevokeSession(ctx context.Context, sessionID uuid.UUID) error {
+	ret := _m.Called(ctx, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewUserService creates a new instance of UserService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserService(t interface {
@@ -0,0 +1,141 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAPIKeyNotFound     = errors.New("api key not found in the database")
+	ErrInvalidAPIKey      = errors.New("invalid api key")
+	ErrAPIKeyScopeDenied  = errors.New("api key scope not allowed")
+	ErrAPIKeyScopeUnknown = errors.New("unknown api key scope")
+)
+
+// APIKeyPrefix marks a bearer credential as an API key instead of a session JWT.
+const APIKeyPrefix = "fsk_"
+
+// APIKeyScopes are the permissions that can be delegated to a restaurant integration.
+var APIKeyScopes = []Permission{
+	ListOrdersPermission, ApproveOrderPermission, DispatchOrderPermission, CancelOrderPermission,
+	UpdateMenuPermission, ListEvaluationsPermission, GetMonthlyMetricsPermission, GetMonthlyTipsPermission,
+	ManageWebhooksPermission,
+}
+
+type APIKey struct {
+	BaseModel
+	RestaurantID uuid.UUID    `gorm:"column:RestaurantID;type:char(36);not null;index"`
+	CreatedBy    uuid.UUID    `gorm:"column:CreatedBy;type:char(36);not null"`
+	Name         string       `gorm:"column:Name;type:varchar(100);not null"`
+	Prefix       string       `gorm:"column:Prefix;type:varchar(16);not null;uniqueIndex"`
+	Hash         string       `gorm:"column:Hash;type:char(64);not null"`
+	Scopes       string       `gorm:"column:Scopes;type:varchar(1000);not null"`
+	ExpiresAt    sql.NullTime `gorm:"column:ExpiresAt"`
+	LastUsedAt   sql.NullTime `gorm:"column:LastUsedAt"`
+	Restaurant   Restaurant   `gorm:"foreignKey:RestaurantID;references:ID;OnDelete:CASCADE"`
+}
+
+func (a *APIKey) TableName() string {
+	return "APIKeys"
+}
+
+type CreateAPIKeyPayload struct {
+	Name          string       `json:"name" validate:"required,max=100"`
+	Scopes        []Permission `json:"scopes" validate:"required,min=1,dive,required"`
+	ExpiresInDays *int         `json:"expiresInDays,omitempty" validate:"omitempty,min=1,max=365"`
+}
+
+type APIKeyResponse struct {
+	ID         uuid.UUID    `json:"id"`
+	Name       string       `json:"name"`
+	Prefix     string       `json:"prefix"`
+	Scopes     []Permission `json:"scopes"`
+	ExpiresAt  string       `json:"expiresAt,omitempty"`
+	LastUsedAt string       `json:"lastUsedAt,omitempty"`
+	CreatedAt  string       `json:"createdAt"`
+}
+
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	// Key is only returned once, when the key is created.
+	Key string `json:"key"`
+}
+
+func (payload *CreateAPIKeyPayload) Validate() error {
+	for _, scope := range payload.Scopes {
+		if !slices.Contains(APIKeyScopes, scope) {
+			return ErrAPIKeyScopeUnknown
+		}
+	}
+
+	return nil
+}
+
+func (payload *CreateAPIKeyPayload) ToAPIKey(restaurantID, createdBy uuid.UUID, prefix, hash string) *APIKey {
+	ID, _ := uuid.NewV7()
+
+	scopes := make([]string, 0, len(payload.Scopes))
+	for _, scope := range payload.Scopes {
+		if !slices.Contains(scopes, string(scope)) {
+			scopes = append(scopes, string(scope))
+		}
+	}
+
+	expiresAt := sql.NullTime{}
+	if payload.ExpiresInDays != nil {
+		expiresAt = sql.NullTime{Time: time.Now().UTC().AddDate(0, 0, *payload.ExpiresInDays), Valid: true}
+	}
+
+	return &APIKey{
+		BaseModel: BaseModel{
+			ID: ID,
+		},
+		RestaurantID: restaurantID,
+		CreatedBy:    createdBy,
+		Name:         payload.Name,
+		Prefix:       prefix,
+		Hash:         hash,
+		Scopes:       strings.Join(scopes, ","),
+		ExpiresAt:    expiresAt,
+	}
+}
+
+func (a *APIKey) ScopeList() []Permission {
+	var scopes []Permission
+	for _, scope := range strings.Split(a.Scopes, ",") {
+		if scope != "" {
+			scopes = append(scopes, Permission(scope))
+		}
+	}
+
+	return scopes
+}
+
+func (a *APIKey) IsExpired() bool {
+	return a.ExpiresAt.Valid && time.Now().After(a.ExpiresAt.Time)
+}
+
+func (a *APIKey) ToAPIKeyResponse() *APIKeyResponse {
+	response := &APIKeyResponse{
+		ID:        a.ID,
+		Name:      a.Name,
+		Prefix:    a.Prefix,
+		Scopes:    a.ScopeList(),
+		CreatedAt: a.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+
+	if a.ExpiresAt.Valid {
+		response.ExpiresAt = a.ExpiresAt.Time.Format("2006-01-02 15:04:05")
+	}
+
+	if a.LastUsedAt.Valid {
+		response.LastUsedAt = a.LastUsedAt.Time.Format("2006-01-02 15:04:05")
+	}
+
+	return response
+}
@@ -1,11 +1,64 @@
 package models
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
 
 var (
-	ErrMagicLinkNotFound = errors.New("magic link not found")
+	ErrMagicLinkNotFound     = errors.New("magic link not found")
+	ErrSignInCodeNotFound    = errors.New("sign-in code not found")
+	ErrInvalidSignInCode     = errors.New("invalid sign-in code")
+	ErrTooManySignInAttempts = errors.New("too many sign-in attempts")
+)
+
+type SignInMethod string
+
+const (
+	MagicLinkSignIn SignInMethod = "link"
+	CodeSignIn      SignInMethod = "code"
 )
 
+// TokenResponseMode makes the sign-in verification endpoints return the token
+// in the body instead of setting the session cookie.
+const TokenResponseMode = "token"
+
 type SignInPayload struct {
+	Email  string       `json:"email" validate:"required,email,max=255"`
+	Method SignInMethod `json:"method,omitempty" validate:"omitempty,oneof=link code"`
+}
+
+type VerifySignInCodePayload struct {
 	Email string `json:"email" validate:"required,email,max=255"`
+	Code  string `json:"code" validate:"required,len=6,numeric"`
+}
+
+type SignInCode struct {
+	UserID    uuid.UUID `json:"userId"`
+	Hash      string    `json:"hash"`
+	ExpiresAt int64     `json:"expiresAt"`
+}
+
+type RefreshTokenPayload struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+type AuthTokenResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refreshToken,omitempty"`
+}
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+}
+
+type JWKSResponse struct {
+	Keys []JWK `json:"keys"`
 }
@@ -3,7 +3,9 @@ package models
 type EmailTemplate string
 
 const (
-	SignInMagicLink EmailTemplate = "sign-in-magic-link"
+	SignInMagicLink      EmailTemplate = "sign-in-magic-link"
+	SignInOneTimeCode    EmailTemplate = "sign-in-code"
+	RestaurantInvitation EmailTemplate = "restaurant-invitation"
 )
 
 type Email struct {
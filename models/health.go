@@ -0,0 +1,17 @@
+package models
+
+type HealthStatus string
+
+const (
+	HealthUp   HealthStatus = "up"
+	HealthDown HealthStatus = "down"
+)
+
+type HealthResponse struct {
+	Status HealthStatus            `json:"status"`
+	Checks map[string]HealthStatus `json:"checks"`
+}
+
+func (h *HealthResponse) IsHealthy() bool {
+	return h.Status == HealthUp
+}
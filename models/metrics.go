@@ -4,3 +4,9 @@ type MonthlyMetricsResponse struct {
 	Amount            float64 `json:"amount"`
 	DiffFromLastMonth float64 `json:"diffFromLastMonth"`
 }
+
+type MonthlyTipsMetricsResponse struct {
+	TotalInCents      int     `json:"totalInCents"`
+	OrdersWithTip     int     `json:"ordersWithTip"`
+	DiffFromLastMonth float64 `json:"diffFromLastMonth"`
+}
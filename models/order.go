@@ -1,7 +1,9 @@
 package models
 
 import (
+	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -9,16 +11,21 @@ import (
 var (
 	ErrorOrderNotFound                  = errors.New("order not found in database")
 	ErrOrderIsDelivered                 = errors.New("order is already delivered")
-	ErrorOrderCannotBeCancelled         = errors.New("order cannot be cancelled unless status is 'pending' or 'processing'")
+	ErrorOrderCannotBeCancelled         = errors.New("order cannot be cancelled unless status is 'scheduled', 'pending' or 'processing'")
 	ErrorOrderDoesNotBelongToRestaurant = errors.New("order does not belong to the specified restaurant")
 	ErrOrderCannotBeApproved            = errors.New("order cannot be approved unless status is 'pending'")
 	ErrOrderCannotBeDispatched          = errors.New("order cannot be dispatched unless status is 'processing'")
 	ErrOrderCannotBeDelivered           = errors.New("order cannot be delivered unless status is 'delivering'")
+	ErrInvalidTipPercentage             = errors.New("tip percentage must be between 1 and 100")
+	ErrScheduledTimeTooSoon             = errors.New("scheduled time does not respect the minimum lead time")
+	ErrCourierNotFound                  = errors.New("courier not found in the database")
+	ErrOrderNotAssignedToCourier        = errors.New("order is not assigned to the courier")
 )
 
 type OrderStatus string
 
 const (
+	Scheduled  OrderStatus = "scheduled"
 	Pending    OrderStatus = "pending"
 	Canceled   OrderStatus = "canceled"
 	Processing OrderStatus = "processing"
@@ -26,14 +33,26 @@ const (
 	Delivered  OrderStatus = "delivered"
 )
 
+type TipType string
+
+const (
+	FixedTip      TipType = "fixed"
+	PercentageTip TipType = "percentage"
+)
+
 type Order struct {
 	BaseModel
-	CustommerID  uuid.UUID   `gorm:"column:CustommerID;type:char(36);not null"`
-	RestaurantID uuid.UUID   `gorm:"column:RestaurantID;type:char(36);not null"`
-	Custommer    User        `gorm:"foreignKey:CustommerID;references:ID;OnDelete:CASCADE"`
-	Restaurant   Restaurant  `gorm:"foreignKey:RestaurantID;references:ID;OnDelete:CASCADE"`
-	Status       OrderStatus `gorm:"column:Status;type:enum('pending', 'canceled', 'processing', 'delivering', 'delivered');default:'pending';not null;index"`
-	TotalInCents int         `gorm:"column:TotalInCents;type:int;not null"`
+	CustommerID       uuid.UUID       `gorm:"column:CustommerID;type:char(36);not null"`
+	RestaurantID      uuid.UUID       `gorm:"column:RestaurantID;type:char(36);not null"`
+	Custommer         User            `gorm:"foreignKey:CustommerID;references:ID;OnDelete:CASCADE"`
+	Restaurant        Restaurant      `gorm:"foreignKey:RestaurantID;references:ID;OnDelete:CASCADE"`
+	Status            OrderStatus     `gorm:"column:Status;type:enum('scheduled', 'pending', 'canceled', 'processing', 'delivering', 'delivered');default:'pending';not null;index"`
+	TotalInCents      int             `gorm:"column:TotalInCents;type:int;not null"`
+	TipInCents        int             `gorm:"column:TipInCents;type:int;not null;default:0"`
+	ScheduledFor      sql.NullTime    `gorm:"column:ScheduledFor;default:null;index"`
+	CourierID         uuid.NullUUID   `gorm:"column:CourierID;type:char(36);default:null;index"`
+	DeliveryLatitude  sql.NullFloat64 `gorm:"column:DeliveryLatitude;type:decimal(10,8);default:null"`
+	DeliveryLongitude sql.NullFloat64 `gorm:"column:DeliveryLongitude;type:decimal(11,8);default:null"`
 }
 
 func (o *Order) TableName() string {
@@ -45,8 +64,31 @@ type OrderPerMonth struct {
 	Amount        int
 }
 
+type TipsPerMonth struct {
+	MonthWithYear string
+	TotalInCents  int
+	Amount        int
+}
+
 type CreateOrderPayload struct {
-	Items []CreateOrderItemPayload `json:"items" validate:"required,dive,required"`
+	Items            []CreateOrderItemPayload `json:"items" validate:"required,dive,required"`
+	Tip              *CreateOrderTipPayload   `json:"tip,omitempty" validate:"omitempty"`
+	ScheduledFor     *time.Time               `json:"scheduledFor,omitempty"`
+	DeliveryLocation *LocationPayload         `json:"deliveryLocation,omitempty" validate:"omitempty"`
+}
+
+type CreateOrderTipPayload struct {
+	Type  TipType `json:"type" validate:"required,oneof=fixed percentage"`
+	Value int     `json:"value" validate:"required,min=1"`
+}
+
+type DispatchOrderPayload struct {
+	CourierID *uuid.UUID `json:"courierId,omitempty"`
+}
+
+type DeliveryPagination struct {
+	Pagination
+	Status *string `json:"status"`
 }
 
 type OrderPagination struct {
@@ -57,14 +99,29 @@ type OrderPagination struct {
 }
 
 type OrderResponse struct {
-	ID            uuid.UUID   `json:"id"`
-	CustommerName string      `json:"custommerName"`
-	Status        OrderStatus `json:"status"`
-	TotalInCents  int         `json:"totalInCents"`
-	CreatedAt     string      `json:"createdAt"`
+	ID             uuid.UUID   `json:"id"`
+	CustommerName  string      `json:"custommerName"`
+	RestaurantName string      `json:"restaurantName,omitempty"`
+	Status         OrderStatus `json:"status"`
+	TotalInCents   int         `json:"totalInCents"`
+	TipInCents     int         `json:"tipInCents"`
+	ScheduledFor   *string     `json:"scheduledFor,omitempty"`
+	CourierID      *string     `json:"courierId,omitempty"`
+	CreatedAt      string      `json:"createdAt"`
+}
+
+type DeliveryResponse struct {
+	ID             uuid.UUID   `json:"id"`
+	RestaurantName string      `json:"restaurantName"`
+	CustommerName  string      `json:"custommerName"`
+	CustommerPhone string      `json:"custommerPhone,omitempty"`
+	Status         OrderStatus `json:"status"`
+	TotalInCents   int         `json:"totalInCents"`
+	TipInCents     int         `json:"tipInCents"`
+	CreatedAt      string      `json:"createdAt"`
 }
 
-func NewOrder(custommerID, restaurantID uuid.UUID, totalInCents int) *Order {
+func NewOrder(custommerID, restaurantID uuid.UUID, totalInCents, tipInCents int) *Order {
 	ID, _ := uuid.NewUUID()
 	return &Order{
 		BaseModel: BaseModel{
@@ -73,15 +130,68 @@ func NewOrder(custommerID, restaurantID uuid.UUID, totalInCents int) *Order {
 		CustommerID:  custommerID,
 		RestaurantID: restaurantID,
 		TotalInCents: totalInCents,
+		TipInCents:   tipInCents,
 	}
 }
 
+func (o *Order) SetDeliveryLocation(location *LocationPayload) {
+	o.DeliveryLatitude = sql.NullFloat64{Float64: *location.Latitude, Valid: true}
+	o.DeliveryLongitude = sql.NullFloat64{Float64: *location.Longitude, Valid: true}
+}
+
+func (o *Order) Schedule(scheduledFor time.Time) {
+	o.Status = Scheduled
+	o.ScheduledFor = sql.NullTime{Time: scheduledFor.UTC(), Valid: true}
+}
+
 func (o *Order) ToOrderResponse() *OrderResponse {
-	return &OrderResponse{
-		ID:            o.ID,
-		CustommerName: o.Custommer.FullName,
-		Status:        o.Status,
-		TotalInCents:  o.TotalInCents,
-		CreatedAt:     o.CreatedAt.Format("2006-01-02 15:04:05"),
+	response := &OrderResponse{
+		ID:             o.ID,
+		CustommerName:  o.Custommer.FullName,
+		RestaurantName: o.Restaurant.Name,
+		Status:         o.Status,
+		TotalInCents:   o.TotalInCents,
+		TipInCents:     o.TipInCents,
+		CreatedAt:      o.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+
+	if o.ScheduledFor.Valid {
+		scheduledFor := o.ScheduledFor.Time.Format("2006-01-02 15:04:05")
+		response.ScheduledFor = &scheduledFor
 	}
+
+	if o.CourierID.Valid {
+		courierID := o.CourierID.UUID.String()
+		response.CourierID = &courierID
+	}
+
+	return response
+}
+
+func (o *Order) ToDeliveryResponse() *DeliveryResponse {
+	return &DeliveryResponse{
+		ID:             o.ID,
+		RestaurantName: o.Restaurant.Name,
+		CustommerName:  o.Custommer.FullName,
+		CustommerPhone: o.Custommer.Phone.String,
+		Status:         o.Status,
+		TotalInCents:   o.TotalInCents,
+		TipInCents:     o.TipInCents,
+		CreatedAt:      o.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func (t *CreateOrderTipPayload) CalculateTipInCents(subtotalInCents int) (int, error) {
+	if t == nil {
+		return 0, nil
+	}
+
+	if t.Type == PercentageTip {
+		if t.Value < 1 || t.Value > 100 {
+			return 0, ErrInvalidTipPercentage
+		}
+		return subtotalInCents * t.Value / 100, nil
+	}
+
+	return t.Value, nil
 }
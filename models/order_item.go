@@ -10,8 +10,6 @@ type OrderItem struct {
 	Product      Product   `gorm:"foreignKey:ProductID;references:ID;OnDelete:CASCADE"`
 	Quantity     int       `gorm:"column:Quantity;type:int;not null"`
 	PriceInCents int       `gorm:"column:PriceInCents;type:int;not null"`
-
-	OrderItems []OrderItem `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE"`
 }
 
 func (o *OrderItem) TableName() string {
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// OutboxMessage is a queue message stored in the same transaction as the
+// change that produced it. The outbox relay publishes it afterwards.
+type OutboxMessage struct {
+	BaseModel
+	Queue       string         `gorm:"column:Queue;type:varchar(100);not null"`
+	Payload     string         `gorm:"column:Payload;type:mediumtext;not null"`
+	Attempts    int            `gorm:"column:Attempts;not null;default:0"`
+	LastError   sql.NullString `gorm:"column:LastError;type:varchar(1000)"`
+	PublishedAt sql.NullTime   `gorm:"column:PublishedAt;index"`
+}
+
+func (o *OutboxMessage) TableName() string {
+	return "OutboxMessages"
+}
+
+func NewOutboxMessage(queue string, payload any) (*OutboxMessage, error) {
+	body, err := jsoniter.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	ID, _ := uuid.NewV7()
+	return &OutboxMessage{
+		BaseModel: BaseModel{
+			ID: ID,
+		},
+		Queue:   queue,
+		Payload: string(body),
+	}, nil
+}
@@ -1,6 +1,20 @@
 package models
 
+import (
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrRoleNotFound         = errors.New("role not found")
+	ErrUnknownPermission    = errors.New("unknown permission")
+	ErrOwnerRoleNotEditable = errors.New("owner role permissions cannot be edited")
+)
+
 type Permission string
+type RoleScope string
 
 const (
 	CreateOrderPermission            Permission = "create_order"
@@ -15,23 +29,182 @@ const (
 	GetEvaluationSummaryPermission   Permission = "get_evaluation_summary"
 	UpdateMenuPermission             Permission = "update_menu"
 	GetMonthlyMetricsPermission      Permission = "get_monthly_metrics"
+	GetMonthlyTipsPermission         Permission = "get_monthly_tips"
+	ListDeliveriesPermission         Permission = "list_deliveries"
+	CompleteDeliveryPermission       Permission = "complete_delivery"
+	UpdateCourierLocationPermission  Permission = "update_courier_location"
+	TrackOrderPermission             Permission = "track_order"
+	ManageMembersPermission          Permission = "manage_members"
+	ManageRolesPermission            Permission = "manage_roles"
+	ManageUsersPermission            Permission = "manage_users"
+	ManageRestaurantsPermission      Permission = "manage_restaurants"
+	ViewAnyOrderPermission           Permission = "view_any_order"
+	ModerateEvaluationsPermission    Permission = "moderate_evaluations"
+	ImpersonateUserPermission        Permission = "impersonate_user"
+	ManageAPIKeysPermission          Permission = "manage_api_keys"
+	ManageWebhooksPermission         Permission = "manage_webhooks"
+)
+
+const (
+	PlatformScope RoleScope = "platform"
+	MemberScope   RoleScope = "member"
 )
 
-var rolePermissions = map[Role][]Permission{
-	Manager: {ListOrdersPermission, CancelOrderPermission, ApproveOrderPermission, DispatchOrderPermission, ListEvaluationsPermission,
-		UpdateEvaluationAnswerPermission, GetEvaluationSummaryPermission, UpdateMenuPermission, GetMonthlyMetricsPermission},
-	Customer: {CreateOrderPermission, DeliverOrderPermission, CreateEvaluationPermission},
+var Permissions = []Permission{
+	CreateOrderPermission, CancelOrderPermission, ApproveOrderPermission, DispatchOrderPermission, DeliverOrderPermission,
+	ListOrdersPermission, CreateEvaluationPermission, ListEvaluationsPermission, UpdateEvaluationAnswerPermission,
+	GetEvaluationSummaryPermission, UpdateMenuPermission, GetMonthlyMetricsPermission, GetMonthlyTipsPermission,
+	ListDeliveriesPermission, CompleteDeliveryPermission, UpdateCourierLocationPermission, TrackOrderPermission,
+	ManageMembersPermission, ManageRolesPermission, ManageUsersPermission, ManageRestaurantsPermission, ViewAnyOrderPermission,
+	ModerateEvaluationsPermission, ImpersonateUserPermission, ManageAPIKeysPermission, ManageWebhooksPermission,
+}
+
+var managerPermissions = []Permission{ListOrdersPermission, CancelOrderPermission, ApproveOrderPermission, DispatchOrderPermission, ListEvaluationsPermission,
+	UpdateEvaluationAnswerPermission, GetEvaluationSummaryPermission, UpdateMenuPermission, GetMonthlyMetricsPermission, GetMonthlyTipsPermission, ManageMembersPermission, ManageAPIKeysPermission, ManageWebhooksPermission}
+
+var adminPermissions = []Permission{ManageRolesPermission, ManageUsersPermission, ManageRestaurantsPermission, ViewAnyOrderPermission,
+	ModerateEvaluationsPermission, ImpersonateUserPermission}
+
+// DefaultRolePermissions holds the grants seeded into the database by the migration.
+// The live grants are read from the RolePermissions table.
+var DefaultRolePermissions = map[RoleScope]map[string][]Permission{
+	PlatformScope: {
+		string(Manager):  managerPermissions,
+		string(Customer): {CreateOrderPermission, DeliverOrderPermission, CreateEvaluationPermission, TrackOrderPermission},
+		string(Courier):  {ListDeliveriesPermission, CompleteDeliveryPermission, UpdateCourierLocationPermission},
+		string(Admin):    adminPermissions,
+	},
+	MemberScope: {
+		string(OwnerMember):   managerPermissions,
+		string(ManagerMember): managerPermissions,
+		string(KitchenMember): {ListOrdersPermission, ApproveOrderPermission, DispatchOrderPermission},
+		string(CashierMember): {ListOrdersPermission, CancelOrderPermission, GetMonthlyMetricsPermission, GetMonthlyTipsPermission},
+	},
+}
+
+type RolePermission struct {
+	BaseModel
+	Scope      RoleScope  `gorm:"column:Scope;type:enum('platform', 'member');not null;uniqueIndex:idx_role_permission"`
+	Role       string     `gorm:"column:Role;type:varchar(50);not null;uniqueIndex:idx_role_permission"`
+	Permission Permission `gorm:"column:Permission;type:varchar(100);not null;uniqueIndex:idx_role_permission"`
+}
+
+// RestaurantRolePermission overrides, inside a single restaurant, whether a
+// member role has a permission. Permissions without an override follow the
+// member scope grants of RolePermissions.
+type RestaurantRolePermission struct {
+	BaseModel
+	RestaurantID uuid.UUID  `gorm:"column:RestaurantID;type:char(36);not null;uniqueIndex:idx_restaurant_role_permission"`
+	Restaurant   Restaurant `gorm:"foreignKey:RestaurantID;references:ID;constraint:OnDelete:CASCADE"`
+	Role         MemberRole `gorm:"column:Role;type:varchar(50);not null;uniqueIndex:idx_restaurant_role_permission"`
+	Permission   Permission `gorm:"column:Permission;type:varchar(100);not null;uniqueIndex:idx_restaurant_role_permission"`
+	Granted      bool       `gorm:"column:Granted;not null"`
+}
+
+// SeededRolePermission records a default grant that was already seeded, so it
+// is not granted again after an admin revokes it.
+type SeededRolePermission struct {
+	BaseModel
+	Scope      RoleScope  `gorm:"column:Scope;type:enum('platform', 'member');not null;uniqueIndex:idx_seeded_role_permission"`
+	Role       string     `gorm:"column:Role;type:varchar(50);not null;uniqueIndex:idx_seeded_role_permission"`
+	Permission Permission `gorm:"column:Permission;type:varchar(100);not null;uniqueIndex:idx_seeded_role_permission"`
+}
+
+type UpdateRolePermissionsPayload struct {
+	Permissions []Permission `json:"permissions" validate:"required,dive,required"`
+}
+
+type RolePermissionsResponse struct {
+	Scope       RoleScope    `json:"scope"`
+	Role        string       `json:"role"`
+	Permissions []Permission `json:"permissions"`
+}
+
+func (r *RolePermission) TableName() string {
+	return "RolePermissions"
 }
 
-func CheckPermission(role Role, permission Permission) bool {
-	permissions, exists := rolePermissions[role]
+func (r *RestaurantRolePermission) TableName() string {
+	return "RestaurantRolePermissions"
+}
+
+func (s *SeededRolePermission) TableName() string {
+	return "SeededRolePermissions"
+}
+
+func NewRolePermission(scope RoleScope, role string, permission Permission) *RolePermission {
+	ID, _ := uuid.NewV7()
+	return &RolePermission{
+		BaseModel: BaseModel{
+			ID: ID,
+		},
+		Scope:      scope,
+		Role:       role,
+		Permission: permission,
+	}
+}
+
+func IsKnownRole(scope RoleScope, role string) bool {
+	roles, exists := DefaultRolePermissions[scope]
 	if !exists {
 		return false
 	}
-	for _, p := range permissions {
-		if p == permission {
-			return true
+
+	_, exists = roles[role]
+	return exists
+}
+
+func (payload *UpdateRolePermissionsPayload) Validate() error {
+	for _, permission := range payload.Permissions {
+		if !slices.Contains(Permissions, permission) {
+			return ErrUnknownPermission
+		}
+	}
+
+	return nil
+}
+
+func (payload *UpdateRolePermissionsPayload) ToRolePermissions(scope RoleScope, role string) []RolePermission {
+	rolePermissions := make([]RolePermission, 0, len(payload.Permissions))
+	for _, permission := range slices.Compact(slices.Sorted(slices.Values(payload.Permissions))) {
+		rolePermissions = append(rolePermissions, *NewRolePermission(scope, role, permission))
+	}
+
+	return rolePermissions
+}
+
+// ToRestaurantRolePermissions keeps only the permissions that differ from the
+// member scope grants of the role, so the restaurant keeps following the
+// defaults it didn't change.
+func (payload *UpdateRolePermissionsPayload) ToRestaurantRolePermissions(restaurantID uuid.UUID, role MemberRole, defaults []Permission) []RestaurantRolePermission {
+	var rolePermissions []RestaurantRolePermission
+	for _, permission := range Permissions {
+		granted := slices.Contains(payload.Permissions, permission)
+		if granted == slices.Contains(defaults, permission) {
+			continue
 		}
+
+		ID, _ := uuid.NewV7()
+		rolePermissions = append(rolePermissions, RestaurantRolePermission{
+			BaseModel:    BaseModel{ID: ID},
+			RestaurantID: restaurantID,
+			Role:         role,
+			Permission:   permission,
+			Granted:      granted,
+		})
+	}
+
+	return rolePermissions
+}
+
+func NewSeededRolePermission(scope RoleScope, role string, permission Permission) *SeededRolePermission {
+	ID, _ := uuid.NewV7()
+	return &SeededRolePermission{
+		BaseModel: BaseModel{
+			ID: ID,
+		},
+		Scope:      scope,
+		Role:       role,
+		Permission: permission,
 	}
-	return false
 }
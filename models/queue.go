@@ -0,0 +1,9 @@
+package models
+
+type DeadLetter struct {
+	Queue    string `json:"queue"`
+	Body     string `json:"body"`
+	Error    string `json:"error"`
+	Retries  int    `json:"retries"`
+	FailedAt string `json:"failedAt"`
+}
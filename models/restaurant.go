@@ -3,20 +3,37 @@ package models
 import (
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 var (
-	ErrRestaurantNotFound = errors.New("restaurant not found in the database")
+	ErrRestaurantNotFound              = errors.New("restaurant not found in the database")
+	ErrRestaurantClosedAtScheduledTime = errors.New("restaurant is closed at the scheduled time")
+	ErrRestaurantSuspended             = errors.New("restaurant is suspended")
+)
+
+const openingHoursLayout = "15:04"
+
+type RestaurantStatus string
+
+const (
+	ActiveRestaurant    RestaurantStatus = "active"
+	SuspendedRestaurant RestaurantStatus = "suspended"
 )
 
 type Restaurant struct {
 	BaseModel
-	Name        string         `gorm:"column:Name;type:varchar(255);not null"`
-	Description sql.NullString `gorm:"column:Description;type:text;default:null"`
-	ManagerID   uuid.UUID      `gorm:"column:ManagerID;type:char(36);not null"`
-	Manager     User           `gorm:"foreignKey:ManagerID;references:ID;OnDelete:CASCADE"`
+	Name        string           `gorm:"column:Name;type:varchar(255);not null"`
+	Description sql.NullString   `gorm:"column:Description;type:text;default:null"`
+	ManagerID   uuid.UUID        `gorm:"column:ManagerID;type:char(36);not null"`
+	Manager     User             `gorm:"foreignKey:ManagerID;references:ID;OnDelete:CASCADE"`
+	OpensAt     string           `gorm:"column:OpensAt;type:char(5);not null;default:'08:00'"`
+	ClosesAt    string           `gorm:"column:ClosesAt;type:char(5);not null;default:'22:00'"`
+	Status      RestaurantStatus `gorm:"column:Status;type:enum('active', 'suspended');not null;default:'active';index"`
+
+	Members []RestaurantMember `gorm:"foreignKey:RestaurantID;constraint:OnDelete:CASCADE"`
 }
 
 func (r *Restaurant) TableName() string {
@@ -26,15 +43,83 @@ func (r *Restaurant) TableName() string {
 type CreateRestaurantPayload struct {
 	Manager        CreateUserPayload `json:"manager" validate:"required"`
 	RestaurantName string            `json:"restaurantName" validate:"required,max=255"`
+	OpensAt        *string           `json:"opensAt,omitempty" validate:"omitempty,datetime=15:04"`
+	ClosesAt       *string           `json:"closesAt,omitempty" validate:"omitempty,datetime=15:04"`
+}
+
+type RestaurantPagination struct {
+	Pagination
+	Search *string `json:"search"`
+	Status *string `json:"status"`
+}
+
+type RestaurantResponse struct {
+	ID          string           `json:"id"`
+	Name        string           `json:"name"`
+	ManagerName string           `json:"managerName"`
+	Status      RestaurantStatus `json:"status"`
+	OpensAt     string           `json:"opensAt"`
+	ClosesAt    string           `json:"closesAt"`
+	CreatedAt   string           `json:"createdAt"`
 }
 
 func (payload *CreateRestaurantPayload) ToRestaurant(managerID uuid.UUID) *Restaurant {
 	ID, _ := uuid.NewV7()
-	return &Restaurant{
+	restaurant := &Restaurant{
 		BaseModel: BaseModel{
 			ID: ID,
 		},
 		Name:      payload.RestaurantName,
 		ManagerID: managerID,
 	}
+
+	restaurant.Members = []RestaurantMember{*NewRestaurantMember(ID, managerID, OwnerMember)}
+
+	if payload.OpensAt != nil {
+		restaurant.OpensAt = *payload.OpensAt
+	}
+
+	if payload.ClosesAt != nil {
+		restaurant.ClosesAt = *payload.ClosesAt
+	}
+
+	return restaurant
+}
+
+func (r *Restaurant) IsOpenAt(t time.Time) bool {
+	if r.OpensAt == "" || r.ClosesAt == "" {
+		return true
+	}
+
+	opensAt, err := time.Parse(openingHoursLayout, r.OpensAt)
+	if err != nil {
+		return false
+	}
+
+	closesAt, err := time.Parse(openingHoursLayout, r.ClosesAt)
+	if err != nil {
+		return false
+	}
+
+	minute := t.Hour()*60 + t.Minute()
+	opensAtMinute := opensAt.Hour()*60 + opensAt.Minute()
+	closesAtMinute := closesAt.Hour()*60 + closesAt.Minute()
+
+	if closesAtMinute <= opensAtMinute {
+		return minute >= opensAtMinute || minute < closesAtMinute
+	}
+
+	return minute >= opensAtMinute && minute < closesAtMinute
+}
+
+func (r *Restaurant) ToRestaurantResponse() *RestaurantResponse {
+	return &RestaurantResponse{
+		ID:          r.ID.String(),
+		Name:        r.Name,
+		ManagerName: r.Manager.FullName,
+		Status:      r.Status,
+		OpensAt:     r.OpensAt,
+		ClosesAt:    r.ClosesAt,
+		CreatedAt:   r.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
 }
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrMemberNotFound              = errors.New("restaurant member not found in the database")
+	ErrUserAlreadyMember           = errors.New("user is already a member of a restaurant")
+	ErrUserCannotJoinRestaurant    = errors.New("user role cannot join a restaurant")
+	ErrInvitationNotFound          = errors.New("member invitation not found")
+	ErrCannotRemoveRestaurantOwner = errors.New("restaurant owner cannot be removed")
+)
+
+type MemberRole string
+
+const (
+	OwnerMember   MemberRole = "owner"
+	ManagerMember MemberRole = "manager"
+	KitchenMember MemberRole = "kitchen"
+	CashierMember MemberRole = "cashier"
+)
+
+type RestaurantMember struct {
+	BaseModel
+	RestaurantID uuid.UUID  `gorm:"column:RestaurantID;type:char(36);not null;index"`
+	UserID       uuid.UUID  `gorm:"column:UserID;type:char(36);not null;uniqueIndex"`
+	Restaurant   Restaurant `gorm:"foreignKey:RestaurantID;references:ID;OnDelete:CASCADE"`
+	User         User       `gorm:"foreignKey:UserID;references:ID;OnDelete:CASCADE"`
+	Role         MemberRole `gorm:"column:Role;type:enum('owner', 'manager', 'kitchen', 'cashier');not null;default:'manager'"`
+}
+
+func (r *RestaurantMember) TableName() string {
+	return "RestaurantMembers"
+}
+
+type InviteMemberPayload struct {
+	FullName string     `json:"fullName" validate:"required,max=255"`
+	Email    string     `json:"email" validate:"required,email,max=255"`
+	Role     MemberRole `json:"role" validate:"required,oneof=manager kitchen cashier"`
+}
+
+type MemberInvitation struct {
+	RestaurantID uuid.UUID  `json:"restaurantId"`
+	FullName     string     `json:"fullName"`
+	Email        string     `json:"email"`
+	Role         MemberRole `json:"role"`
+	InvitedBy    uuid.UUID  `json:"invitedBy"`
+}
+
+type RestaurantMemberResponse struct {
+	UserID    uuid.UUID  `json:"userId"`
+	FullName  string     `json:"fullName"`
+	Email     string     `json:"email"`
+	Role      MemberRole `json:"role"`
+	CreatedAt string     `json:"createdAt"`
+}
+
+func NewRestaurantMember(restaurantID, userID uuid.UUID, role MemberRole) *RestaurantMember {
+	ID, _ := uuid.NewV7()
+	return &RestaurantMember{
+		BaseModel: BaseModel{
+			ID: ID,
+		},
+		RestaurantID: restaurantID,
+		UserID:       userID,
+		Role:         role,
+	}
+}
+
+func (payload *InviteMemberPayload) ToMemberInvitation(restaurantID, invitedBy uuid.UUID) *MemberInvitation {
+	return &MemberInvitation{
+		RestaurantID: restaurantID,
+		FullName:     payload.FullName,
+		Email:        payload.Email,
+		Role:         payload.Role,
+		InvitedBy:    invitedBy,
+	}
+}
+
+func (i *MemberInvitation) ToCreateUserPayload() *CreateUserPayload {
+	return &CreateUserPayload{
+		FullName: i.FullName,
+		Email:    i.Email,
+	}
+}
+
+func (r *RestaurantMember) ToRestaurantMemberResponse() *RestaurantMemberResponse {
+	return &RestaurantMemberResponse{
+		UserID:    r.UserID,
+		FullName:  r.User.FullName,
+		Email:     r.User.Email,
+		Role:      r.Role,
+		CreatedAt: r.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -2,19 +2,60 @@ package models
 
 import (
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 var (
-	ErrSessionNotFound = errors.New("session not found in the database")
+	ErrSessionNotFound     = errors.New("session not found in the database")
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reused")
 )
 
 type Session struct {
-	UserID       uuid.UUID  `json:"userId"`
-	SessionID    uuid.UUID  `json:"sessionId"`
-	RestaurantID *uuid.UUID `json:"restaurantID,omitempty"`
-	Role         Role       `json:"role"`
-	Token        string     `json:"token"`
-	CreatedAt    int64      `json:"createdAt"`
+	UserID       uuid.UUID   `json:"userId"`
+	SessionID    uuid.UUID   `json:"sessionId"`
+	RestaurantID *uuid.UUID  `json:"restaurantID,omitempty"`
+	Role         Role        `json:"role"`
+	MemberRole   *MemberRole `json:"memberRole,omitempty"`
+	// ImpersonatedBy holds the ID of the admin who opened this session on behalf of the user.
+	ImpersonatedBy *uuid.UUID `json:"impersonatedBy,omitempty"`
+	Token          string     `json:"token"`
+	// RefreshToken is only filled when the token is issued; the cache keeps its hash.
+	RefreshToken     string `json:"-"`
+	RefreshTokenHash string `json:"refreshTokenHash,omitempty"`
+	UserAgent        string `json:"userAgent,omitempty"`
+	IPAddress        string `json:"ipAddress,omitempty"`
+	CreatedAt        int64  `json:"createdAt"`
+	LastSeenAt       int64  `json:"lastSeenAt"`
+}
+
+// RefreshTokenEntry is stored under the refresh token hash and points back to
+// the session it was issued for.
+type RefreshTokenEntry struct {
+	SessionID uuid.UUID `json:"sessionId"`
+	UserID    uuid.UUID `json:"userId"`
+}
+
+type SessionResponse struct {
+	ID           uuid.UUID `json:"id"`
+	UserAgent    string    `json:"userAgent"`
+	IPAddress    string    `json:"ipAddress"`
+	Current      bool      `json:"current"`
+	Impersonated bool      `json:"impersonated"`
+	CreatedAt    string    `json:"createdAt"`
+	LastSeenAt   string    `json:"lastSeenAt"`
+}
+
+func (s *Session) ToSessionResponse(currentSessionID uuid.UUID) *SessionResponse {
+	return &SessionResponse{
+		ID:           s.SessionID,
+		UserAgent:    s.UserAgent,
+		IPAddress:    s.IPAddress,
+		Current:      s.SessionID == currentSessionID,
+		Impersonated: s.ImpersonatedBy != nil,
+		CreatedAt:    time.Unix(s.CreatedAt, 0).UTC().Format("2006-01-02 15:04:05"),
+		LastSeenAt:   time.Unix(s.LastSeenAt, 0).UTC().Format("2006-01-02 15:04:05"),
+	}
 }
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrOrderIsNotBeingDelivered     = errors.New("order is not being delivered")
+	ErrOrderDoesNotBelongToCustomer = errors.New("order does not belong to the customer")
+	ErrCourierLocationNotFound      = errors.New("courier location not found")
+)
+
+const earthRadiusInKm = 6371.0
+
+type LocationPayload struct {
+	Latitude  *float64 `json:"latitude" validate:"required,latitude"`
+	Longitude *float64 `json:"longitude" validate:"required,longitude"`
+}
+
+type UpdateCourierLocationPayload struct {
+	LocationPayload
+	SpeedInKmh *float64 `json:"speedInKmh,omitempty" validate:"omitempty,gte=0"`
+}
+
+type CourierLocation struct {
+	Latitude   float64  `json:"latitude"`
+	Longitude  float64  `json:"longitude"`
+	SpeedInKmh *float64 `json:"speedInKmh,omitempty"`
+	RecordedAt int64    `json:"recordedAt"`
+}
+
+type OrderTrackingResponse struct {
+	OrderID          uuid.UUID   `json:"orderId"`
+	Status           OrderStatus `json:"status"`
+	Latitude         float64     `json:"latitude"`
+	Longitude        float64     `json:"longitude"`
+	RecordedAt       string      `json:"recordedAt"`
+	DistanceInMeters *int        `json:"distanceInMeters,omitempty"`
+	ETAInMinutes     *int        `json:"etaInMinutes,omitempty"`
+}
+
+func (payload *UpdateCourierLocationPayload) ToCourierLocation() *CourierLocation {
+	return &CourierLocation{
+		Latitude:   *payload.Latitude,
+		Longitude:  *payload.Longitude,
+		SpeedInKmh: payload.SpeedInKmh,
+		RecordedAt: time.Now().Unix(),
+	}
+}
+
+func (c *CourierLocation) DistanceInKm(latitude, longitude float64) float64 {
+	lat1 := c.Latitude * math.Pi / 180
+	lat2 := latitude * math.Pi / 180
+	deltaLat := (latitude - c.Latitude) * math.Pi / 180
+	deltaLon := (longitude - c.Longitude) * math.Pi / 180
+
+	a := math.Sin(deltaLat/2)*math.Sin(deltaLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(deltaLon/2)*math.Sin(deltaLon/2)
+
+	return earthRadiusInKm * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+func (c *CourierLocation) ToOrderTrackingResponse(order *Order, averageSpeedInKmh float64) *OrderTrackingResponse {
+	response := &OrderTrackingResponse{
+		OrderID:    order.ID,
+		Status:     order.Status,
+		Latitude:   c.Latitude,
+		Longitude:  c.Longitude,
+		RecordedAt: time.Unix(c.RecordedAt, 0).UTC().Format("2006-01-02 15:04:05"),
+	}
+
+	if !order.DeliveryLatitude.Valid || !order.DeliveryLongitude.Valid {
+		return response
+	}
+
+	distanceInKm := c.DistanceInKm(order.DeliveryLatitude.Float64, order.DeliveryLongitude.Float64)
+	distanceInMeters := int(math.Round(distanceInKm * 1000))
+	response.DistanceInMeters = &distanceInMeters
+
+	speed := averageSpeedInKmh
+	if c.SpeedInKmh != nil && *c.SpeedInKmh > 0 {
+		speed = *c.SpeedInKmh
+	}
+
+	if speed > 0 {
+		etaInMinutes := int(math.Ceil(distanceInKm / speed * 60))
+		response.ETAInMinutes = &etaInMinutes
+	}
+
+	return response
+}
@@ -11,6 +11,9 @@ var (
 	ErrUserNotFound          = errors.New("user not found in the database")
 	ErrEmailAlreadyExists    = errors.New("email already exists in the database")
 	ErrUserNotFoundInContext = errors.New("user not found in the context")
+	ErrCannotImpersonateUser = errors.New("user cannot be impersonated")
+	ErrCannotBlockUser       = errors.New("user cannot be blocked")
+	ErrUserBlocked           = errors.New("user is blocked")
 )
 
 type Status string
@@ -24,6 +27,8 @@ const (
 const (
 	Manager  Role = "manager"
 	Customer Role = "customer"
+	Courier  Role = "courier"
+	Admin    Role = "admin"
 )
 
 type User struct {
@@ -31,7 +36,7 @@ type User struct {
 	FullName string         `gorm:"column:FullName;type:varchar(255);not null"`
 	Email    string         `gorm:"column:Email;type:varchar(255);not null;unique"`
 	Status   Status         `gorm:"column:Status;type:enum('active', 'blocked');not null;default:'active'"`
-	Role     Role           `gorm:"column:Role;type:enum('manager', 'customer');not null;default:'customer';index"`
+	Role     Role           `gorm:"column:Role;type:enum('manager', 'customer', 'courier', 'admin');not null;default:'customer';index"`
 	Phone    sql.NullString `gorm:"column:Phone;type:varchar(20)"`
 	Avatar   sql.NullString `gorm:"column:Avatar;type:varchar(255)"`
 }
@@ -46,6 +51,13 @@ type CreateUserPayload struct {
 	Phone    *string `json:"phone,omitempty" validate:"omitempty,max=20,phone_format"`
 }
 
+type UserPagination struct {
+	Pagination
+	Search *string `json:"search"`
+	Role   *string `json:"role"`
+	Status *string `json:"status"`
+}
+
 type UserResponse struct {
 	ID             string `json:"id"`
 	FullName       string `json:"full_name"`
@@ -54,6 +66,16 @@ type UserResponse struct {
 	Avatar         string `json:"avatar,omitempty"`
 }
 
+type AdminUserResponse struct {
+	ID        string `json:"id"`
+	FullName  string `json:"fullName"`
+	Email     string `json:"email"`
+	Phone     string `json:"phone,omitempty"`
+	Role      Role   `json:"role"`
+	Status    Status `json:"status"`
+	CreatedAt string `json:"createdAt"`
+}
+
 func (payload *CreateUserPayload) ToUser(Role Role) *User {
 	ID, _ := uuid.NewV7()
 
@@ -81,3 +103,15 @@ func (user *User) ToUserResponse() *UserResponse {
 		Avatar:   user.Avatar.String,
 	}
 }
+
+func (user *User) ToAdminUserResponse() *AdminUserResponse {
+	return &AdminUserResponse{
+		ID:        user.ID.String(),
+		FullName:  user.FullName,
+		Email:     user.Email,
+		Phone:     user.Phone.String,
+		Role:      user.Role,
+		Status:    user.Status,
+		CreatedAt: user.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,283 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+)
+
+var (
+	ErrWebhookNotFound         = errors.New("webhook not found in the database")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found in the database")
+	ErrWebhookURLNotAllowed    = errors.New("webhook url must use https and point to a public address")
+)
+
+type WebhookEvent string
+type WebhookDeliveryStatus string
+
+const (
+	OrderCreatedEvent       WebhookEvent = "order.created"
+	OrderStatusChangedEvent WebhookEvent = "order.status_changed"
+	EvaluationCreatedEvent  WebhookEvent = "evaluation.created"
+	MenuUpdatedEvent        WebhookEvent = "menu.updated"
+	PingEvent               WebhookEvent = "ping"
+)
+
+const (
+	PendingDelivery   WebhookDeliveryStatus = "pending"
+	SucceededDelivery WebhookDeliveryStatus = "succeeded"
+	FailedDelivery    WebhookDeliveryStatus = "failed"
+)
+
+var WebhookEvents = []WebhookEvent{OrderCreatedEvent, OrderStatusChangedEvent, EvaluationCreatedEvent, MenuUpdatedEvent}
+
+type Webhook struct {
+	BaseModel
+	RestaurantID uuid.UUID  `gorm:"column:RestaurantID;type:char(36);not null;index"`
+	URL          string     `gorm:"column:URL;type:varchar(2048);not null"`
+	Secret       string     `gorm:"column:Secret;type:varchar(64);not null"`
+	Events       string     `gorm:"column:Events;type:varchar(255);not null"`
+	Restaurant   Restaurant `gorm:"foreignKey:RestaurantID;references:ID;OnDelete:CASCADE"`
+}
+
+func (w *Webhook) TableName() string {
+	return "Webhooks"
+}
+
+type WebhookDelivery struct {
+	BaseModel
+	WebhookID      uuid.UUID             `gorm:"column:WebhookID;type:char(36);not null;uniqueIndex:idx_webhook_delivery_event"`
+	EventID        uuid.UUID             `gorm:"column:EventID;type:char(36);not null;uniqueIndex:idx_webhook_delivery_event"`
+	Event          WebhookEvent          `gorm:"column:Event;type:varchar(50);not null"`
+	Payload        string                `gorm:"column:Payload;type:text;not null"`
+	Status         WebhookDeliveryStatus `gorm:"column:Status;type:enum('pending', 'succeeded', 'failed');not null;default:'pending';index:idx_webhook_delivery_retry"`
+	Attempts       int                   `gorm:"column:Attempts;not null;default:0"`
+	ResponseStatus sql.NullInt32         `gorm:"column:ResponseStatus"`
+	Error          sql.NullString        `gorm:"column:Error;type:varchar(1000)"`
+	NextAttemptAt  sql.NullTime          `gorm:"column:NextAttemptAt;index:idx_webhook_delivery_retry"`
+	DeliveredAt    sql.NullTime          `gorm:"column:DeliveredAt"`
+	Webhook        Webhook               `gorm:"foreignKey:WebhookID;references:ID;OnDelete:CASCADE"`
+}
+
+func (w *WebhookDelivery) TableName() string {
+	return "WebhookDeliveries"
+}
+
+// WebhookEventEnvelope is the JSON body sent to the webhook URL.
+type WebhookEventEnvelope struct {
+	ID        uuid.UUID    `json:"id"`
+	Event     WebhookEvent `json:"event"`
+	CreatedAt string       `json:"createdAt"`
+	Data      any          `json:"data"`
+}
+
+type WebhookDeliveryTask struct {
+	DeliveryID uuid.UUID `json:"deliveryId"`
+}
+
+// WebhookEventTask is published through the outbox when a domain event happens.
+// The webhook worker turns it into one delivery per subscribed webhook.
+type WebhookEventTask struct {
+	ID           uuid.UUID       `json:"id"`
+	RestaurantID uuid.UUID       `json:"restaurantId"`
+	Event        WebhookEvent    `json:"event"`
+	Data         json.RawMessage `json:"data"`
+}
+
+type OrderStatusChangedEventData struct {
+	OrderID uuid.UUID   `json:"orderId"`
+	Status  OrderStatus `json:"status"`
+}
+
+type CreateWebhookPayload struct {
+	URL    string         `json:"url" validate:"required,url,max=2048"`
+	Events []WebhookEvent `json:"events" validate:"required,min=1,dive,oneof=order.created order.status_changed evaluation.created menu.updated"`
+}
+
+type WebhookResponse struct {
+	ID        uuid.UUID      `json:"id"`
+	URL       string         `json:"url"`
+	Events    []WebhookEvent `json:"events"`
+	CreatedAt string         `json:"createdAt"`
+}
+
+type CreateWebhookResponse struct {
+	WebhookResponse
+	// Secret is only returned once and signs every delivery of this webhook.
+	Secret string `json:"secret"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             uuid.UUID             `json:"id"`
+	Event          WebhookEvent          `json:"event"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	ResponseStatus *int32                `json:"responseStatus,omitempty"`
+	Error          string                `json:"error,omitempty"`
+	NextAttemptAt  string                `json:"nextAttemptAt,omitempty"`
+	DeliveredAt    string                `json:"deliveredAt,omitempty"`
+	CreatedAt      string                `json:"createdAt"`
+}
+
+// Validate only checks what can be told from the URL itself. Host names are
+// resolved when the delivery is sent, and the address is checked again there.
+func (payload *CreateWebhookPayload) Validate() error {
+	webhookURL, err := url.Parse(payload.URL)
+	if err != nil || webhookURL.Scheme != "https" || webhookURL.Hostname() == "" {
+		return ErrWebhookURLNotAllowed
+	}
+
+	host := strings.ToLower(webhookURL.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrWebhookURLNotAllowed
+	}
+
+	if ip := net.ParseIP(host); ip != nil && !IsPublicIP(ip) {
+		return ErrWebhookURLNotAllowed
+	}
+
+	return nil
+}
+
+// IsPublicIP tells whether a webhook may be delivered to the address, keeping
+// deliveries away from the private network the API runs in.
+func IsPublicIP(ip net.IP) bool {
+	return !ip.IsPrivate() && !ip.IsLoopback() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified()
+}
+
+func (payload *CreateWebhookPayload) ToWebhook(restaurantID uuid.UUID, secret string) *Webhook {
+	ID, _ := uuid.NewV7()
+
+	events := make([]string, 0, len(payload.Events))
+	for _, event := range payload.Events {
+		if !slices.Contains(events, string(event)) {
+			events = append(events, string(event))
+		}
+	}
+
+	return &Webhook{
+		BaseModel: BaseModel{
+			ID: ID,
+		},
+		RestaurantID: restaurantID,
+		URL:          payload.URL,
+		Secret:       secret,
+		Events:       strings.Join(events, ","),
+	}
+}
+
+func (w *Webhook) EventList() []WebhookEvent {
+	var events []WebhookEvent
+	for _, event := range strings.Split(w.Events, ",") {
+		if event != "" {
+			events = append(events, WebhookEvent(event))
+		}
+	}
+
+	return events
+}
+
+func (w *Webhook) ToWebhookResponse() *WebhookResponse {
+	return &WebhookResponse{
+		ID:        w.ID,
+		URL:       w.URL,
+		Events:    w.EventList(),
+		CreatedAt: w.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func NewWebhookDelivery(webhookID, eventID uuid.UUID, event WebhookEvent, payload string) *WebhookDelivery {
+	ID, _ := uuid.NewV7()
+	return &WebhookDelivery{
+		BaseModel: BaseModel{
+			ID: ID,
+		},
+		WebhookID: webhookID,
+		EventID:   eventID,
+		Event:     event,
+		Payload:   payload,
+		Status:    PendingDelivery,
+	}
+}
+
+func (d *WebhookDelivery) ToWebhookDeliveryResponse() *WebhookDeliveryResponse {
+	response := &WebhookDeliveryResponse{
+		ID:        d.ID,
+		Event:     d.Event,
+		Status:    d.Status,
+		Attempts:  d.Attempts,
+		Error:     d.Error.String,
+		CreatedAt: d.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+
+	if d.ResponseStatus.Valid {
+		response.ResponseStatus = &d.ResponseStatus.Int32
+	}
+
+	if d.NextAttemptAt.Valid {
+		response.NextAttemptAt = d.NextAttemptAt.Time.Format("2006-01-02 15:04:05")
+	}
+
+	if d.DeliveredAt.Valid {
+		response.DeliveredAt = d.DeliveredAt.Time.Format("2006-01-02 15:04:05")
+	}
+
+	return response
+}
+
+type OrderCreatedEventData struct {
+	OrderID      uuid.UUID   `json:"orderId"`
+	Status       OrderStatus `json:"status"`
+	TotalInCents int         `json:"totalInCents"`
+	TipInCents   int         `json:"tipInCents"`
+	ScheduledFor *string     `json:"scheduledFor,omitempty"`
+}
+
+type MenuUpdatedEventData struct {
+	CreatedProducts   int         `json:"createdProducts"`
+	UpdatedProducts   int         `json:"updatedProducts"`
+	DeletedProductIDs []uuid.UUID `json:"deletedProductIds"`
+}
+
+func NewOrderCreatedEventData(order *Order) *OrderCreatedEventData {
+	data := &OrderCreatedEventData{
+		OrderID:      order.ID,
+		Status:       order.Status,
+		TotalInCents: order.TotalInCents,
+		TipInCents:   order.TipInCents,
+	}
+
+	if data.Status == "" {
+		data.Status = Pending
+	}
+
+	if order.ScheduledFor.Valid {
+		scheduledFor := order.ScheduledFor.Time.Format("2006-01-02 15:04:05")
+		data.ScheduledFor = &scheduledFor
+	}
+
+	return data
+}
+
+func NewWebhookEventTask(restaurantID uuid.UUID, event WebhookEvent, data any) (*WebhookEventTask, error) {
+	body, err := jsoniter.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	ID, _ := uuid.NewV7()
+	return &WebhookEventTask{
+		ID:           ID,
+		RestaurantID: restaurantID,
+		Event:        event,
+		Data:         body,
+	}, nil
+}
@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+//go:generate mockery --name=APIKeyRepository --output=../mocks --outpkg=mocks
+type APIKeyRepository interface {
+	CreateAPIKey(ctx context.Context, apiKey models.APIKey) error
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (*models.APIKey, error)
+	GetAPIKeysByRestaurantID(ctx context.Context, restaurantID uuid.UUID) ([]models.APIKey, error)
+	DeleteAPIKey(ctx context.Context, ID uuid.UUID, restaurantID uuid.UUID) (bool, error)
+	UpdateLastUsedAt(ctx context.Context, ID uuid.UUID, lastUsedAt time.Time) error
+}
+
+type apiKeyRepository struct {
+	di *internal.Di
+	DB *gorm.DB
+}
+
+func NewAPIKeyRepository(di *internal.Di) (APIKeyRepository, error) {
+	db, err := internal.Invoke[*gorm.DB](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiKeyRepository{
+		di: di,
+		DB: db,
+	}, nil
+}
+
+func (a *apiKeyRepository) CreateAPIKey(ctx context.Context, apiKey models.APIKey) error {
+	if err := a.DB.WithContext(ctx).Create(&apiKey).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a *apiKeyRepository) GetAPIKeyByPrefix(ctx context.Context, prefix string) (*models.APIKey, error) {
+	var apiKey models.APIKey
+	if err := a.DB.WithContext(ctx).
+		Preload("Restaurant").
+		Where("Prefix = ?", prefix).
+		First(&apiKey).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &apiKey, nil
+}
+
+func (a *apiKeyRepository) GetAPIKeysByRestaurantID(ctx context.Context, restaurantID uuid.UUID) ([]models.APIKey, error) {
+	var apiKeys []models.APIKey
+	if err := a.DB.WithContext(ctx).
+		Where("RestaurantID = ?", restaurantID).
+		Order("CreatedAt desc").
+		Find(&apiKeys).Error; err != nil {
+		return nil, err
+	}
+
+	return apiKeys, nil
+}
+
+func (a *apiKeyRepository) DeleteAPIKey(ctx context.Context, ID uuid.UUID, restaurantID uuid.UUID) (bool, error) {
+	result := a.DB.WithContext(ctx).
+		Where("Id = ? AND RestaurantID = ?", ID, restaurantID).
+		Delete(&models.APIKey{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (a *apiKeyRepository) UpdateLastUsedAt(ctx context.Context, ID uuid.UUID, lastUsedAt time.Time) error {
+	return a.DB.WithContext(ctx).
+		Model(&models.APIKey{}).
+		Where("Id = ?", ID).
+		UpdateColumn("LastUsedAt", lastUsedAt).
+		Error
+}
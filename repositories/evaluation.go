@@ -13,10 +13,11 @@ import (
 
 //go:generate mockery --name=EvaluationRepository --output=../mocks --outpkg=mocks
 type EvaluationRepository interface {
-	CreateEvaluation(ctx context.Context, evaluation models.Evaluation) error
+	CreateEvaluation(ctx context.Context, evaluation models.Evaluation, outbox []models.OutboxMessage) error
 	GetPaginatedEvaluationsByRestaurantID(ctx context.Context, restaurantID uuid.UUID, pagination *models.EvaluationPagination) (*models.PaginatedResponse[models.Evaluation], error)
 	UpdateAnswer(ctx context.Context, evaluationID uuid.UUID, answer string) error
 	GetEvaluationByID(ctx context.Context, evaluationID uuid.UUID) (*models.Evaluation, error)
+	DeleteEvaluation(ctx context.Context, evaluationID uuid.UUID) error
 	GetEvaluationSumaryByRestaurantID(ctx context.Context, restaurantID uuid.UUID) ([]models.EvaluationSummary, error)
 }
 
@@ -37,12 +38,14 @@ func NewEvaluationRepository(di *internal.Di) (EvaluationRepository, error) {
 	}, nil
 }
 
-func (e *evaluationRepository) CreateEvaluation(ctx context.Context, evaluation models.Evaluation) error {
-	if err := e.DB.WithContext(ctx).Create(&evaluation).Error; err != nil {
-		return err
-	}
+func (e *evaluationRepository) CreateEvaluation(ctx context.Context, evaluation models.Evaluation, outbox []models.OutboxMessage) error {
+	return e.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&evaluation).Error; err != nil {
+			return err
+		}
 
-	return nil
+		return createOutboxMessages(tx, outbox)
+	})
 }
 
 func (e *evaluationRepository) GetPaginatedEvaluationsByRestaurantID(ctx context.Context, restaurantID uuid.UUID, pagination *models.EvaluationPagination) (*models.PaginatedResponse[models.Evaluation], error) {
@@ -98,6 +101,16 @@ func (e *evaluationRepository) GetEvaluationByID(ctx context.Context, evaluation
 	return &evaluation, nil
 }
 
+func (e *evaluationRepository) DeleteEvaluation(ctx context.Context, evaluationID uuid.UUID) error {
+	if err := e.DB.WithContext(ctx).
+		Where("Id = ?", evaluationID).
+		Delete(&models.Evaluation{}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (e *evaluationRepository) GetEvaluationSumaryByRestaurantID(ctx context.Context, restaurantID uuid.UUID) ([]models.EvaluationSummary, error) {
 	var evaluationSummary []models.EvaluationSummary
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/G-Villarinho/food-shop-api/internal"
 	"github.com/G-Villarinho/food-shop-api/models"
@@ -13,11 +14,16 @@ import (
 
 //go:generate mockery --name=OrderRepository --output=../mocks --outpkg=mocks
 type OrderRepository interface {
-	CreateOrderWithItems(ctx context.Context, order *models.Order, items []models.OrderItem) error
+	CreateOrderWithItems(ctx context.Context, order *models.Order, items []models.OrderItem, outbox []models.OutboxMessage) error
 	GetPaginatedOrdersByRestaurantID(ctx context.Context, restaurantID uuid.UUID, pagination *models.OrderPagination) (*models.PaginatedResponse[models.Order], error)
-	UpdateStatus(ctx context.Context, orderID uuid.UUID, status models.OrderStatus) error
+	UpdateStatus(ctx context.Context, orderID uuid.UUID, status models.OrderStatus, outbox []models.OutboxMessage) error
 	GetOrderByID(ctx context.Context, orderID uuid.UUID, preload bool) (*models.Order, error)
 	GetOrderPerMonth(ctx context.Context, restaurantID uuid.UUID, orderStatus *models.OrderStatus) ([]models.OrderPerMonth, error)
+	GetTipsPerMonth(ctx context.Context, restaurantID uuid.UUID) ([]models.TipsPerMonth, error)
+	GetDueScheduledOrders(ctx context.Context, until time.Time) ([]models.Order, error)
+	ReleaseScheduledOrder(ctx context.Context, orderID uuid.UUID, outbox []models.OutboxMessage) (bool, error)
+	AssignCourier(ctx context.Context, orderID uuid.UUID, courierID uuid.UUID, outbox []models.OutboxMessage) error
+	GetPaginatedOrdersByCourierID(ctx context.Context, courierID uuid.UUID, pagination *models.DeliveryPagination) (*models.PaginatedResponse[models.Order], error)
 }
 
 type orderRepository struct {
@@ -37,7 +43,7 @@ func NewOrderRepository(di *internal.Di) (OrderRepository, error) {
 	}, nil
 }
 
-func (o *orderRepository) CreateOrderWithItems(ctx context.Context, order *models.Order, items []models.OrderItem) error {
+func (o *orderRepository) CreateOrderWithItems(ctx context.Context, order *models.Order, items []models.OrderItem, outbox []models.OutboxMessage) error {
 	return o.DB.Transaction(func(tx *gorm.DB) error {
 		if err := tx.WithContext(ctx).Create(order).Error; err != nil {
 			return fmt.Errorf("error to create order: %w", err)
@@ -51,6 +57,10 @@ func (o *orderRepository) CreateOrderWithItems(ctx context.Context, order *model
 			return fmt.Errorf("error to create order items: %w", err)
 		}
 
+		if err := createOutboxMessages(tx.WithContext(ctx), outbox); err != nil {
+			return fmt.Errorf("error to create outbox messages: %w", err)
+		}
+
 		return nil
 	})
 }
@@ -61,8 +71,12 @@ func (o *orderRepository) GetPaginatedOrdersByRestaurantID(ctx context.Context,
 		Preload("Custommer").
 		Where("RestaurantID = ?", restaurantID)
 
+	// Scheduled orders only reach the kitchen when they are released, so they
+	// are listed only when asked for.
 	if pagination.Status != nil {
 		query = query.Where("Orders.Status = ?", *pagination.Status)
+	} else {
+		query = query.Where("Orders.Status <> ?", models.Scheduled)
 	}
 
 	if pagination.OrderID != nil {
@@ -85,12 +99,17 @@ func (o *orderRepository) GetPaginatedOrdersByRestaurantID(ctx context.Context,
 	return orders, nil
 }
 
-func (o *orderRepository) UpdateStatus(ctx context.Context, orderID uuid.UUID, status models.OrderStatus) error {
-	return o.DB.WithContext(ctx).
-		Model(&models.Order{}).
-		Where("Id = ?", orderID).
-		Update("Status", status).
-		Error
+func (o *orderRepository) UpdateStatus(ctx context.Context, orderID uuid.UUID, status models.OrderStatus, outbox []models.OutboxMessage) error {
+	return o.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Order{}).
+			Where("Id = ?", orderID).
+			Update("Status", status).
+			Error; err != nil {
+			return err
+		}
+
+		return createOutboxMessages(tx, outbox)
+	})
 }
 
 func (o *orderRepository) GetOrderByID(ctx context.Context, orderID uuid.UUID, preload bool) (*models.Order, error) {
@@ -135,3 +154,102 @@ func (o *orderRepository) GetOrderPerMonth(ctx context.Context, restaurantID uui
 
 	return orderPerMonth, nil
 }
+
+func (o *orderRepository) GetTipsPerMonth(ctx context.Context, restaurantID uuid.UUID) ([]models.TipsPerMonth, error) {
+	var tipsPerMonth []models.TipsPerMonth
+	query := o.DB.WithContext(ctx).
+		Model(&models.Order{}).
+		Select("DATE_FORMAT(Orders.CreatedAt, '%Y-%m') as MonthWithYear, SUM(Orders.TipInCents) as TotalInCents, COUNT(Orders.ID) as Amount").
+		Where("Orders.RestaurantID = ?", restaurantID).
+		Where("Orders.Status = ?", models.Delivered).
+		Where("Orders.TipInCents > 0").
+		Group("MonthWithYear")
+
+	if err := query.Scan(&tipsPerMonth).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return tipsPerMonth, nil
+}
+
+func (o *orderRepository) GetDueScheduledOrders(ctx context.Context, until time.Time) ([]models.Order, error) {
+	var orders []models.Order
+	if err := o.DB.WithContext(ctx).
+		Where("Status = ?", models.Scheduled).
+		Where("ScheduledFor <= ?", until).
+		Order("ScheduledFor").
+		Find(&orders).Error; err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// ReleaseScheduledOrder moves the order to pending only if it is still
+// scheduled, so an order cancelled in the meantime is left alone and no event
+// is recorded for it.
+func (o *orderRepository) ReleaseScheduledOrder(ctx context.Context, orderID uuid.UUID, outbox []models.OutboxMessage) (bool, error) {
+	released := false
+	err := o.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Order{}).
+			Where("Id = ?", orderID).
+			Where("Status = ?", models.Scheduled).
+			Update("Status", models.Pending)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		released = true
+		return createOutboxMessages(tx, outbox)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return released, nil
+}
+
+func (o *orderRepository) AssignCourier(ctx context.Context, orderID uuid.UUID, courierID uuid.UUID, outbox []models.OutboxMessage) error {
+	return o.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Order{}).
+			Where("Id = ?", orderID).
+			Updates(map[string]any{
+				"Status":    models.Delivering,
+				"CourierID": courierID,
+			}).
+			Error; err != nil {
+			return err
+		}
+
+		return createOutboxMessages(tx, outbox)
+	})
+}
+
+func (o *orderRepository) GetPaginatedOrdersByCourierID(ctx context.Context, courierID uuid.UUID, pagination *models.DeliveryPagination) (*models.PaginatedResponse[models.Order], error) {
+	query := o.DB.WithContext(ctx).
+		Model(&models.Order{}).
+		Preload("Custommer").
+		Preload("Restaurant").
+		Where("CourierID = ?", courierID)
+
+	if pagination.Status != nil {
+		query = query.Where("Orders.Status = ?", *pagination.Status)
+	}
+
+	orders, err := paginate[models.Order](query, &pagination.Pagination, &models.Order{})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return orders, nil
+}
@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockery --name=OutboxRepository --output=../mocks --outpkg=mocks
+type OutboxRepository interface {
+	CreateMessages(ctx context.Context, messages []models.OutboxMessage) error
+	RelayPendingMessages(ctx context.Context, limit int, maxAttempts int, publish func(message models.OutboxMessage) error) (int, error)
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type outboxRepository struct {
+	di *internal.Di
+	DB *gorm.DB
+}
+
+func NewOutboxRepository(di *internal.Di) (OutboxRepository, error) {
+	db, err := internal.Invoke[*gorm.DB](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &outboxRepository{
+		di: di,
+		DB: db,
+	}, nil
+}
+
+func (o *outboxRepository) CreateMessages(ctx context.Context, messages []models.OutboxMessage) error {
+	return createOutboxMessages(o.DB.WithContext(ctx), messages)
+}
+
+// RelayPendingMessages locks a batch of pending messages and hands them to
+// publish in the order they were written. Rows locked by another relay are
+// skipped, so running more than one relay never publishes a message twice at
+// the same time. The batch stops at the first failure, which is recorded on
+// the message and returned; messages that failed maxAttempts times are parked
+// and no longer picked up.
+func (o *outboxRepository) RelayPendingMessages(ctx context.Context, limit int, maxAttempts int, publish func(message models.OutboxMessage) error) (int, error) {
+	relayed := 0
+	var publishErr error
+
+	err := o.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var messages []models.OutboxMessage
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("PublishedAt IS NULL").
+			Where("Attempts < ?", maxAttempts).
+			Order("CreatedAt asc").
+			Limit(limit).
+			Find(&messages).Error; err != nil {
+			return err
+		}
+
+		for _, message := range messages {
+			if publishErr = publish(message); publishErr != nil {
+				reason := publishErr.Error()
+				if len(reason) > 1000 {
+					reason = reason[:1000]
+				}
+
+				return tx.Model(&models.OutboxMessage{}).
+					Where("Id = ?", message.ID).
+					UpdateColumns(map[string]any{
+						"Attempts":  gorm.Expr("Attempts + 1"),
+						"LastError": sql.NullString{String: reason, Valid: true},
+					}).
+					Error
+			}
+
+			if err := tx.Model(&models.OutboxMessage{}).
+				Where("Id = ?", message.ID).
+				UpdateColumn("PublishedAt", time.Now().UTC()).
+				Error; err != nil {
+				return err
+			}
+
+			relayed++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return relayed, publishErr
+}
+
+func (o *outboxRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := o.DB.WithContext(ctx).
+		Unscoped().
+		Where("PublishedAt IS NOT NULL AND PublishedAt < ?", before).
+		Delete(&models.OutboxMessage{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+// createOutboxMessages stores the messages with the given connection, so
+// repositories can write them inside their own transaction.
+func createOutboxMessages(db *gorm.DB, messages []models.OutboxMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	return db.Create(&messages).Error
+}
@@ -15,8 +15,9 @@ import (
 type RestaurantRepository interface {
 	CreateRestaurant(ctx context.Context, restaurant models.Restaurant) error
 	GetRestaurantByID(ctx context.Context, ID uuid.UUID) (*models.Restaurant, error)
-	GetRestaurantIDByUserID(ctx context.Context, userID uuid.UUID) (*uuid.UUID, error)
 	GetRestaurantByUserID(ctx context.Context, userID uuid.UUID) (*models.Restaurant, error)
+	GetPaginatedRestaurants(ctx context.Context, pagination *models.RestaurantPagination) (*models.PaginatedResponse[models.Restaurant], error)
+	UpdateStatus(ctx context.Context, restaurantID uuid.UUID, status models.RestaurantStatus) error
 }
 
 type restaurantRepository struct {
@@ -56,38 +57,47 @@ func (r *restaurantRepository) GetRestaurantByID(ctx context.Context, ID uuid.UU
 	return &restaurant, nil
 }
 
-func (r *restaurantRepository) GetRestaurantIDByUserID(ctx context.Context, userID uuid.UUID) (*uuid.UUID, error) {
-	var restaurantIDStr string
+func (r *restaurantRepository) GetRestaurantByUserID(ctx context.Context, userID uuid.UUID) (*models.Restaurant, error) {
+	var restaurant models.Restaurant
 	if err := r.DB.
 		WithContext(ctx).
-		Table("Restaurants").
-		Select("Id").
-		Where("ManagerID = ?", userID).
-		Scan(&restaurantIDStr).Error; err != nil {
+		Joins("JOIN RestaurantMembers ON RestaurantMembers.RestaurantID = Restaurants.Id").
+		Where("RestaurantMembers.UserID = ?", userID).
+		First(&restaurant).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
 		return nil, err
 	}
 
-	restaurantID, err := uuid.Parse(restaurantIDStr)
-	if err != nil {
-		return nil, fmt.Errorf("error to parse restaurant ID: %w", err)
-	}
-	return &restaurantID, nil
+	return &restaurant, nil
 }
 
-func (r *restaurantRepository) GetRestaurantByUserID(ctx context.Context, userID uuid.UUID) (*models.Restaurant, error) {
-	var restaurant models.Restaurant
-	if err := r.DB.
-		WithContext(ctx).
-		Where("ManagerID = ?", userID).
-		First(&restaurant).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil
-		}
+func (r *restaurantRepository) GetPaginatedRestaurants(ctx context.Context, pagination *models.RestaurantPagination) (*models.PaginatedResponse[models.Restaurant], error) {
+	query := r.DB.WithContext(ctx).
+		Model(&models.Restaurant{}).
+		Preload("Manager")
+
+	if pagination.Search != nil {
+		query = query.Where("Name LIKE ?", fmt.Sprintf("%%%s%%", *pagination.Search))
+	}
+
+	if pagination.Status != nil {
+		query = query.Where("Status = ?", *pagination.Status)
+	}
+
+	restaurants, err := paginate[models.Restaurant](query, &pagination.Pagination, &models.Restaurant{})
+	if err != nil {
 		return nil, err
 	}
 
-	return &restaurant, nil
+	return restaurants, nil
+}
+
+func (r *restaurantRepository) UpdateStatus(ctx context.Context, restaurantID uuid.UUID, status models.RestaurantStatus) error {
+	return r.DB.WithContext(ctx).
+		Model(&models.Restaurant{}).
+		Where("Id = ?", restaurantID).
+		Update("Status", status).
+		Error
 }
@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+//go:generate mockery --name=RestaurantMemberRepository --output=../mocks --outpkg=mocks
+type RestaurantMemberRepository interface {
+	CreateMember(ctx context.Context, member models.RestaurantMember) error
+	GetMemberByUserID(ctx context.Context, userID uuid.UUID) (*models.RestaurantMember, error)
+	GetMembersByRestaurantID(ctx context.Context, restaurantID uuid.UUID) ([]models.RestaurantMember, error)
+	DeleteMember(ctx context.Context, memberID uuid.UUID) error
+}
+
+type restaurantMemberRepository struct {
+	di *internal.Di
+	DB *gorm.DB
+}
+
+func NewRestaurantMemberRepository(di *internal.Di) (RestaurantMemberRepository, error) {
+	db, err := internal.Invoke[*gorm.DB](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &restaurantMemberRepository{
+		di: di,
+		DB: db,
+	}, nil
+}
+
+func (r *restaurantMemberRepository) CreateMember(ctx context.Context, member models.RestaurantMember) error {
+	if err := r.DB.WithContext(ctx).Create(&member).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *restaurantMemberRepository) GetMemberByUserID(ctx context.Context, userID uuid.UUID) (*models.RestaurantMember, error) {
+	var member models.RestaurantMember
+	if err := r.DB.WithContext(ctx).
+		Where("UserID = ?", userID).
+		First(&member).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+func (r *restaurantMemberRepository) GetMembersByRestaurantID(ctx context.Context, restaurantID uuid.UUID) ([]models.RestaurantMember, error) {
+	var members []models.RestaurantMember
+	if err := r.DB.WithContext(ctx).
+		Preload("User").
+		Where("RestaurantID = ?", restaurantID).
+		Order("CreatedAt asc").
+		Find(&members).Error; err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (r *restaurantMemberRepository) DeleteMember(ctx context.Context, memberID uuid.UUID) error {
+	return r.DB.WithContext(ctx).
+		Unscoped().
+		Where("Id = ?", memberID).
+		Delete(&models.RestaurantMember{}).
+		Error
+}
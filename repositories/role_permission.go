@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+//go:generate mockery --name=RolePermissionRepository --output=../mocks --outpkg=mocks
+type RolePermissionRepository interface {
+	GetPermissionsByRole(ctx context.Context, scope models.RoleScope, role string) ([]models.Permission, error)
+	GetRolePermissions(ctx context.Context) ([]models.RolePermission, error)
+	ReplaceRolePermissions(ctx context.Context, scope models.RoleScope, role string, rolePermissions []models.RolePermission) error
+	GetRestaurantRolePermissions(ctx context.Context, restaurantID uuid.UUID) ([]models.RestaurantRolePermission, error)
+	ReplaceRestaurantRolePermissions(ctx context.Context, restaurantID uuid.UUID, role models.MemberRole, rolePermissions []models.RestaurantRolePermission) error
+}
+
+type rolePermissionRepository struct {
+	di *internal.Di
+	DB *gorm.DB
+}
+
+func NewRolePermissionRepository(di *internal.Di) (RolePermissionRepository, error) {
+	db, err := internal.Invoke[*gorm.DB](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rolePermissionRepository{
+		di: di,
+		DB: db,
+	}, nil
+}
+
+func (r *rolePermissionRepository) GetPermissionsByRole(ctx context.Context, scope models.RoleScope, role string) ([]models.Permission, error) {
+	var permissions []models.Permission
+	if err := r.DB.WithContext(ctx).
+		Model(&models.RolePermission{}).
+		Where("Scope = ? AND Role = ?", scope, role).
+		Pluck("Permission", &permissions).Error; err != nil {
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+func (r *rolePermissionRepository) GetRolePermissions(ctx context.Context) ([]models.RolePermission, error) {
+	var rolePermissions []models.RolePermission
+	if err := r.DB.WithContext(ctx).
+		Order("Scope, Role, Permission").
+		Find(&rolePermissions).Error; err != nil {
+		return nil, err
+	}
+
+	return rolePermissions, nil
+}
+
+func (r *rolePermissionRepository) ReplaceRolePermissions(ctx context.Context, scope models.RoleScope, role string, rolePermissions []models.RolePermission) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().
+			Where("Scope = ? AND Role = ?", scope, role).
+			Delete(&models.RolePermission{}).Error; err != nil {
+			return err
+		}
+
+		if len(rolePermissions) == 0 {
+			return nil
+		}
+
+		return tx.Create(&rolePermissions).Error
+	})
+}
+
+func (r *rolePermissionRepository) GetRestaurantRolePermissions(ctx context.Context, restaurantID uuid.UUID) ([]models.RestaurantRolePermission, error) {
+	var rolePermissions []models.RestaurantRolePermission
+	if err := r.DB.WithContext(ctx).
+		Where("RestaurantID = ?", restaurantID).
+		Order("Role, Permission").
+		Find(&rolePermissions).Error; err != nil {
+		return nil, err
+	}
+
+	return rolePermissions, nil
+}
+
+func (r *rolePermissionRepository) ReplaceRestaurantRolePermissions(ctx context.Context, restaurantID uuid.UUID, role models.MemberRole, rolePermissions []models.RestaurantRolePermission) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().
+			Where("RestaurantID = ? AND Role = ?", restaurantID, role).
+			Delete(&models.RestaurantRolePermission{}).Error; err != nil {
+			return err
+		}
+
+		if len(rolePermissions) == 0 {
+			return nil
+		}
+
+		return tx.Create(&rolePermissions).Error
+	})
+}
@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/G-Villarinho/food-shop-api/internal"
 	"github.com/G-Villarinho/food-shop-api/models"
@@ -15,6 +16,8 @@ type UserRepository interface {
 	CreateUser(ctx context.Context, user models.User) error
 	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
 	GetUserByID(ctx context.Context, ID uuid.UUID) (*models.User, error)
+	GetPaginatedUsers(ctx context.Context, pagination *models.UserPagination) (*models.PaginatedResponse[models.User], error)
+	UpdateStatus(ctx context.Context, userID uuid.UUID, status models.Status) error
 }
 
 type userRepository struct {
@@ -65,3 +68,36 @@ func (u *userRepository) GetUserByID(ctx context.Context, ID uuid.UUID) (*models
 
 	return user, nil
 }
+
+func (u *userRepository) GetPaginatedUsers(ctx context.Context, pagination *models.UserPagination) (*models.PaginatedResponse[models.User], error) {
+	query := u.DB.WithContext(ctx).
+		Model(&models.User{})
+
+	if pagination.Search != nil {
+		search := fmt.Sprintf("%%%s%%", *pagination.Search)
+		query = query.Where("FullName LIKE ? OR Email LIKE ?", search, search)
+	}
+
+	if pagination.Role != nil {
+		query = query.Where("Role = ?", *pagination.Role)
+	}
+
+	if pagination.Status != nil {
+		query = query.Where("Status = ?", *pagination.Status)
+	}
+
+	users, err := paginate[models.User](query, &pagination.Pagination, &models.User{})
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (u *userRepository) UpdateStatus(ctx context.Context, userID uuid.UUID, status models.Status) error {
+	return u.DB.WithContext(ctx).
+		Model(&models.User{}).
+		Where("Id = ?", userID).
+		Update("Status", status).
+		Error
+}
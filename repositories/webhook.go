@@ -0,0 +1,161 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockery --name=WebhookRepository --output=../mocks --outpkg=mocks
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, webhook models.Webhook) error
+	GetWebhookByID(ctx context.Context, ID uuid.UUID, restaurantID uuid.UUID) (*models.Webhook, error)
+	GetWebhooksByRestaurantID(ctx context.Context, restaurantID uuid.UUID) ([]models.Webhook, error)
+	DeleteWebhook(ctx context.Context, ID uuid.UUID, restaurantID uuid.UUID) (bool, error)
+	CreateDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error
+	GetDeliveryByID(ctx context.Context, ID uuid.UUID) (*models.WebhookDelivery, error)
+	GetPaginatedDeliveriesByWebhookID(ctx context.Context, webhookID uuid.UUID, pagination *models.Pagination) (*models.PaginatedResponse[models.WebhookDelivery], error)
+	GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery models.WebhookDelivery) error
+}
+
+type webhookRepository struct {
+	di *internal.Di
+	DB *gorm.DB
+}
+
+func NewWebhookRepository(di *internal.Di) (WebhookRepository, error) {
+	db, err := internal.Invoke[*gorm.DB](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhookRepository{
+		di: di,
+		DB: db,
+	}, nil
+}
+
+func (w *webhookRepository) CreateWebhook(ctx context.Context, webhook models.Webhook) error {
+	if err := w.DB.WithContext(ctx).Create(&webhook).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (w *webhookRepository) GetWebhookByID(ctx context.Context, ID uuid.UUID, restaurantID uuid.UUID) (*models.Webhook, error) {
+	var webhook models.Webhook
+	if err := w.DB.WithContext(ctx).
+		Where("Id = ? AND RestaurantID = ?", ID, restaurantID).
+		First(&webhook).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+func (w *webhookRepository) GetWebhooksByRestaurantID(ctx context.Context, restaurantID uuid.UUID) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	if err := w.DB.WithContext(ctx).
+		Where("RestaurantID = ?", restaurantID).
+		Order("CreatedAt desc").
+		Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (w *webhookRepository) DeleteWebhook(ctx context.Context, ID uuid.UUID, restaurantID uuid.UUID) (bool, error) {
+	result := w.DB.WithContext(ctx).
+		Where("Id = ? AND RestaurantID = ?", ID, restaurantID).
+		Delete(&models.Webhook{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// CreateDeliveries skips deliveries already recorded for the same webhook and
+// event, so an event relayed twice by the outbox is only delivered once.
+func (w *webhookRepository) CreateDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	if err := w.DB.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&deliveries).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (w *webhookRepository) GetDeliveryByID(ctx context.Context, ID uuid.UUID) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := w.DB.WithContext(ctx).
+		Preload("Webhook").
+		Where("Id = ?", ID).
+		First(&delivery).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+func (w *webhookRepository) GetPaginatedDeliveriesByWebhookID(ctx context.Context, webhookID uuid.UUID, pagination *models.Pagination) (*models.PaginatedResponse[models.WebhookDelivery], error) {
+	query := w.DB.WithContext(ctx).
+		Model(&models.WebhookDelivery{}).
+		Where("WebhookID = ?", webhookID)
+
+	deliveries, err := paginate[models.WebhookDelivery](query, pagination, &models.WebhookDelivery{})
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (w *webhookRepository) GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	if err := w.DB.WithContext(ctx).
+		Where("Status = ? AND NextAttemptAt <= ?", models.PendingDelivery, now).
+		Order("NextAttemptAt asc").
+		Limit(limit).
+		Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (w *webhookRepository) UpdateDelivery(ctx context.Context, delivery models.WebhookDelivery) error {
+	if err := w.DB.WithContext(ctx).
+		Model(&models.WebhookDelivery{}).
+		Where("Id = ?", delivery.ID).
+		Updates(map[string]any{
+			"Status":         delivery.Status,
+			"Attempts":       delivery.Attempts,
+			"ResponseStatus": delivery.ResponseStatus,
+			"Error":          delivery.Error,
+			"NextAttemptAt":  delivery.NextAttemptAt,
+			"DeliveredAt":    delivery.DeliveredAt,
+		}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/cache"
+	"github.com/G-Villarinho/food-shop-api/config"
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/G-Villarinho/food-shop-api/repositories"
+	"github.com/google/uuid"
+)
+
+//go:generate mockery --name=AdminService --output=../mocks --outpkg=mocks
+type AdminService interface {
+	GetPaginatedUsers(ctx context.Context, pagination *models.UserPagination) (*models.PaginatedResponse[*models.AdminUserResponse], error)
+	BlockUser(ctx context.Context, userID uuid.UUID) error
+	UnblockUser(ctx context.Context, userID uuid.UUID) error
+	ImpersonateUser(ctx context.Context, userID uuid.UUID) (string, error)
+	GetPaginatedRestaurants(ctx context.Context, pagination *models.RestaurantPagination) (*models.PaginatedResponse[*models.RestaurantResponse], error)
+	SuspendRestaurant(ctx context.Context, restaurantID uuid.UUID) error
+	ReactivateRestaurant(ctx context.Context, restaurantID uuid.UUID) error
+	GetOrder(ctx context.Context, orderID uuid.UUID) (*models.OrderResponse, error)
+	DeleteEvaluation(ctx context.Context, evaluationID uuid.UUID) error
+}
+
+type adminService struct {
+	di                         *internal.Di
+	cacheService               cache.CacheService
+	sessionService             SessionService
+	evaluationRepository       repositories.EvaluationRepository
+	orderRepository            repositories.OrderRepository
+	restaurantRepository       repositories.RestaurantRepository
+	restaurantMemberRepository repositories.RestaurantMemberRepository
+	userRepository             repositories.UserRepository
+}
+
+func NewAdminService(di *internal.Di) (AdminService, error) {
+	cacheService, err := internal.Invoke[cache.CacheService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionService, err := internal.Invoke[SessionService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluationRepository, err := internal.Invoke[repositories.EvaluationRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	orderRepository, err := internal.Invoke[repositories.OrderRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	restaurantRepository, err := internal.Invoke[repositories.RestaurantRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	restaurantMemberRepository, err := internal.Invoke[repositories.RestaurantMemberRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	userRepository, err := internal.Invoke[repositories.UserRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &adminService{
+		di:                         di,
+		cacheService:               cacheService,
+		sessionService:             sessionService,
+		evaluationRepository:       evaluationRepository,
+		orderRepository:            orderRepository,
+		restaurantRepository:       restaurantRepository,
+		restaurantMemberRepository: restaurantMemberRepository,
+		userRepository:             userRepository,
+	}, nil
+}
+
+func (a *adminService) GetPaginatedUsers(ctx context.Context, pagination *models.UserPagination) (*models.PaginatedResponse[*models.AdminUserResponse], error) {
+	paginatedUsers, err := a.userRepository.GetPaginatedUsers(ctx, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("get paginated users: %w", err)
+	}
+
+	return models.MapPaginatedResult(paginatedUsers, func(user models.User) *models.AdminUserResponse {
+		return user.ToAdminUserResponse()
+	}), nil
+}
+
+func (a *adminService) BlockUser(ctx context.Context, userID uuid.UUID) error {
+	if err := a.updateUserStatus(ctx, userID, models.Blocked); err != nil {
+		return err
+	}
+
+	ttl := time.Duration(config.Env.Cache.SessionExp) * time.Hour
+	if err := a.cacheService.Set(ctx, getBlockedUserKey(userID), true, ttl); err != nil {
+		return fmt.Errorf("set blocked user: %w", err)
+	}
+
+	if err := a.sessionService.DeleteAllSessions(ctx, userID); err != nil && !errors.Is(err, models.ErrSessionNotFound) {
+		return fmt.Errorf("delete all sessions: %w", err)
+	}
+
+	return nil
+}
+
+func (a *adminService) UnblockUser(ctx context.Context, userID uuid.UUID) error {
+	if err := a.updateUserStatus(ctx, userID, models.Active); err != nil {
+		return err
+	}
+
+	if err := a.cacheService.Delete(ctx, getBlockedUserKey(userID)); err != nil {
+		return fmt.Errorf("delete blocked user: %w", err)
+	}
+
+	return nil
+}
+
+func (a *adminService) ImpersonateUser(ctx context.Context, userID uuid.UUID) (string, error) {
+	adminID, ok := ctx.Value(internal.UserIDKey).(uuid.UUID)
+	if !ok {
+		return "", models.ErrUserNotFoundInContext
+	}
+
+	user, err := a.userRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("get user by id: %w", err)
+	}
+
+	if user == nil {
+		return "", models.ErrUserNotFound
+	}
+
+	if user.Role == models.Admin {
+		return "", models.ErrCannotImpersonateUser
+	}
+
+	if user.Status == models.Blocked {
+		return "", models.ErrUserBlocked
+	}
+
+	restaurantID, memberRole, err := getSessionMembership(ctx, a.restaurantMemberRepository, user)
+	if err != nil {
+		return "", err
+	}
+
+	session, err := a.sessionService.CreateImpersonationSession(ctx, user.ID, restaurantID, user.Role, memberRole, adminID)
+	if err != nil {
+		return "", fmt.Errorf("create impersonation session: %w", err)
+	}
+
+	return session.Token, nil
+}
+
+func (a *adminService) GetPaginatedRestaurants(ctx context.Context, pagination *models.RestaurantPagination) (*models.PaginatedResponse[*models.RestaurantResponse], error) {
+	paginatedRestaurants, err := a.restaurantRepository.GetPaginatedRestaurants(ctx, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("get paginated restaurants: %w", err)
+	}
+
+	return models.MapPaginatedResult(paginatedRestaurants, func(restaurant models.Restaurant) *models.RestaurantResponse {
+		return restaurant.ToRestaurantResponse()
+	}), nil
+}
+
+func (a *adminService) SuspendRestaurant(ctx context.Context, restaurantID uuid.UUID) error {
+	return a.updateRestaurantStatus(ctx, restaurantID, models.SuspendedRestaurant)
+}
+
+func (a *adminService) ReactivateRestaurant(ctx context.Context, restaurantID uuid.UUID) error {
+	return a.updateRestaurantStatus(ctx, restaurantID, models.ActiveRestaurant)
+}
+
+func (a *adminService) GetOrder(ctx context.Context, orderID uuid.UUID) (*models.OrderResponse, error) {
+	order, err := a.orderRepository.GetOrderByID(ctx, orderID, true)
+	if err != nil {
+		return nil, fmt.Errorf("get order by id: %w", err)
+	}
+
+	if order == nil {
+		return nil, models.ErrorOrderNotFound
+	}
+
+	return order.ToOrderResponse(), nil
+}
+
+func (a *adminService) DeleteEvaluation(ctx context.Context, evaluationID uuid.UUID) error {
+	evaluation, err := a.evaluationRepository.GetEvaluationByID(ctx, evaluationID)
+	if err != nil {
+		return fmt.Errorf("get evaluation by id: %w", err)
+	}
+
+	if evaluation == nil {
+		return models.ErrEvaluationNotFound
+	}
+
+	if err := a.evaluationRepository.DeleteEvaluation(ctx, evaluationID); err != nil {
+		return fmt.Errorf("delete evaluation: %w", err)
+	}
+
+	return nil
+}
+
+func (a *adminService) updateUserStatus(ctx context.Context, userID uuid.UUID, status models.Status) error {
+	user, err := a.userRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get user by id: %w", err)
+	}
+
+	if user == nil {
+		return models.ErrUserNotFound
+	}
+
+	if user.Role == models.Admin {
+		return models.ErrCannotBlockUser
+	}
+
+	if err := a.userRepository.UpdateStatus(ctx, userID, status); err != nil {
+		return fmt.Errorf("update user status: %w", err)
+	}
+
+	if err := a.cacheService.Delete(ctx, getUserKey(userID)); err != nil {
+		return fmt.Errorf("delete user from cache: %w", err)
+	}
+
+	return nil
+}
+
+func (a *adminService) updateRestaurantStatus(ctx context.Context, restaurantID uuid.UUID, status models.RestaurantStatus) error {
+	restaurant, err := a.restaurantRepository.GetRestaurantByID(ctx, restaurantID)
+	if err != nil {
+		return fmt.Errorf("get restaurant by id: %w", err)
+	}
+
+	if restaurant == nil {
+		return models.ErrRestaurantNotFound
+	}
+
+	if err := a.restaurantRepository.UpdateStatus(ctx, restaurantID, status); err != nil {
+		return fmt.Errorf("update restaurant status: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,276 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/mocks"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAdminService_BlockUser(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("should block user and revoke sessions", func(t *testing.T) {
+		userRepository := &mocks.UserRepository{}
+		cacheService := &mocks.CacheService{}
+		sessionService := &mocks.SessionService{}
+
+		service := &adminService{
+			userRepository: userRepository,
+			cacheService:   cacheService,
+			sessionService: sessionService,
+		}
+
+		userRepository.On("GetUserByID", ctx, userID).Return(&models.User{BaseModel: models.BaseModel{ID: userID}, Role: models.Customer}, nil)
+		userRepository.On("UpdateStatus", ctx, userID, models.Blocked).Return(nil)
+		cacheService.On("Delete", ctx, getUserKey(userID)).Return(nil)
+		cacheService.On("Set", ctx, getBlockedUserKey(userID), true, mock.Anything).Return(nil)
+		sessionService.On("DeleteAllSessions", ctx, userID).Return(nil)
+
+		err := service.BlockUser(ctx, userID)
+
+		assert.NoError(t, err)
+		userRepository.AssertExpectations(t)
+		cacheService.AssertExpectations(t)
+		sessionService.AssertExpectations(t)
+	})
+
+	t.Run("should block user without active sessions", func(t *testing.T) {
+		userRepository := &mocks.UserRepository{}
+		cacheService := &mocks.CacheService{}
+		sessionService := &mocks.SessionService{}
+
+		service := &adminService{
+			userRepository: userRepository,
+			cacheService:   cacheService,
+			sessionService: sessionService,
+		}
+
+		userRepository.On("GetUserByID", ctx, userID).Return(&models.User{BaseModel: models.BaseModel{ID: userID}, Role: models.Customer}, nil)
+		userRepository.On("UpdateStatus", ctx, userID, models.Blocked).Return(nil)
+		cacheService.On("Delete", ctx, getUserKey(userID)).Return(nil)
+		cacheService.On("Set", ctx, getBlockedUserKey(userID), true, mock.Anything).Return(nil)
+		sessionService.On("DeleteAllSessions", ctx, userID).Return(models.ErrSessionNotFound)
+
+		err := service.BlockUser(ctx, userID)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error when user is not found", func(t *testing.T) {
+		userRepository := &mocks.UserRepository{}
+
+		service := &adminService{
+			userRepository: userRepository,
+		}
+
+		userRepository.On("GetUserByID", ctx, userID).Return(nil, nil)
+
+		err := service.BlockUser(ctx, userID)
+
+		assert.ErrorIs(t, err, models.ErrUserNotFound)
+		userRepository.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should not block an admin", func(t *testing.T) {
+		userRepository := &mocks.UserRepository{}
+
+		service := &adminService{
+			userRepository: userRepository,
+		}
+
+		userRepository.On("GetUserByID", ctx, userID).Return(&models.User{BaseModel: models.BaseModel{ID: userID}, Role: models.Admin}, nil)
+
+		err := service.BlockUser(ctx, userID)
+
+		assert.ErrorIs(t, err, models.ErrCannotBlockUser)
+		userRepository.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAdminService_UnblockUser(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("should unblock user and clear blocked marker", func(t *testing.T) {
+		userRepository := &mocks.UserRepository{}
+		cacheService := &mocks.CacheService{}
+
+		service := &adminService{
+			userRepository: userRepository,
+			cacheService:   cacheService,
+		}
+
+		userRepository.On("GetUserByID", ctx, userID).Return(&models.User{BaseModel: models.BaseModel{ID: userID}, Role: models.Customer, Status: models.Blocked}, nil)
+		userRepository.On("UpdateStatus", ctx, userID, models.Active).Return(nil)
+		cacheService.On("Delete", ctx, getUserKey(userID)).Return(nil)
+		cacheService.On("Delete", ctx, getBlockedUserKey(userID)).Return(nil)
+
+		err := service.UnblockUser(ctx, userID)
+
+		assert.NoError(t, err)
+		userRepository.AssertExpectations(t)
+		cacheService.AssertExpectations(t)
+	})
+}
+
+func TestAdminService_ImpersonateUser(t *testing.T) {
+	adminID := uuid.New()
+	ctx := context.WithValue(context.Background(), internal.UserIDKey, adminID)
+
+	t.Run("should create a session recording the admin", func(t *testing.T) {
+		userRepository := &mocks.UserRepository{}
+		restaurantMemberRepository := &mocks.RestaurantMemberRepository{}
+		sessionService := &mocks.SessionService{}
+
+		service := &adminService{
+			userRepository:             userRepository,
+			restaurantMemberRepository: restaurantMemberRepository,
+			sessionService:             sessionService,
+		}
+
+		userID := uuid.New()
+		restaurantID := uuid.New()
+		member := &models.RestaurantMember{RestaurantID: restaurantID, UserID: userID, Role: models.KitchenMember}
+
+		userRepository.On("GetUserByID", ctx, userID).Return(&models.User{BaseModel: models.BaseModel{ID: userID}, Role: models.Manager}, nil)
+		restaurantMemberRepository.On("GetMemberByUserID", ctx, userID).Return(member, nil)
+		sessionService.On("CreateImpersonationSession", ctx, userID, &member.RestaurantID, models.Manager, &member.Role, adminID).Return(&models.Session{Token: "token"}, nil)
+
+		token, err := service.ImpersonateUser(ctx, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "token", token)
+		sessionService.AssertExpectations(t)
+	})
+
+	t.Run("should not impersonate another admin", func(t *testing.T) {
+		userRepository := &mocks.UserRepository{}
+		sessionService := &mocks.SessionService{}
+
+		service := &adminService{
+			userRepository: userRepository,
+			sessionService: sessionService,
+		}
+
+		userID := uuid.New()
+
+		userRepository.On("GetUserByID", ctx, userID).Return(&models.User{BaseModel: models.BaseModel{ID: userID}, Role: models.Admin}, nil)
+
+		token, err := service.ImpersonateUser(ctx, userID)
+
+		assert.ErrorIs(t, err, models.ErrCannotImpersonateUser)
+		assert.Empty(t, token)
+		sessionService.AssertNotCalled(t, "CreateImpersonationSession", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should not impersonate a blocked user", func(t *testing.T) {
+		userRepository := &mocks.UserRepository{}
+		sessionService := &mocks.SessionService{}
+
+		service := &adminService{
+			userRepository: userRepository,
+			sessionService: sessionService,
+		}
+
+		userID := uuid.New()
+
+		userRepository.On("GetUserByID", ctx, userID).Return(&models.User{BaseModel: models.BaseModel{ID: userID}, Role: models.Customer, Status: models.Blocked}, nil)
+
+		token, err := service.ImpersonateUser(ctx, userID)
+
+		assert.ErrorIs(t, err, models.ErrUserBlocked)
+		assert.Empty(t, token)
+		sessionService.AssertNotCalled(t, "CreateImpersonationSession", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should not impersonate a manager without a restaurant", func(t *testing.T) {
+		userRepository := &mocks.UserRepository{}
+		restaurantMemberRepository := &mocks.RestaurantMemberRepository{}
+		sessionService := &mocks.SessionService{}
+
+		service := &adminService{
+			userRepository:             userRepository,
+			restaurantMemberRepository: restaurantMemberRepository,
+			sessionService:             sessionService,
+		}
+
+		userID := uuid.New()
+
+		userRepository.On("GetUserByID", ctx, userID).Return(&models.User{BaseModel: models.BaseModel{ID: userID}, Role: models.Manager}, nil)
+		restaurantMemberRepository.On("GetMemberByUserID", ctx, userID).Return(nil, nil)
+
+		token, err := service.ImpersonateUser(ctx, userID)
+
+		assert.ErrorIs(t, err, models.ErrRestaurantNotFound)
+		assert.Empty(t, token)
+		sessionService.AssertNotCalled(t, "CreateImpersonationSession", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return error when admin is not in context", func(t *testing.T) {
+		service := &adminService{}
+
+		token, err := service.ImpersonateUser(context.Background(), uuid.New())
+
+		assert.ErrorIs(t, err, models.ErrUserNotFoundInContext)
+		assert.Empty(t, token)
+	})
+}
+
+func TestAdminService_SuspendRestaurant(t *testing.T) {
+	ctx := context.Background()
+	restaurantID := uuid.New()
+
+	t.Run("should suspend restaurant", func(t *testing.T) {
+		restaurantRepository := &mocks.RestaurantRepository{}
+
+		service := &adminService{
+			restaurantRepository: restaurantRepository,
+		}
+
+		restaurantRepository.On("GetRestaurantByID", ctx, restaurantID).Return(&models.Restaurant{BaseModel: models.BaseModel{ID: restaurantID}}, nil)
+		restaurantRepository.On("UpdateStatus", ctx, restaurantID, models.SuspendedRestaurant).Return(nil)
+
+		err := service.SuspendRestaurant(ctx, restaurantID)
+
+		assert.NoError(t, err)
+		restaurantRepository.AssertExpectations(t)
+	})
+
+	t.Run("should return error when restaurant is not found", func(t *testing.T) {
+		restaurantRepository := &mocks.RestaurantRepository{}
+
+		service := &adminService{
+			restaurantRepository: restaurantRepository,
+		}
+
+		restaurantRepository.On("GetRestaurantByID", ctx, restaurantID).Return(nil, nil)
+
+		err := service.SuspendRestaurant(ctx, restaurantID)
+
+		assert.ErrorIs(t, err, models.ErrRestaurantNotFound)
+	})
+
+	t.Run("should return error when update fails", func(t *testing.T) {
+		restaurantRepository := &mocks.RestaurantRepository{}
+
+		service := &adminService{
+			restaurantRepository: restaurantRepository,
+		}
+
+		restaurantRepository.On("GetRestaurantByID", ctx, restaurantID).Return(&models.Restaurant{BaseModel: models.BaseModel{ID: restaurantID}}, nil)
+		restaurantRepository.On("UpdateStatus", ctx, restaurantID, models.SuspendedRestaurant).Return(errors.New("database error"))
+
+		err := service.SuspendRestaurant(ctx, restaurantID)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "database error")
+	})
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/G-Villarinho/food-shop-api/repositories"
+	"github.com/google/uuid"
+)
+
+//go:generate mockery --name=APIKeyService --output=../mocks --outpkg=mocks
+type APIKeyService interface {
+	CreateAPIKey(ctx context.Context, payload models.CreateAPIKeyPayload) (*models.CreateAPIKeyResponse, error)
+	GetAPIKeys(ctx context.Context) ([]*models.APIKeyResponse, error)
+	RevokeAPIKey(ctx context.Context, apiKeyID uuid.UUID) error
+	Authenticate(ctx context.Context, key string) (*models.APIKey, *models.RestaurantMember, error)
+}
+
+type apiKeyService struct {
+	di                         *internal.Di
+	permissionService          PermissionService
+	apiKeyRepository           repositories.APIKeyRepository
+	restaurantMemberRepository repositories.RestaurantMemberRepository
+	userRepository             repositories.UserRepository
+}
+
+func NewAPIKeyService(di *internal.Di) (APIKeyService, error) {
+	permissionService, err := internal.Invoke[PermissionService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeyRepository, err := internal.Invoke[repositories.APIKeyRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	restaurantMemberRepository, err := internal.Invoke[repositories.RestaurantMemberRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	userRepository, err := internal.Invoke[repositories.UserRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiKeyService{
+		di:                         di,
+		permissionService:          permissionService,
+		apiKeyRepository:           apiKeyRepository,
+		restaurantMemberRepository: restaurantMemberRepository,
+		userRepository:             userRepository,
+	}, nil
+}
+
+func (a *apiKeyService) CreateAPIKey(ctx context.Context, payload models.CreateAPIKeyPayload) (*models.CreateAPIKeyResponse, error) {
+	restaurantID, ok := ctx.Value(internal.RestaurantIDKey).(*uuid.UUID)
+	if !ok || restaurantID == nil {
+		return nil, models.ErrRestaurantNotFound
+	}
+
+	userID, ok := ctx.Value(internal.UserIDKey).(uuid.UUID)
+	if !ok {
+		return nil, models.ErrUserNotFoundInContext
+	}
+
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	// A key can't grant more than its creator is allowed to do.
+	for _, scope := range payload.Scopes {
+		allowed, err := a.hasPermission(ctx, *restaurantID, scope)
+		if err != nil {
+			return nil, fmt.Errorf("check permission: %w", err)
+		}
+
+		if !allowed {
+			return nil, models.ErrAPIKeyScopeDenied
+		}
+	}
+
+	prefix, secret, err := generateAPIKey()
+	if err != nil {
+		return nil, fmt.Errorf("generate api key: %w", err)
+	}
+
+	key := prefix + "_" + secret
+	apiKey := payload.ToAPIKey(*restaurantID, userID, prefix, hashAPIKey(key))
+
+	if err := a.apiKeyRepository.CreateAPIKey(ctx, *apiKey); err != nil {
+		return nil, fmt.Errorf("create api key: %w", err)
+	}
+
+	return &models.CreateAPIKeyResponse{
+		APIKeyResponse: *apiKey.ToAPIKeyResponse(),
+		Key:            key,
+	}, nil
+}
+
+func (a *apiKeyService) GetAPIKeys(ctx context.Context) ([]*models.APIKeyResponse, error) {
+	restaurantID, ok := ctx.Value(internal.RestaurantIDKey).(*uuid.UUID)
+	if !ok || restaurantID == nil {
+		return nil, models.ErrRestaurantNotFound
+	}
+
+	apiKeys, err := a.apiKeyRepository.GetAPIKeysByRestaurantID(ctx, *restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("get api keys by restaurant id: %w", err)
+	}
+
+	response := make([]*models.APIKeyResponse, len(apiKeys))
+	for i, apiKey := range apiKeys {
+		response[i] = apiKey.ToAPIKeyResponse()
+	}
+
+	return response, nil
+}
+
+func (a *apiKeyService) RevokeAPIKey(ctx context.Context, apiKeyID uuid.UUID) error {
+	restaurantID, ok := ctx.Value(internal.RestaurantIDKey).(*uuid.UUID)
+	if !ok || restaurantID == nil {
+		return models.ErrRestaurantNotFound
+	}
+
+	deleted, err := a.apiKeyRepository.DeleteAPIKey(ctx, apiKeyID, *restaurantID)
+	if err != nil {
+		return fmt.Errorf("delete api key: %w", err)
+	}
+
+	if !deleted {
+		return models.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+// Authenticate returns the key along with the current membership of the person
+// who created it. The key acts with that member role, so it stops working once
+// its creator leaves the restaurant or is blocked, or once the restaurant is
+// suspended, and never does more than its creator still can.
+func (a *apiKeyService) Authenticate(ctx context.Context, key string) (*models.APIKey, *models.RestaurantMember, error) {
+	prefix, _, found := strings.Cut(strings.TrimPrefix(key, models.APIKeyPrefix), "_")
+	if !strings.HasPrefix(key, models.APIKeyPrefix) || !found {
+		return nil, nil, models.ErrInvalidAPIKey
+	}
+
+	apiKey, err := a.apiKeyRepository.GetAPIKeyByPrefix(ctx, models.APIKeyPrefix+prefix)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get api key by prefix: %w", err)
+	}
+
+	if apiKey == nil {
+		return nil, nil, models.ErrInvalidAPIKey
+	}
+
+	if subtle.ConstantTimeCompare([]byte(apiKey.Hash), []byte(hashAPIKey(key))) != 1 {
+		return nil, nil, models.ErrInvalidAPIKey
+	}
+
+	if apiKey.IsExpired() || apiKey.Restaurant.Status == models.SuspendedRestaurant {
+		return nil, nil, models.ErrInvalidAPIKey
+	}
+
+	member, err := a.restaurantMemberRepository.GetMemberByUserID(ctx, apiKey.CreatedBy)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get member by user id: %w", err)
+	}
+
+	if member == nil || member.RestaurantID != apiKey.RestaurantID {
+		return nil, nil, models.ErrInvalidAPIKey
+	}
+
+	user, err := a.userRepository.GetUserByID(ctx, apiKey.CreatedBy)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get user by id: %w", err)
+	}
+
+	if user == nil || user.Status == models.Blocked {
+		return nil, nil, models.ErrInvalidAPIKey
+	}
+
+	now := time.Now().UTC()
+	if !apiKey.LastUsedAt.Valid || now.Sub(apiKey.LastUsedAt.Time) >= lastSeenInterval {
+		if err := a.apiKeyRepository.UpdateLastUsedAt(ctx, apiKey.ID, now); err != nil {
+			return nil, nil, fmt.Errorf("update api key last used at: %w", err)
+		}
+	}
+
+	return apiKey, member, nil
+}
+
+func (a *apiKeyService) hasPermission(ctx context.Context, restaurantID uuid.UUID, permission models.Permission) (bool, error) {
+	role, ok := ctx.Value(internal.RoleKey).(models.Role)
+	if !ok {
+		return false, nil
+	}
+
+	allowed, err := a.permissionService.HasPermission(ctx, models.PlatformScope, string(role), permission)
+	if err != nil || !allowed {
+		return false, err
+	}
+
+	memberRole, ok := ctx.Value(internal.MemberRoleKey).(models.MemberRole)
+	if !ok {
+		return false, nil
+	}
+
+	return a.permissionService.HasMemberPermission(ctx, restaurantID, memberRole, permission)
+}
+
+// generateAPIKey returns a short public prefix, used to look the key up and to
+// tell keys apart in listings, and the secret part.
+func generateAPIKey() (string, string, error) {
+	prefix := make([]byte, 6)
+	if _, err := rand.Read(prefix); err != nil {
+		return "", "", err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+
+	return models.APIKeyPrefix + hex.EncodeToString(prefix), base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+func hashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,275 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/mocks"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAPIKeyService_CreateAPIKey(t *testing.T) {
+	restaurantID := uuid.New()
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), internal.RestaurantIDKey, &restaurantID)
+	ctx = context.WithValue(ctx, internal.UserIDKey, userID)
+	ctx = context.WithValue(ctx, internal.RoleKey, models.Manager)
+	ctx = context.WithValue(ctx, internal.MemberRoleKey, models.KitchenMember)
+
+	t.Run("should create api key and return the plain key once", func(t *testing.T) {
+		permissionService := &mocks.PermissionService{}
+		apiKeyRepository := &mocks.APIKeyRepository{}
+
+		service := &apiKeyService{
+			permissionService: permissionService,
+			apiKeyRepository:  apiKeyRepository,
+		}
+
+		payload := models.CreateAPIKeyPayload{
+			Name:   "POS",
+			Scopes: []models.Permission{models.ListOrdersPermission, models.ApproveOrderPermission},
+		}
+
+		permissionService.On("HasPermission", ctx, models.PlatformScope, string(models.Manager), mock.Anything).Return(true, nil)
+		permissionService.On("HasMemberPermission", ctx, restaurantID, models.KitchenMember, mock.Anything).Return(true, nil)
+		apiKeyRepository.On("CreateAPIKey", ctx, mock.MatchedBy(func(apiKey models.APIKey) bool {
+			return apiKey.RestaurantID == restaurantID && apiKey.CreatedBy == userID && apiKey.Scopes == "list_orders,approve_order"
+		})).Return(nil)
+
+		response, err := service.CreateAPIKey(ctx, payload)
+
+		assert.NoError(t, err)
+		assert.Contains(t, response.Key, response.Prefix+"_")
+		assert.Equal(t, payload.Scopes, response.Scopes)
+		apiKeyRepository.AssertExpectations(t)
+	})
+
+	t.Run("should not grant scopes the creator does not have", func(t *testing.T) {
+		permissionService := &mocks.PermissionService{}
+		apiKeyRepository := &mocks.APIKeyRepository{}
+
+		service := &apiKeyService{
+			permissionService: permissionService,
+			apiKeyRepository:  apiKeyRepository,
+		}
+
+		payload := models.CreateAPIKeyPayload{
+			Name:   "POS",
+			Scopes: []models.Permission{models.GetMonthlyMetricsPermission},
+		}
+
+		permissionService.On("HasPermission", ctx, models.PlatformScope, string(models.Manager), models.GetMonthlyMetricsPermission).Return(true, nil)
+		permissionService.On("HasMemberPermission", ctx, restaurantID, models.KitchenMember, models.GetMonthlyMetricsPermission).Return(false, nil)
+
+		response, err := service.CreateAPIKey(ctx, payload)
+
+		assert.ErrorIs(t, err, models.ErrAPIKeyScopeDenied)
+		assert.Nil(t, response)
+		apiKeyRepository.AssertNotCalled(t, "CreateAPIKey", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject scopes that can't be delegated", func(t *testing.T) {
+		apiKeyRepository := &mocks.APIKeyRepository{}
+
+		service := &apiKeyService{
+			apiKeyRepository: apiKeyRepository,
+		}
+
+		payload := models.CreateAPIKeyPayload{
+			Name:   "POS",
+			Scopes: []models.Permission{models.ManageAPIKeysPermission},
+		}
+
+		response, err := service.CreateAPIKey(ctx, payload)
+
+		assert.ErrorIs(t, err, models.ErrAPIKeyScopeUnknown)
+		assert.Nil(t, response)
+	})
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	ctx := context.Background()
+
+	prefix, secret, err := generateAPIKey()
+	assert.NoError(t, err)
+	key := prefix + "_" + secret
+
+	restaurantID := uuid.New()
+	creatorID := uuid.New()
+	member := &models.RestaurantMember{RestaurantID: restaurantID, UserID: creatorID, Role: models.CashierMember}
+	creator := &models.User{BaseModel: models.BaseModel{ID: creatorID}, Status: models.Active}
+
+	t.Run("should authenticate a valid key and record its use", func(t *testing.T) {
+		apiKeyRepository := &mocks.APIKeyRepository{}
+		restaurantMemberRepository := &mocks.RestaurantMemberRepository{}
+		userRepository := &mocks.UserRepository{}
+		service := &apiKeyService{apiKeyRepository: apiKeyRepository, restaurantMemberRepository: restaurantMemberRepository, userRepository: userRepository}
+
+		apiKey := &models.APIKey{
+			BaseModel:    models.BaseModel{ID: uuid.New()},
+			RestaurantID: restaurantID,
+			CreatedBy:    creatorID,
+			Prefix:       prefix,
+			Hash:         hashAPIKey(key),
+			Scopes:       string(models.ListOrdersPermission),
+		}
+
+		apiKeyRepository.On("GetAPIKeyByPrefix", ctx, prefix).Return(apiKey, nil)
+		apiKeyRepository.On("UpdateLastUsedAt", ctx, apiKey.ID, mock.AnythingOfType("time.Time")).Return(nil)
+		restaurantMemberRepository.On("GetMemberByUserID", ctx, creatorID).Return(member, nil)
+		userRepository.On("GetUserByID", ctx, creatorID).Return(creator, nil)
+
+		response, creator, err := service.Authenticate(ctx, key)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []models.Permission{models.ListOrdersPermission}, response.ScopeList())
+		assert.Equal(t, models.CashierMember, creator.Role)
+		apiKeyRepository.AssertExpectations(t)
+	})
+
+	t.Run("should reject a key whose creator left the restaurant", func(t *testing.T) {
+		apiKeyRepository := &mocks.APIKeyRepository{}
+		restaurantMemberRepository := &mocks.RestaurantMemberRepository{}
+		service := &apiKeyService{apiKeyRepository: apiKeyRepository, restaurantMemberRepository: restaurantMemberRepository}
+
+		apiKeyRepository.On("GetAPIKeyByPrefix", ctx, prefix).Return(&models.APIKey{
+			RestaurantID: restaurantID,
+			CreatedBy:    creatorID,
+			Prefix:       prefix,
+			Hash:         hashAPIKey(key),
+		}, nil)
+		restaurantMemberRepository.On("GetMemberByUserID", ctx, creatorID).Return(nil, nil)
+
+		_, _, err := service.Authenticate(ctx, key)
+
+		assert.ErrorIs(t, err, models.ErrInvalidAPIKey)
+		apiKeyRepository.AssertNotCalled(t, "UpdateLastUsedAt", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject a key whose creator moved to another restaurant", func(t *testing.T) {
+		apiKeyRepository := &mocks.APIKeyRepository{}
+		restaurantMemberRepository := &mocks.RestaurantMemberRepository{}
+		service := &apiKeyService{apiKeyRepository: apiKeyRepository, restaurantMemberRepository: restaurantMemberRepository}
+
+		apiKeyRepository.On("GetAPIKeyByPrefix", ctx, prefix).Return(&models.APIKey{
+			RestaurantID: restaurantID,
+			CreatedBy:    creatorID,
+			Prefix:       prefix,
+			Hash:         hashAPIKey(key),
+		}, nil)
+		restaurantMemberRepository.On("GetMemberByUserID", ctx, creatorID).Return(&models.RestaurantMember{RestaurantID: uuid.New(), UserID: creatorID, Role: models.OwnerMember}, nil)
+
+		_, _, err := service.Authenticate(ctx, key)
+
+		assert.ErrorIs(t, err, models.ErrInvalidAPIKey)
+	})
+
+	t.Run("should reject a key whose creator is blocked", func(t *testing.T) {
+		apiKeyRepository := &mocks.APIKeyRepository{}
+		restaurantMemberRepository := &mocks.RestaurantMemberRepository{}
+		userRepository := &mocks.UserRepository{}
+		service := &apiKeyService{apiKeyRepository: apiKeyRepository, restaurantMemberRepository: restaurantMemberRepository, userRepository: userRepository}
+
+		apiKeyRepository.On("GetAPIKeyByPrefix", ctx, prefix).Return(&models.APIKey{
+			RestaurantID: restaurantID,
+			CreatedBy:    creatorID,
+			Prefix:       prefix,
+			Hash:         hashAPIKey(key),
+		}, nil)
+		restaurantMemberRepository.On("GetMemberByUserID", ctx, creatorID).Return(member, nil)
+		userRepository.On("GetUserByID", ctx, creatorID).Return(&models.User{BaseModel: models.BaseModel{ID: creatorID}, Status: models.Blocked}, nil)
+
+		_, _, err := service.Authenticate(ctx, key)
+
+		assert.ErrorIs(t, err, models.ErrInvalidAPIKey)
+		apiKeyRepository.AssertNotCalled(t, "UpdateLastUsedAt", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject a key of a suspended restaurant", func(t *testing.T) {
+		apiKeyRepository := &mocks.APIKeyRepository{}
+		restaurantMemberRepository := &mocks.RestaurantMemberRepository{}
+		service := &apiKeyService{apiKeyRepository: apiKeyRepository, restaurantMemberRepository: restaurantMemberRepository}
+
+		apiKeyRepository.On("GetAPIKeyByPrefix", ctx, prefix).Return(&models.APIKey{
+			RestaurantID: restaurantID,
+			Restaurant:   models.Restaurant{Status: models.SuspendedRestaurant},
+			CreatedBy:    creatorID,
+			Prefix:       prefix,
+			Hash:         hashAPIKey(key),
+		}, nil)
+
+		_, _, err := service.Authenticate(ctx, key)
+
+		assert.ErrorIs(t, err, models.ErrInvalidAPIKey)
+		restaurantMemberRepository.AssertNotCalled(t, "GetMemberByUserID", mock.Anything, mock.Anything)
+		apiKeyRepository.AssertNotCalled(t, "UpdateLastUsedAt", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should skip the last used update when the key was used recently", func(t *testing.T) {
+		apiKeyRepository := &mocks.APIKeyRepository{}
+		restaurantMemberRepository := &mocks.RestaurantMemberRepository{}
+		userRepository := &mocks.UserRepository{}
+		service := &apiKeyService{apiKeyRepository: apiKeyRepository, restaurantMemberRepository: restaurantMemberRepository, userRepository: userRepository}
+
+		apiKey := &models.APIKey{
+			BaseModel:    models.BaseModel{ID: uuid.New()},
+			RestaurantID: restaurantID,
+			CreatedBy:    creatorID,
+			Prefix:       prefix,
+			Hash:         hashAPIKey(key),
+			LastUsedAt:   sql.NullTime{Time: time.Now().UTC(), Valid: true},
+		}
+
+		apiKeyRepository.On("GetAPIKeyByPrefix", ctx, prefix).Return(apiKey, nil)
+		restaurantMemberRepository.On("GetMemberByUserID", ctx, creatorID).Return(member, nil)
+		userRepository.On("GetUserByID", ctx, creatorID).Return(creator, nil)
+
+		_, _, err := service.Authenticate(ctx, key)
+
+		assert.NoError(t, err)
+		apiKeyRepository.AssertNotCalled(t, "UpdateLastUsedAt", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject a key with the wrong secret", func(t *testing.T) {
+		apiKeyRepository := &mocks.APIKeyRepository{}
+		service := &apiKeyService{apiKeyRepository: apiKeyRepository}
+
+		apiKeyRepository.On("GetAPIKeyByPrefix", ctx, prefix).Return(&models.APIKey{Prefix: prefix, Hash: hashAPIKey(key)}, nil)
+
+		_, _, err := service.Authenticate(ctx, prefix+"_wrong")
+
+		assert.ErrorIs(t, err, models.ErrInvalidAPIKey)
+	})
+
+	t.Run("should reject an expired key", func(t *testing.T) {
+		apiKeyRepository := &mocks.APIKeyRepository{}
+		service := &apiKeyService{apiKeyRepository: apiKeyRepository}
+
+		apiKeyRepository.On("GetAPIKeyByPrefix", ctx, prefix).Return(&models.APIKey{
+			Prefix:    prefix,
+			Hash:      hashAPIKey(key),
+			ExpiresAt: sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true},
+		}, nil)
+
+		_, _, err := service.Authenticate(ctx, key)
+
+		assert.ErrorIs(t, err, models.ErrInvalidAPIKey)
+		apiKeyRepository.AssertNotCalled(t, "UpdateLastUsedAt", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject a malformed key without querying the database", func(t *testing.T) {
+		apiKeyRepository := &mocks.APIKeyRepository{}
+		service := &apiKeyService{apiKeyRepository: apiKeyRepository}
+
+		_, _, err := service.Authenticate(ctx, "fsk_malformed")
+
+		assert.ErrorIs(t, err, models.ErrInvalidAPIKey)
+		apiKeyRepository.AssertNotCalled(t, "GetAPIKeyByPrefix", mock.Anything, mock.Anything)
+	})
+}
@@ -2,8 +2,13 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/G-Villarinho/food-shop-api/cache"
@@ -13,24 +18,28 @@ import (
 	"github.com/G-Villarinho/food-shop-api/repositories"
 	"github.com/G-Villarinho/food-shop-api/services/email"
 	"github.com/google/uuid"
-	jsoniter "github.com/json-iterator/go"
 )
 
 //go:generate mockery --name=AuthService --output=../mocks --outpkg=mocks
 type AuthService interface {
 	SignIn(ctx context.Context, email string) error
-	VeryfyMagicLink(ctx context.Context, code uuid.UUID) (string, error)
+	SignInWithCode(ctx context.Context, email string) error
+	VeryfyMagicLink(ctx context.Context, code uuid.UUID) (*models.AuthTokenResponse, error)
+	VerifySignInCode(ctx context.Context, payload models.VerifySignInCodePayload) (*models.AuthTokenResponse, error)
+	RefreshToken(ctx context.Context, refreshToken string) (*models.AuthTokenResponse, error)
 	SignOut(ctx context.Context) error
 }
 
+const maxSignInCodeAttempts = 5
+
 type authService struct {
-	di                   *internal.Di
-	emailFactory         email.EmailFactory
-	cacheService         cache.CacheService
-	queueService         QueueService
-	sessionService       SessionService
-	restaurantRepository repositories.RestaurantRepository
-	userRespository      repositories.UserRepository
+	di                         *internal.Di
+	emailFactory               email.EmailFactory
+	cacheService               cache.CacheService
+	outboxRepository           repositories.OutboxRepository
+	sessionService             SessionService
+	restaurantMemberRepository repositories.RestaurantMemberRepository
+	userRespository            repositories.UserRepository
 }
 
 func NewAuthService(di *internal.Di) (AuthService, error) {
@@ -39,34 +48,34 @@ func NewAuthService(di *internal.Di) (AuthService, error) {
 		return nil, err
 	}
 
-	queueService, err := internal.Invoke[QueueService](di)
+	sessionService, err := internal.Invoke[SessionService](di)
 	if err != nil {
 		return nil, err
 	}
 
-	sessionService, err := internal.Invoke[SessionService](di)
+	restaurantMemberRepository, err := internal.Invoke[repositories.RestaurantMemberRepository](di)
 	if err != nil {
 		return nil, err
 	}
 
-	restaurantRepository, err := internal.Invoke[repositories.RestaurantRepository](di)
+	userRepository, err := internal.Invoke[repositories.UserRepository](di)
 	if err != nil {
 		return nil, err
 	}
 
-	userRepository, err := internal.Invoke[repositories.UserRepository](di)
+	outboxRepository, err := internal.Invoke[repositories.OutboxRepository](di)
 	if err != nil {
 		return nil, err
 	}
 
 	return &authService{
-		di:                   di,
-		emailFactory:         *email.NewEmailTaskFactory(),
-		cacheService:         cacheService,
-		queueService:         queueService,
-		sessionService:       sessionService,
-		restaurantRepository: restaurantRepository,
-		userRespository:      userRepository,
+		di:                         di,
+		emailFactory:               *email.NewEmailTaskFactory(),
+		cacheService:               cacheService,
+		outboxRepository:           outboxRepository,
+		sessionService:             sessionService,
+		restaurantMemberRepository: restaurantMemberRepository,
+		userRespository:            userRepository,
 	}, nil
 }
 
@@ -80,6 +89,10 @@ func (a *authService) SignIn(ctx context.Context, email string) error {
 		return models.ErrUserNotFound
 	}
 
+	if user.Status == models.Blocked {
+		return models.ErrUserBlocked
+	}
+
 	code, err := uuid.NewV7()
 	if err != nil {
 		return fmt.Errorf("generate code: %w", err)
@@ -91,54 +104,174 @@ func (a *authService) SignIn(ctx context.Context, email string) error {
 		return fmt.Errorf("set magic link: %w", err)
 	}
 
-	message, err := jsoniter.Marshal(a.emailFactory.CreateSignInMagicLinkEmail(user.Email, user.FullName, magicLink))
+	message, err := models.NewOutboxMessage(QueueSendEmail, a.emailFactory.CreateSignInMagicLinkEmail(user.Email, user.FullName, magicLink))
 	if err != nil {
-		return fmt.Errorf("marshal email task: %w", err)
+		return fmt.Errorf("create email message: %w", err)
 	}
 
-	if err := a.queueService.Publish(QueueSendEmail, message); err != nil {
-		return fmt.Errorf("publish email task: %w", err)
+	if err := a.outboxRepository.CreateMessages(ctx, []models.OutboxMessage{*message}); err != nil {
+		return fmt.Errorf("create outbox messages: %w", err)
 	}
 
 	return nil
 }
 
-func (a *authService) VeryfyMagicLink(ctx context.Context, code uuid.UUID) (string, error) {
+func (a *authService) VeryfyMagicLink(ctx context.Context, code uuid.UUID) (*models.AuthTokenResponse, error) {
 	var userID uuid.UUID
 	if err := a.cacheService.Get(ctx, getMagicLinkKey(code), &userID); err != nil {
 		if errors.Is(err, cache.ErrCacheMiss) {
-			return "", models.ErrMagicLinkNotFound
+			return nil, models.ErrMagicLinkNotFound
 		}
-		return "", fmt.Errorf("get magic link: %w", err)
+		return nil, fmt.Errorf("get magic link: %w", err)
 	}
 
 	user, err := a.userRespository.GetUserByID(ctx, userID)
 	if err != nil {
-		return "", fmt.Errorf("get user by id: %w", err)
+		return nil, fmt.Errorf("get user by id: %w", err)
 	}
 
 	if user == nil {
-		return "", models.ErrUserNotFound
+		return nil, models.ErrUserNotFound
 	}
 
-	var restaurantID *uuid.UUID
-	if user.Role == models.Manager {
-		restaurantID, err = a.restaurantRepository.GetRestaurantIDByUserID(ctx, user.ID)
-		if err != nil {
-			return "", fmt.Errorf("get restaurant id by user id: %w", err)
-		}
+	if user.Status == models.Blocked {
+		return nil, models.ErrUserBlocked
 	}
 
-	session, err := a.sessionService.CreateSession(ctx, user.ID, restaurantID, user.Role)
+	response, err := a.createUserSession(ctx, user)
 	if err != nil {
-		return "", fmt.Errorf("create session: %w", err)
+		return nil, err
 	}
 
 	if err := a.cacheService.Delete(ctx, getMagicLinkKey(code)); err != nil {
-		return "", fmt.Errorf("delete magic link: %w", err)
+		return nil, fmt.Errorf("delete magic link: %w", err)
+	}
+
+	return response, nil
+}
+
+func (a *authService) SignInWithCode(ctx context.Context, email string) error {
+	user, err := a.userRespository.GetUserByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return models.ErrUserNotFound
+	}
+
+	if user.Status == models.Blocked {
+		return models.ErrUserBlocked
+	}
+
+	// A new code doesn't give back the attempts spent on the previous ones.
+	attempts, err := a.getSignInCodeAttempts(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	if attempts >= maxSignInCodeAttempts {
+		return models.ErrTooManySignInAttempts
+	}
+
+	code, err := generateSignInCode()
+	if err != nil {
+		return fmt.Errorf("generate sign-in code: %w", err)
+	}
+
+	ttl := config.SignInCodeExp()
+	signInCode := models.SignInCode{
+		UserID:    user.ID,
+		Hash:      hashSignInCode(user.ID, code),
+		ExpiresAt: time.Now().Add(ttl).Unix(),
+	}
+
+	if err := a.cacheService.Set(ctx, getSignInCodeKey(user.ID), signInCode, ttl); err != nil {
+		return fmt.Errorf("set sign-in code: %w", err)
+	}
+
+	message, err := models.NewOutboxMessage(QueueSendEmail, a.emailFactory.CreateSignInCodeEmail(user.Email, user.FullName, code, int(ttl.Minutes())))
+	if err != nil {
+		return fmt.Errorf("create email message: %w", err)
+	}
+
+	if err := a.outboxRepository.CreateMessages(ctx, []models.OutboxMessage{*message}); err != nil {
+		return fmt.Errorf("create outbox messages: %w", err)
+	}
+
+	return nil
+}
+
+func (a *authService) VerifySignInCode(ctx context.Context, payload models.VerifySignInCodePayload) (*models.AuthTokenResponse, error) {
+	user, err := a.userRespository.GetUserByEmail(ctx, payload.Email)
+	if err != nil {
+		return nil, fmt.Errorf("get user by email: %w", err)
+	}
+
+	if user == nil {
+		return nil, models.ErrInvalidSignInCode
+	}
+
+	// Every attempt is counted before the code is compared, so concurrent
+	// guesses can't get past the limit.
+	attempts, err := a.cacheService.Increment(ctx, getSignInCodeAttemptsKey(user.ID), config.SignInCodeLockout())
+	if err != nil {
+		return nil, fmt.Errorf("increment sign-in code attempts: %w", err)
+	}
+
+	if attempts > maxSignInCodeAttempts {
+		return nil, models.ErrTooManySignInAttempts
+	}
+
+	var signInCode models.SignInCode
+	if err := a.cacheService.Get(ctx, getSignInCodeKey(user.ID), &signInCode); err != nil {
+		if errors.Is(err, cache.ErrCacheMiss) {
+			return nil, models.ErrSignInCodeNotFound
+		}
+		return nil, fmt.Errorf("get sign-in code: %w", err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(signInCode.Hash), []byte(hashSignInCode(user.ID, payload.Code))) != 1 {
+		if attempts < maxSignInCodeAttempts {
+			return nil, models.ErrInvalidSignInCode
+		}
+
+		if err := a.cacheService.Delete(ctx, getSignInCodeKey(user.ID)); err != nil {
+			return nil, fmt.Errorf("delete sign-in code: %w", err)
+		}
+
+		return nil, models.ErrTooManySignInAttempts
 	}
 
-	return session.Token, nil
+	if err := a.cacheService.Delete(ctx, getSignInCodeKey(user.ID)); err != nil {
+		return nil, fmt.Errorf("delete sign-in code: %w", err)
+	}
+
+	if err := a.cacheService.Delete(ctx, getSignInCodeAttemptsKey(user.ID)); err != nil {
+		return nil, fmt.Errorf("delete sign-in code attempts: %w", err)
+	}
+
+	if user.Status == models.Blocked {
+		return nil, models.ErrUserBlocked
+	}
+
+	return a.createUserSession(ctx, user)
+}
+
+func (a *authService) RefreshToken(ctx context.Context, refreshToken string) (*models.AuthTokenResponse, error) {
+	if refreshToken == "" {
+		return nil, models.ErrInvalidRefreshToken
+	}
+
+	session, err := a.sessionService.RefreshSession(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AuthTokenResponse{
+		Token:        session.Token,
+		RefreshToken: session.RefreshToken,
+	}, nil
 }
 
 func (a *authService) SignOut(ctx context.Context) error {
@@ -154,6 +287,57 @@ func (a *authService) SignOut(ctx context.Context) error {
 	return nil
 }
 
+func (a *authService) getSignInCodeAttempts(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var attempts int64
+	if err := a.cacheService.Get(ctx, getSignInCodeAttemptsKey(userID), &attempts); err != nil {
+		if errors.Is(err, cache.ErrCacheMiss) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("get sign-in code attempts: %w", err)
+	}
+
+	return attempts, nil
+}
+
+func (a *authService) createUserSession(ctx context.Context, user *models.User) (*models.AuthTokenResponse, error) {
+	restaurantID, memberRole, err := getSessionMembership(ctx, a.restaurantMemberRepository, user)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := a.sessionService.CreateSession(ctx, user.ID, restaurantID, user.Role, memberRole)
+	if err != nil {
+		return nil, fmt.Errorf("create session: %w", err)
+	}
+
+	return &models.AuthTokenResponse{
+		Token:        session.Token,
+		RefreshToken: session.RefreshToken,
+	}, nil
+}
+
+func generateSignInCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+func hashSignInCode(userID uuid.UUID, code string) string {
+	hash := sha256.Sum256([]byte(userID.String() + ":" + code))
+	return hex.EncodeToString(hash[:])
+}
+
+func getSignInCodeKey(userID uuid.UUID) string {
+	return fmt.Sprintf("sign-in-code:%s", userID.String())
+}
+
+func getSignInCodeAttemptsKey(userID uuid.UUID) string {
+	return fmt.Sprintf("sign-in-code-attempts:%s", userID.String())
+}
+
 func getMagicLinkKey(code uuid.UUID) string {
 	return fmt.Sprintf("magic-link:%s", code.String())
 }
@@ -21,14 +21,14 @@ func TestAuthService_SignIn(t *testing.T) {
 
 	t.Run("should send magic link successfully", func(t *testing.T) {
 		cacheService := &mocks.CacheService{}
-		queueService := &mocks.QueueService{}
+		outboxRepository := &mocks.OutboxRepository{}
 		userRepository := &mocks.UserRepository{}
 
 		authService := &authService{
-			cacheService:    cacheService,
-			queueService:    queueService,
-			userRespository: userRepository,
-			emailFactory:    *email.NewEmailTaskFactory(),
+			cacheService:     cacheService,
+			outboxRepository: outboxRepository,
+			userRespository:  userRepository,
+			emailFactory:     *email.NewEmailTaskFactory(),
 		}
 
 		email := "user@example.com"
@@ -41,26 +41,26 @@ func TestAuthService_SignIn(t *testing.T) {
 
 		userRepository.On("GetUserByEmail", ctx, email).Return(user, nil)
 		cacheService.On("Set", ctx, mock.Anything, user.ID.String(), 15*time.Minute).Return(nil)
-		queueService.On("Publish", QueueSendEmail, mock.Anything).Return(nil)
+		outboxRepository.On("CreateMessages", mock.Anything, emailOutbox()).Return(nil)
 
 		err := authService.SignIn(ctx, email)
 
 		assert.NoError(t, err)
 		userRepository.AssertCalled(t, "GetUserByEmail", ctx, email)
 		cacheService.AssertCalled(t, "Set", ctx, mock.Anything, user.ID.String(), 15*time.Minute)
-		queueService.AssertCalled(t, "Publish", QueueSendEmail, mock.Anything)
+		outboxRepository.AssertCalled(t, "CreateMessages", mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when user not found", func(t *testing.T) {
 		cacheService := &mocks.CacheService{}
-		queueService := &mocks.QueueService{}
+		outboxRepository := &mocks.OutboxRepository{}
 		userRepository := &mocks.UserRepository{}
 
 		authService := &authService{
-			cacheService:    cacheService,
-			queueService:    queueService,
-			userRespository: userRepository,
-			emailFactory:    *email.NewEmailTaskFactory(),
+			cacheService:     cacheService,
+			outboxRepository: outboxRepository,
+			userRespository:  userRepository,
+			emailFactory:     *email.NewEmailTaskFactory(),
 		}
 
 		email := "user@example.com"
@@ -72,19 +72,47 @@ func TestAuthService_SignIn(t *testing.T) {
 		assert.ErrorIs(t, err, models.ErrUserNotFound)
 		userRepository.AssertCalled(t, "GetUserByEmail", ctx, email)
 		cacheService.AssertNotCalled(t, "Set", ctx, mock.Anything, mock.Anything, mock.Anything)
-		queueService.AssertNotCalled(t, "Publish", QueueSendEmail, mock.Anything)
+		outboxRepository.AssertNotCalled(t, "CreateMessages", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return error when user is blocked", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		outboxRepository := &mocks.OutboxRepository{}
+		userRepository := &mocks.UserRepository{}
+
+		authService := &authService{
+			cacheService:     cacheService,
+			outboxRepository: outboxRepository,
+			userRespository:  userRepository,
+			emailFactory:     *email.NewEmailTaskFactory(),
+		}
+
+		email := "user@example.com"
+		user := &models.User{
+			BaseModel: models.BaseModel{ID: uuid.New()},
+			Email:     email,
+			Status:    models.Blocked,
+		}
+
+		userRepository.On("GetUserByEmail", ctx, email).Return(user, nil)
+
+		err := authService.SignIn(ctx, email)
+
+		assert.ErrorIs(t, err, models.ErrUserBlocked)
+		cacheService.AssertNotCalled(t, "Set", ctx, mock.Anything, mock.Anything, mock.Anything)
+		outboxRepository.AssertNotCalled(t, "CreateMessages", mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when GetUserByEmail fails", func(t *testing.T) {
 		cacheService := &mocks.CacheService{}
-		queueService := &mocks.QueueService{}
+		outboxRepository := &mocks.OutboxRepository{}
 		userRepository := &mocks.UserRepository{}
 
 		authService := &authService{
-			cacheService:    cacheService,
-			queueService:    queueService,
-			userRespository: userRepository,
-			emailFactory:    *email.NewEmailTaskFactory(),
+			cacheService:     cacheService,
+			outboxRepository: outboxRepository,
+			userRespository:  userRepository,
+			emailFactory:     *email.NewEmailTaskFactory(),
 		}
 
 		email := "user@example.com"
@@ -97,19 +125,19 @@ func TestAuthService_SignIn(t *testing.T) {
 		assert.Contains(t, err.Error(), "database error")
 		userRepository.AssertCalled(t, "GetUserByEmail", ctx, email)
 		cacheService.AssertNotCalled(t, "Set", ctx, mock.Anything, mock.Anything, mock.Anything)
-		queueService.AssertNotCalled(t, "Publish", QueueSendEmail, mock.Anything)
+		outboxRepository.AssertNotCalled(t, "CreateMessages", mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when Set in cache fails", func(t *testing.T) {
 		cacheService := &mocks.CacheService{}
-		queueService := &mocks.QueueService{}
+		outboxRepository := &mocks.OutboxRepository{}
 		userRepository := &mocks.UserRepository{}
 
 		authService := &authService{
-			cacheService:    cacheService,
-			queueService:    queueService,
-			userRespository: userRepository,
-			emailFactory:    *email.NewEmailTaskFactory(),
+			cacheService:     cacheService,
+			outboxRepository: outboxRepository,
+			userRespository:  userRepository,
+			emailFactory:     *email.NewEmailTaskFactory(),
 		}
 
 		email := "user@example.com"
@@ -129,19 +157,19 @@ func TestAuthService_SignIn(t *testing.T) {
 		assert.Contains(t, err.Error(), "set magic link")
 		userRepository.AssertCalled(t, "GetUserByEmail", ctx, email)
 		cacheService.AssertCalled(t, "Set", ctx, mock.Anything, user.ID.String(), 15*time.Minute)
-		queueService.AssertNotCalled(t, "Publish", QueueSendEmail, mock.Anything)
+		outboxRepository.AssertNotCalled(t, "CreateMessages", mock.Anything, mock.Anything)
 	})
 
-	t.Run("should return error when Publish to queue fails", func(t *testing.T) {
+	t.Run("should return error when the email can't be stored in the outbox", func(t *testing.T) {
 		cacheService := &mocks.CacheService{}
-		queueService := &mocks.QueueService{}
+		outboxRepository := &mocks.OutboxRepository{}
 		userRepository := &mocks.UserRepository{}
 
 		authService := &authService{
-			cacheService:    cacheService,
-			queueService:    queueService,
-			userRespository: userRepository,
-			emailFactory:    *email.NewEmailTaskFactory(),
+			cacheService:     cacheService,
+			outboxRepository: outboxRepository,
+			userRespository:  userRepository,
+			emailFactory:     *email.NewEmailTaskFactory(),
 		}
 
 		email := "user@example.com"
@@ -154,15 +182,15 @@ func TestAuthService_SignIn(t *testing.T) {
 
 		userRepository.On("GetUserByEmail", ctx, email).Return(user, nil)
 		cacheService.On("Set", ctx, mock.Anything, user.ID.String(), 15*time.Minute).Return(nil)
-		queueService.On("Publish", QueueSendEmail, mock.Anything).Return(errors.New("queue error"))
+		outboxRepository.On("CreateMessages", mock.Anything, emailOutbox()).Return(errors.New("database error"))
 
 		err := authService.SignIn(ctx, email)
 
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "publish email task")
+		assert.Contains(t, err.Error(), "create outbox messages")
 		userRepository.AssertCalled(t, "GetUserByEmail", ctx, email)
 		cacheService.AssertCalled(t, "Set", ctx, mock.Anything, user.ID.String(), 15*time.Minute)
-		queueService.AssertCalled(t, "Publish", QueueSendEmail, mock.Anything)
+		outboxRepository.AssertCalled(t, "CreateMessages", mock.Anything, mock.Anything)
 	})
 }
 
@@ -172,14 +200,14 @@ func TestAuthService_VeryfyMagicLink(t *testing.T) {
 	t.Run("should verify magic link successfully", func(t *testing.T) {
 		cacheService := &mocks.CacheService{}
 		userRepository := &mocks.UserRepository{}
-		restaurantRepository := &mocks.RestaurantRepository{}
+		restaurantMemberRepository := &mocks.RestaurantMemberRepository{}
 		sessionService := &mocks.SessionService{}
 
 		authService := &authService{
-			cacheService:         cacheService,
-			userRespository:      userRepository,
-			restaurantRepository: restaurantRepository,
-			sessionService:       sessionService,
+			cacheService:               cacheService,
+			userRespository:            userRepository,
+			restaurantMemberRepository: restaurantMemberRepository,
+			sessionService:             sessionService,
 		}
 
 		code := uuid.New()
@@ -195,32 +223,97 @@ func TestAuthService_VeryfyMagicLink(t *testing.T) {
 		}).Return(nil)
 		cacheService.On("Delete", ctx, getMagicLinkKey(code)).Return(nil)
 		userRepository.On("GetUserByID", ctx, userID).Return(user, nil)
-		sessionService.On("CreateSession", ctx, userID, (*uuid.UUID)(nil), user.Role).Return(&models.Session{
+		sessionService.On("CreateSession", ctx, userID, (*uuid.UUID)(nil), user.Role, (*models.MemberRole)(nil)).Return(&models.Session{
 			Token: sessionToken,
 		}, nil)
 
 		token, err := authService.VeryfyMagicLink(ctx, code)
 
 		assert.NoError(t, err)
-		assert.Equal(t, sessionToken, token)
+		assert.Equal(t, sessionToken, token.Token)
 
 		cacheService.AssertCalled(t, "Get", ctx, getMagicLinkKey(code), mock.AnythingOfType("*uuid.UUID"))
 		cacheService.AssertCalled(t, "Delete", ctx, getMagicLinkKey(code))
 		userRepository.AssertCalled(t, "GetUserByID", ctx, userID)
-		sessionService.AssertCalled(t, "CreateSession", ctx, userID, (*uuid.UUID)(nil), user.Role)
+		sessionService.AssertCalled(t, "CreateSession", ctx, userID, (*uuid.UUID)(nil), user.Role, (*models.MemberRole)(nil))
+	})
+
+	t.Run("should return error when user is blocked", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		userRepository := &mocks.UserRepository{}
+		sessionService := &mocks.SessionService{}
+
+		authService := &authService{
+			cacheService:    cacheService,
+			userRespository: userRepository,
+			sessionService:  sessionService,
+		}
+
+		code := uuid.New()
+		userID := uuid.New()
+		user := &models.User{
+			BaseModel: models.BaseModel{ID: userID},
+			Role:      models.Customer,
+			Status:    models.Blocked,
+		}
+
+		cacheService.On("Get", ctx, getMagicLinkKey(code), mock.AnythingOfType("*uuid.UUID")).Run(func(args mock.Arguments) {
+			*(args.Get(2).(*uuid.UUID)) = userID
+		}).Return(nil)
+		userRepository.On("GetUserByID", ctx, userID).Return(user, nil)
+
+		token, err := authService.VeryfyMagicLink(ctx, code)
+
+		assert.ErrorIs(t, err, models.ErrUserBlocked)
+		assert.Empty(t, token)
+		sessionService.AssertNotCalled(t, "CreateSession", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return error when manager is not a member of any restaurant", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		userRepository := &mocks.UserRepository{}
+		restaurantMemberRepository := &mocks.RestaurantMemberRepository{}
+		sessionService := &mocks.SessionService{}
+
+		authService := &authService{
+			cacheService:               cacheService,
+			userRespository:            userRepository,
+			restaurantMemberRepository: restaurantMemberRepository,
+			sessionService:             sessionService,
+		}
+
+		code := uuid.New()
+		userID := uuid.New()
+		user := &models.User{
+			BaseModel: models.BaseModel{ID: userID},
+			Role:      models.Manager,
+		}
+
+		cacheService.On("Get", ctx, getMagicLinkKey(code), mock.AnythingOfType("*uuid.UUID")).Run(func(args mock.Arguments) {
+			*(args.Get(2).(*uuid.UUID)) = userID
+		}).Return(nil)
+		userRepository.On("GetUserByID", ctx, userID).Return(user, nil)
+		restaurantMemberRepository.On("GetMemberByUserID", ctx, userID).Return(nil, nil)
+
+		token, err := authService.VeryfyMagicLink(ctx, code)
+
+		assert.ErrorIs(t, err, models.ErrRestaurantNotFound)
+		assert.Empty(t, token)
+		sessionService.AssertNotCalled(t, "CreateSession", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		cacheService.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when magic link is not found in cache", func(t *testing.T) {
 		cacheService := &mocks.CacheService{}
 		userRepository := &mocks.UserRepository{}
 		sessionService := &mocks.SessionService{}
-		restaurantRepository := &mocks.RestaurantRepository{}
+		restaurantMemberRepository := &mocks.RestaurantMemberRepository{}
 
 		authService := &authService{
-			cacheService:         cacheService,
-			userRespository:      userRepository,
-			restaurantRepository: restaurantRepository,
-			sessionService:       sessionService,
+			cacheService:               cacheService,
+			userRespository:            userRepository,
+			restaurantMemberRepository: restaurantMemberRepository,
+			sessionService:             sessionService,
 		}
 
 		code := uuid.New()
@@ -235,20 +328,20 @@ func TestAuthService_VeryfyMagicLink(t *testing.T) {
 		cacheService.AssertCalled(t, "Get", ctx, getMagicLinkKey(code), mock.AnythingOfType("*uuid.UUID"))
 		cacheService.AssertNotCalled(t, "Delete", ctx, getMagicLinkKey(code))
 		userRepository.AssertNotCalled(t, "GetUserByID", ctx, mock.Anything)
-		sessionService.AssertNotCalled(t, "CreateSession", ctx, mock.Anything, mock.Anything, mock.Anything)
+		sessionService.AssertNotCalled(t, "CreateSession", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when creating session fails", func(t *testing.T) {
 		cacheService := &mocks.CacheService{}
 		userRepository := &mocks.UserRepository{}
-		restaurantRepository := &mocks.RestaurantRepository{}
+		restaurantMemberRepository := &mocks.RestaurantMemberRepository{}
 		sessionService := &mocks.SessionService{}
 
 		authService := &authService{
-			cacheService:         cacheService,
-			userRespository:      userRepository,
-			restaurantRepository: restaurantRepository,
-			sessionService:       sessionService,
+			cacheService:               cacheService,
+			userRespository:            userRepository,
+			restaurantMemberRepository: restaurantMemberRepository,
+			sessionService:             sessionService,
 		}
 
 		code := uuid.New()
@@ -263,7 +356,7 @@ func TestAuthService_VeryfyMagicLink(t *testing.T) {
 		}).Return(nil)
 		cacheService.On("Delete", ctx, getMagicLinkKey(code)).Return(nil)
 		userRepository.On("GetUserByID", ctx, userID).Return(user, nil)
-		sessionService.On("CreateSession", ctx, userID, (*uuid.UUID)(nil), user.Role).Return(nil, errors.New("session creation error"))
+		sessionService.On("CreateSession", ctx, userID, (*uuid.UUID)(nil), user.Role, (*models.MemberRole)(nil)).Return(nil, errors.New("session creation error"))
 
 		token, err := authService.VeryfyMagicLink(ctx, code)
 
@@ -274,7 +367,7 @@ func TestAuthService_VeryfyMagicLink(t *testing.T) {
 		cacheService.AssertCalled(t, "Get", ctx, getMagicLinkKey(code), mock.AnythingOfType("*uuid.UUID"))
 		cacheService.AssertNotCalled(t, "Delete", ctx, getMagicLinkKey(code))
 		userRepository.AssertCalled(t, "GetUserByID", ctx, userID)
-		sessionService.AssertCalled(t, "CreateSession", ctx, userID, (*uuid.UUID)(nil), user.Role)
+		sessionService.AssertCalled(t, "CreateSession", ctx, userID, (*uuid.UUID)(nil), user.Role, (*models.MemberRole)(nil))
 	})
 }
 
@@ -329,3 +422,262 @@ func TestAuthService_SignOut(t *testing.T) {
 		sessionService.AssertCalled(t, "DeleteSession", ctx, sessionID)
 	})
 }
+
+func TestAuthService_SignInWithCode(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should send sign-in code successfully", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		outboxRepository := &mocks.OutboxRepository{}
+		userRepository := &mocks.UserRepository{}
+
+		authService := &authService{
+			cacheService:     cacheService,
+			outboxRepository: outboxRepository,
+			userRespository:  userRepository,
+			emailFactory:     *email.NewEmailTaskFactory(),
+		}
+
+		email := "user@example.com"
+		userID := uuid.New()
+		user := &models.User{
+			BaseModel: models.BaseModel{ID: userID},
+			Email:     email,
+			FullName:  "Test User",
+		}
+
+		userRepository.On("GetUserByEmail", ctx, email).Return(user, nil)
+		cacheService.On("Get", ctx, getSignInCodeAttemptsKey(userID), mock.AnythingOfType("*int64")).Return(cache.ErrCacheMiss)
+		cacheService.On("Set", ctx, getSignInCodeKey(userID), mock.AnythingOfType("models.SignInCode"), mock.Anything).Return(nil)
+		outboxRepository.On("CreateMessages", mock.Anything, emailOutbox()).Return(nil)
+
+		err := authService.SignInWithCode(ctx, email)
+
+		assert.NoError(t, err)
+		cacheService.AssertCalled(t, "Set", ctx, getSignInCodeKey(userID), mock.AnythingOfType("models.SignInCode"), 10*time.Minute)
+		outboxRepository.AssertCalled(t, "CreateMessages", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return error when user not found", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		userRepository := &mocks.UserRepository{}
+
+		authService := &authService{
+			cacheService:    cacheService,
+			userRespository: userRepository,
+		}
+
+		email := "user@example.com"
+
+		userRepository.On("GetUserByEmail", ctx, email).Return(nil, nil)
+
+		err := authService.SignInWithCode(ctx, email)
+
+		assert.ErrorIs(t, err, models.ErrUserNotFound)
+		cacheService.AssertNotCalled(t, "Set", ctx, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should not send a new code after too many invalid attempts", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		outboxRepository := &mocks.OutboxRepository{}
+		userRepository := &mocks.UserRepository{}
+
+		authService := &authService{
+			cacheService:     cacheService,
+			outboxRepository: outboxRepository,
+			userRespository:  userRepository,
+		}
+
+		email := "user@example.com"
+		userID := uuid.New()
+		user := &models.User{
+			BaseModel: models.BaseModel{ID: userID},
+			Email:     email,
+		}
+
+		userRepository.On("GetUserByEmail", ctx, email).Return(user, nil)
+		cacheService.On("Get", ctx, getSignInCodeAttemptsKey(userID), mock.AnythingOfType("*int64")).Run(func(args mock.Arguments) {
+			*(args.Get(2).(*int64)) = maxSignInCodeAttempts
+		}).Return(nil)
+
+		err := authService.SignInWithCode(ctx, email)
+
+		assert.ErrorIs(t, err, models.ErrTooManySignInAttempts)
+		cacheService.AssertNotCalled(t, "Set", ctx, mock.Anything, mock.Anything, mock.Anything)
+		outboxRepository.AssertNotCalled(t, "CreateMessages", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthService_VerifySignInCode(t *testing.T) {
+	ctx := context.Background()
+
+	email := "user@example.com"
+	code := "123456"
+
+	t.Run("should verify sign-in code successfully", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		userRepository := &mocks.UserRepository{}
+		sessionService := &mocks.SessionService{}
+
+		authService := &authService{
+			cacheService:    cacheService,
+			userRespository: userRepository,
+			sessionService:  sessionService,
+		}
+
+		userID := uuid.New()
+		user := &models.User{
+			BaseModel: models.BaseModel{ID: userID},
+			Email:     email,
+			Role:      models.Customer,
+		}
+
+		userRepository.On("GetUserByEmail", ctx, email).Return(user, nil)
+		cacheService.On("Increment", ctx, getSignInCodeAttemptsKey(userID), 15*time.Minute).Return(int64(1), nil)
+		cacheService.On("Get", ctx, getSignInCodeKey(userID), mock.AnythingOfType("*models.SignInCode")).Run(func(args mock.Arguments) {
+			*(args.Get(2).(*models.SignInCode)) = models.SignInCode{
+				UserID:    userID,
+				Hash:      hashSignInCode(userID, code),
+				ExpiresAt: time.Now().Add(5 * time.Minute).Unix(),
+			}
+		}).Return(nil)
+		cacheService.On("Delete", ctx, getSignInCodeKey(userID)).Return(nil)
+		cacheService.On("Delete", ctx, getSignInCodeAttemptsKey(userID)).Return(nil)
+		sessionService.On("CreateSession", ctx, userID, (*uuid.UUID)(nil), user.Role, (*models.MemberRole)(nil)).Return(&models.Session{
+			Token: "session_token",
+		}, nil)
+
+		token, err := authService.VerifySignInCode(ctx, models.VerifySignInCodePayload{Email: email, Code: code})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "session_token", token.Token)
+		cacheService.AssertCalled(t, "Delete", ctx, getSignInCodeKey(userID))
+		cacheService.AssertCalled(t, "Delete", ctx, getSignInCodeAttemptsKey(userID))
+	})
+
+	t.Run("should count the attempt when code is invalid", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		userRepository := &mocks.UserRepository{}
+		sessionService := &mocks.SessionService{}
+
+		authService := &authService{
+			cacheService:    cacheService,
+			userRespository: userRepository,
+			sessionService:  sessionService,
+		}
+
+		userID := uuid.New()
+		user := &models.User{BaseModel: models.BaseModel{ID: userID}, Email: email}
+
+		userRepository.On("GetUserByEmail", ctx, email).Return(user, nil)
+		cacheService.On("Increment", ctx, getSignInCodeAttemptsKey(userID), mock.Anything).Return(int64(1), nil)
+		cacheService.On("Get", ctx, getSignInCodeKey(userID), mock.AnythingOfType("*models.SignInCode")).Run(func(args mock.Arguments) {
+			*(args.Get(2).(*models.SignInCode)) = models.SignInCode{
+				UserID:    userID,
+				Hash:      hashSignInCode(userID, code),
+				ExpiresAt: time.Now().Add(5 * time.Minute).Unix(),
+			}
+		}).Return(nil)
+
+		token, err := authService.VerifySignInCode(ctx, models.VerifySignInCodePayload{Email: email, Code: "654321"})
+
+		assert.ErrorIs(t, err, models.ErrInvalidSignInCode)
+		assert.Empty(t, token)
+		cacheService.AssertExpectations(t)
+		sessionService.AssertNotCalled(t, "CreateSession", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should lock sign-in by code after too many invalid attempts", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		userRepository := &mocks.UserRepository{}
+
+		authService := &authService{
+			cacheService:    cacheService,
+			userRespository: userRepository,
+		}
+
+		userID := uuid.New()
+		user := &models.User{BaseModel: models.BaseModel{ID: userID}, Email: email}
+
+		userRepository.On("GetUserByEmail", ctx, email).Return(user, nil)
+		cacheService.On("Increment", ctx, getSignInCodeAttemptsKey(userID), mock.Anything).Return(int64(maxSignInCodeAttempts), nil)
+		cacheService.On("Get", ctx, getSignInCodeKey(userID), mock.AnythingOfType("*models.SignInCode")).Run(func(args mock.Arguments) {
+			*(args.Get(2).(*models.SignInCode)) = models.SignInCode{
+				UserID:    userID,
+				Hash:      hashSignInCode(userID, code),
+				ExpiresAt: time.Now().Add(5 * time.Minute).Unix(),
+			}
+		}).Return(nil)
+		cacheService.On("Delete", ctx, getSignInCodeKey(userID)).Return(nil)
+
+		_, err := authService.VerifySignInCode(ctx, models.VerifySignInCodePayload{Email: email, Code: "654321"})
+
+		assert.ErrorIs(t, err, models.ErrTooManySignInAttempts)
+		cacheService.AssertExpectations(t)
+	})
+
+	t.Run("should not compare the code once the attempts are spent", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		userRepository := &mocks.UserRepository{}
+		sessionService := &mocks.SessionService{}
+
+		authService := &authService{
+			cacheService:    cacheService,
+			userRespository: userRepository,
+			sessionService:  sessionService,
+		}
+
+		userID := uuid.New()
+		user := &models.User{BaseModel: models.BaseModel{ID: userID}, Email: email}
+
+		userRepository.On("GetUserByEmail", ctx, email).Return(user, nil)
+		cacheService.On("Increment", ctx, getSignInCodeAttemptsKey(userID), mock.Anything).Return(int64(maxSignInCodeAttempts+1), nil)
+
+		_, err := authService.VerifySignInCode(ctx, models.VerifySignInCodePayload{Email: email, Code: code})
+
+		assert.ErrorIs(t, err, models.ErrTooManySignInAttempts)
+		cacheService.AssertNotCalled(t, "Get", ctx, getSignInCodeKey(userID), mock.Anything)
+		sessionService.AssertNotCalled(t, "CreateSession", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return error when code is not found", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		userRepository := &mocks.UserRepository{}
+
+		authService := &authService{
+			cacheService:    cacheService,
+			userRespository: userRepository,
+		}
+
+		userID := uuid.New()
+		user := &models.User{BaseModel: models.BaseModel{ID: userID}, Email: email}
+
+		userRepository.On("GetUserByEmail", ctx, email).Return(user, nil)
+		cacheService.On("Increment", ctx, getSignInCodeAttemptsKey(userID), mock.Anything).Return(int64(1), nil)
+		cacheService.On("Get", ctx, getSignInCodeKey(userID), mock.AnythingOfType("*models.SignInCode")).Return(cache.ErrCacheMiss)
+
+		_, err := authService.VerifySignInCode(ctx, models.VerifySignInCodePayload{Email: email, Code: code})
+
+		assert.ErrorIs(t, err, models.ErrSignInCodeNotFound)
+	})
+
+	t.Run("should return invalid code when user does not exist", func(t *testing.T) {
+		userRepository := &mocks.UserRepository{}
+
+		authService := &authService{
+			userRespository: userRepository,
+		}
+
+		userRepository.On("GetUserByEmail", ctx, email).Return(nil, nil)
+
+		_, err := authService.VerifySignInCode(ctx, models.VerifySignInCodePayload{Email: email, Code: code})
+
+		assert.ErrorIs(t, err, models.ErrInvalidSignInCode)
+	})
+}
+
+func emailOutbox() any {
+	return mock.MatchedBy(func(messages []models.OutboxMessage) bool {
+		return len(messages) == 1 && messages[0].Queue == QueueSendEmail
+	})
+}
@@ -1,6 +1,9 @@
 package email
 
 import (
+	"fmt"
+	"strconv"
+
 	"github.com/G-Villarinho/food-shop-api/models"
 )
 
@@ -21,3 +24,31 @@ func (f *EmailFactory) CreateSignInMagicLinkEmail(to string, name string, magicL
 		},
 	}
 }
+
+func (f *EmailFactory) CreateSignInCodeEmail(to string, name string, code string, expiresIn int) models.EmailQueueTask {
+	return models.EmailQueueTask{
+		To:       []string{to},
+		Subject:  "Your Level Up sign-in code",
+		Template: models.SignInOneTimeCode,
+		Params: map[string]string{
+			"code":       code,
+			"name":       name,
+			"expires_in": strconv.Itoa(expiresIn),
+		},
+	}
+}
+
+func (f *EmailFactory) CreateRestaurantInvitationEmail(to string, name string, restaurantName string, role string, invitationLink string, expiresIn int) models.EmailQueueTask {
+	return models.EmailQueueTask{
+		To:       []string{to},
+		Subject:  fmt.Sprintf("You have been invited to join %s", restaurantName),
+		Template: models.RestaurantInvitation,
+		Params: map[string]string{
+			"invitation_link": invitationLink,
+			"name":            name,
+			"restaurant_name": restaurantName,
+			"role":            role,
+			"expires_in":      strconv.Itoa(expiresIn),
+		},
+	}
+}
@@ -59,16 +59,23 @@ func (e *evaluationService) CreateEvaluation(ctx context.Context, payload models
 	}
 
 	evaluation := payload.ToEvaluation(custommerID)
-	if err := e.evaluationRepository.CreateEvaluation(ctx, *evaluation); err != nil {
+	response := evaluation.ToEvaluationResponse()
+
+	outbox, err := newWebhookEventOutbox(restaurant.ID, models.EvaluationCreatedEvent, response)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.evaluationRepository.CreateEvaluation(ctx, *evaluation, outbox); err != nil {
 		return nil, fmt.Errorf("create evaluation: %w", err)
 	}
 
-	return evaluation.ToEvaluationResponse(), nil
+	return response, nil
 }
 
 func (e *evaluationService) GetPaginatedEvaluationsByRestaurantID(ctx context.Context, pagination *models.EvaluationPagination) (*models.PaginatedResponse[*models.EvaluationResponse], error) {
 	restaurantID, ok := ctx.Value(internal.RestaurantIDKey).(*uuid.UUID)
-	if !ok {
+	if !ok || restaurantID == nil {
 		return nil, models.ErrRestaurantNotFound
 	}
 
@@ -90,7 +97,7 @@ func (e *evaluationService) GetPaginatedEvaluationsByRestaurantID(ctx context.Co
 
 func (e *evaluationService) UpdateAnswer(ctx context.Context, payload models.UpdateAnswerPayload) (*models.EvaluationResponse, error) {
 	restaurantID, ok := ctx.Value(internal.RestaurantIDKey).(*uuid.UUID)
-	if !ok {
+	if !ok || restaurantID == nil {
 		return nil, models.ErrRestaurantNotFound
 	}
 
@@ -119,7 +126,7 @@ func (e *evaluationService) UpdateAnswer(ctx context.Context, payload models.Upd
 
 func (e *evaluationService) GetEvaluationSumary(ctx context.Context) (*models.EvaluationSummaryResponse, error) {
 	restaurantID, ok := ctx.Value(internal.RestaurantIDKey).(*uuid.UUID)
-	if !ok {
+	if !ok || restaurantID == nil {
 		return nil, models.ErrRestaurantNotFound
 	}
 
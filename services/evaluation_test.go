@@ -33,14 +33,14 @@ func TestEvaluationService_CreateEvaluation(t *testing.T) {
 			Comment:      "Excellent food!",
 		}
 
-		restaurantRepository.On("GetRestaurantByID", ctx, restaurantID).Return(&models.Restaurant{}, nil)
+		restaurantRepository.On("GetRestaurantByID", ctx, restaurantID).Return(&models.Restaurant{BaseModel: models.BaseModel{ID: restaurantID}}, nil)
 
 		evaluationRepository.On("CreateEvaluation", ctx, mock.MatchedBy(func(evaluation models.Evaluation) bool {
 			return evaluation.CustommerID == custommerID &&
 				evaluation.RestaurantID == restaurantID &&
 				evaluation.Rating == payload.Rating &&
 				evaluation.Comment == payload.Comment
-		})).Return(nil)
+		}), webhookEventOutbox(restaurantID, models.EvaluationCreatedEvent)).Return(nil)
 
 		response, err := evaluationService.CreateEvaluation(ctx, payload)
 
@@ -50,7 +50,7 @@ func TestEvaluationService_CreateEvaluation(t *testing.T) {
 		assert.Equal(t, payload.Comment, response.Comment)
 
 		restaurantRepository.AssertCalled(t, "GetRestaurantByID", ctx, restaurantID)
-		evaluationRepository.AssertCalled(t, "CreateEvaluation", ctx, mock.AnythingOfType("models.Evaluation"))
+		evaluationRepository.AssertCalled(t, "CreateEvaluation", ctx, mock.AnythingOfType("models.Evaluation"), mock.Anything)
 	})
 
 	t.Run("should return error when user ID is not in context", func(t *testing.T) {
@@ -76,7 +76,7 @@ func TestEvaluationService_CreateEvaluation(t *testing.T) {
 		assert.ErrorIs(t, err, models.ErrUserNotFoundInContext)
 
 		restaurantRepository.AssertNotCalled(t, "GetRestaurantByID", mock.Anything, mock.Anything)
-		evaluationRepository.AssertNotCalled(t, "CreateEvaluation", mock.Anything, mock.Anything)
+		evaluationRepository.AssertNotCalled(t, "CreateEvaluation", mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when restaurant is not found", func(t *testing.T) {
@@ -104,7 +104,7 @@ func TestEvaluationService_CreateEvaluation(t *testing.T) {
 		assert.ErrorIs(t, err, models.ErrRestaurantNotFound)
 
 		restaurantRepository.AssertCalled(t, "GetRestaurantByID", ctx, restaurantID)
-		evaluationRepository.AssertNotCalled(t, "CreateEvaluation", mock.Anything, mock.Anything)
+		evaluationRepository.AssertNotCalled(t, "CreateEvaluation", mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when repository fails to create evaluation", func(t *testing.T) {
@@ -131,7 +131,7 @@ func TestEvaluationService_CreateEvaluation(t *testing.T) {
 				evaluation.RestaurantID == restaurantID &&
 				evaluation.Rating == payload.Rating &&
 				evaluation.Comment == payload.Comment
-		})).Return(errors.New("database error"))
+		}), mock.Anything).Return(errors.New("database error"))
 
 		response, err := evaluationService.CreateEvaluation(ctx, payload)
 
@@ -140,7 +140,7 @@ func TestEvaluationService_CreateEvaluation(t *testing.T) {
 		assert.Contains(t, err.Error(), "create evaluation")
 
 		restaurantRepository.AssertCalled(t, "GetRestaurantByID", ctx, restaurantID)
-		evaluationRepository.AssertCalled(t, "CreateEvaluation", ctx, mock.AnythingOfType("models.Evaluation"))
+		evaluationRepository.AssertCalled(t, "CreateEvaluation", ctx, mock.AnythingOfType("models.Evaluation"), mock.Anything)
 	})
 }
 
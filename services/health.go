@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/client"
+	"github.com/G-Villarinho/food-shop-api/config"
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/go-redis/redis/v8"
+	"gorm.io/gorm"
+)
+
+const healthCheckTimeout = 2 * time.Second
+
+//go:generate mockery --name=HealthService --output=../mocks --outpkg=mocks
+type HealthService interface {
+	Check(ctx context.Context) *models.HealthResponse
+}
+
+type healthService struct {
+	di             *internal.Di
+	db             *gorm.DB
+	redisClient    *redis.Client
+	rabbitMQClient client.RabbitMQClient
+}
+
+func NewHealthService(di *internal.Di) (HealthService, error) {
+	db, err := internal.Invoke[*gorm.DB](di)
+	if err != nil {
+		return nil, err
+	}
+
+	// The in-memory cache has no Redis connection to check.
+	var redisClient *redis.Client
+	if config.Env.Cache.Driver != config.MemoryDriver {
+		redisClient, err = internal.Invoke[*redis.Client](di)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rabbitMQClient, err := internal.Invoke[client.RabbitMQClient](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &healthService{
+		di:             di,
+		db:             db,
+		redisClient:    redisClient,
+		rabbitMQClient: rabbitMQClient,
+	}, nil
+}
+
+func (h *healthService) Check(ctx context.Context) *models.HealthResponse {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	response := &models.HealthResponse{
+		Status: models.HealthUp,
+		Checks: map[string]models.HealthStatus{
+			"database": healthStatus(h.pingDatabase(ctx)),
+			"rabbitmq": healthStatus(h.rabbitMQClient.IsConnected()),
+		},
+	}
+
+	if h.redisClient != nil {
+		response.Checks["redis"] = healthStatus(h.redisClient.Ping(ctx).Err() == nil)
+	}
+
+	for _, status := range response.Checks {
+		if status == models.HealthDown {
+			response.Status = models.HealthDown
+		}
+	}
+
+	return response
+}
+
+func (h *healthService) pingDatabase(ctx context.Context) bool {
+	sqlDB, err := h.db.DB()
+	if err != nil {
+		return false
+	}
+
+	return sqlDB.PingContext(ctx) == nil
+}
+
+func healthStatus(up bool) models.HealthStatus {
+	if up {
+		return models.HealthUp
+	}
+
+	return models.HealthDown
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/G-Villarinho/food-shop-api/config"
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/models"
+)
+
+var ErrUnknownKeyID = errors.New("unknown signing key id")
+
+//go:generate mockery --name=KeyRing --output=../mocks --outpkg=mocks
+type KeyRing interface {
+	SigningKey() (string, *ecdsa.PrivateKey)
+	VerificationKeys(keyID string) ([]*ecdsa.PublicKey, error)
+	JWKS() *models.JWKSResponse
+}
+
+// keyRing signs with PRIVATE_KEY and accepts tokens signed by any key in
+// PUBLIC_KEY or PREVIOUS_PUBLIC_KEYS. Key IDs are JWK thumbprints (RFC 7638),
+// so rotating only means moving the old public key to PREVIOUS_PUBLIC_KEYS.
+type keyRing struct {
+	di               *internal.Di
+	signingKeyID     string
+	signingKey       *ecdsa.PrivateKey
+	keyIDs           []string
+	verificationKeys map[string]*ecdsa.PublicKey
+}
+
+func NewKeyRing(di *internal.Di) (KeyRing, error) {
+	ring, err := newKeyRing(config.Env.PrivateKey, config.Env.PublicKey, config.Env.PreviousPublicKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	ring.di = di
+	return ring, nil
+}
+
+func newKeyRing(privateKeyPEM string, publicKeysPEM ...string) (*keyRing, error) {
+	signingKey, err := parseECPrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	ring := &keyRing{
+		signingKey:       signingKey,
+		verificationKeys: make(map[string]*ecdsa.PublicKey),
+	}
+
+	signingKeyID, err := ring.add(&signingKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	ring.signingKeyID = signingKeyID
+
+	for _, keysPEM := range publicKeysPEM {
+		publicKeys, err := parseECPublicKeys(keysPEM)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, publicKey := range publicKeys {
+			if _, err := ring.add(publicKey); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return ring, nil
+}
+
+func (k *keyRing) SigningKey() (string, *ecdsa.PrivateKey) {
+	return k.signingKeyID, k.signingKey
+}
+
+// VerificationKeys returns the keys that may have signed a token with the given
+// kid. Tokens issued before key IDs were introduced have no kid header, so
+// they are checked against every key in the ring, starting with the signing key.
+func (k *keyRing) VerificationKeys(keyID string) ([]*ecdsa.PublicKey, error) {
+	if keyID == "" {
+		publicKeys := make([]*ecdsa.PublicKey, 0, len(k.keyIDs))
+		for _, keyID := range k.keyIDs {
+			publicKeys = append(publicKeys, k.verificationKeys[keyID])
+		}
+
+		return publicKeys, nil
+	}
+
+	publicKey, ok := k.verificationKeys[keyID]
+	if !ok {
+		return nil, ErrUnknownKeyID
+	}
+
+	return []*ecdsa.PublicKey{publicKey}, nil
+}
+
+func (k *keyRing) JWKS() *models.JWKSResponse {
+	response := &models.JWKSResponse{Keys: make([]models.JWK, 0, len(k.keyIDs))}
+	for _, keyID := range k.keyIDs {
+		response.Keys = append(response.Keys, toJWK(keyID, k.verificationKeys[keyID]))
+	}
+
+	return response
+}
+
+func (k *keyRing) add(publicKey *ecdsa.PublicKey) (string, error) {
+	if publicKey.Curve != elliptic.P256() {
+		return "", errors.New("only P-256 keys are supported")
+	}
+
+	keyID := keyThumbprint(publicKey)
+	if _, ok := k.verificationKeys[keyID]; !ok {
+		k.verificationKeys[keyID] = publicKey
+		k.keyIDs = append(k.keyIDs, keyID)
+	}
+
+	return keyID, nil
+}
+
+func toJWK(keyID string, publicKey *ecdsa.PublicKey) models.JWK {
+	x, y := encodeCoordinates(publicKey)
+	return models.JWK{
+		Kty: "EC",
+		Crv: "P-256",
+		X:   x,
+		Y:   y,
+		Kid: keyID,
+		Use: "sig",
+		Alg: "ES256",
+	}
+}
+
+func keyThumbprint(publicKey *ecdsa.PublicKey) string {
+	x, y := encodeCoordinates(publicKey)
+	hash := sha256.Sum256([]byte(fmt.Sprintf(`{"crv":"P-256","kty":"EC","x":"%s","y":"%s"}`, x, y)))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+func encodeCoordinates(publicKey *ecdsa.PublicKey) (string, string) {
+	x := make([]byte, 32)
+	y := make([]byte, 32)
+	publicKey.X.FillBytes(x)
+	publicKey.Y.FillBytes(y)
+	return base64.RawURLEncoding.EncodeToString(x), base64.RawURLEncoding.EncodeToString(y)
+}
+
+func parseECPrivateKey(pemKey string) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(pemKey))
+	if block == nil || block.Type != "EC PRIVATE KEY" {
+		return nil, errors.New("failed to parse EC private key")
+	}
+
+	privateKey, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return privateKey, nil
+}
+
+// parseECPublicKeys accepts any number of concatenated PEM blocks.
+func parseECPublicKeys(pemKeys string) ([]*ecdsa.PublicKey, error) {
+	var publicKeys []*ecdsa.PublicKey
+
+	rest := []byte(pemKeys)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "PUBLIC KEY" {
+			return nil, errors.New("failed to parse EC public key")
+		}
+
+		publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		ecdsaPubKey, ok := publicKey.(*ecdsa.PublicKey)
+		if !ok {
+			return nil, errors.New("key is not a valid ECDSA public key")
+		}
+
+		publicKeys = append(publicKeys, ecdsaPubKey)
+	}
+
+	if len(bytes.TrimSpace(rest)) > 0 {
+		return nil, errors.New("failed to parse EC public key")
+	}
+
+	return publicKeys, nil
+}
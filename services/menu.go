@@ -2,9 +2,11 @@ package services
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/G-Villarinho/food-shop-api/internal"
 	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/G-Villarinho/food-shop-api/repositories"
 	"github.com/google/uuid"
 )
 
@@ -13,8 +15,9 @@ type MenuService interface {
 }
 
 type menuService struct {
-	di             *internal.Di
-	productService ProductService
+	di               *internal.Di
+	productService   ProductService
+	outboxRepository repositories.OutboxRepository
 }
 
 func NewMenuService(di *internal.Di) (MenuService, error) {
@@ -23,15 +26,21 @@ func NewMenuService(di *internal.Di) (MenuService, error) {
 		return nil, err
 	}
 
+	outboxRepository, err := internal.Invoke[repositories.OutboxRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
 	return &menuService{
-		di:             di,
-		productService: productService,
+		di:               di,
+		productService:   productService,
+		outboxRepository: outboxRepository,
 	}, nil
 }
 
 func (m *menuService) UpdateMenu(ctx context.Context, payload *models.UpdateMenuPayload) error {
 	restaurantID, ok := ctx.Value(internal.RestaurantIDKey).(*uuid.UUID)
-	if !ok {
+	if !ok || restaurantID == nil {
 		return models.ErrRestaurantNotFound
 	}
 
@@ -63,5 +72,20 @@ func (m *menuService) UpdateMenu(ctx context.Context, payload *models.UpdateMenu
 		return err
 	}
 
+	data := models.MenuUpdatedEventData{
+		CreatedProducts:   len(newProducts),
+		UpdatedProducts:   len(updatedProducts),
+		DeletedProductIDs: payload.DeletedProductIDs,
+	}
+
+	outbox, err := newWebhookEventOutbox(*restaurantID, models.MenuUpdatedEvent, data)
+	if err != nil {
+		return err
+	}
+
+	if err := m.outboxRepository.CreateMessages(ctx, outbox); err != nil {
+		return fmt.Errorf("create outbox messages: %w", err)
+	}
+
 	return nil
 }
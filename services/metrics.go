@@ -13,6 +13,7 @@ import (
 
 type MetricsService interface {
 	GetMonthOrdersAmount(ctx context.Context) (*models.MonthlyMetricsResponse, error)
+	GetMonthTips(ctx context.Context) (*models.MonthlyTipsMetricsResponse, error)
 }
 
 type metricsService struct {
@@ -34,7 +35,7 @@ func NewMetricsService(di *internal.Di) (MetricsService, error) {
 
 func (m *metricsService) GetMonthOrdersAmount(ctx context.Context) (*models.MonthlyMetricsResponse, error) {
 	restaurantID, ok := ctx.Value(internal.RestaurantIDKey).(*uuid.UUID)
-	if !ok {
+	if !ok || restaurantID == nil {
 		return nil, models.ErrRestaurantNotFound
 	}
 
@@ -75,3 +76,46 @@ func (m *metricsService) GetMonthOrdersAmount(ctx context.Context) (*models.Mont
 
 	return monthlyMetricsResponse, nil
 }
+
+func (m *metricsService) GetMonthTips(ctx context.Context) (*models.MonthlyTipsMetricsResponse, error) {
+	restaurantID, ok := ctx.Value(internal.RestaurantIDKey).(*uuid.UUID)
+	if !ok || restaurantID == nil {
+		return nil, models.ErrRestaurantNotFound
+	}
+
+	tipsPerMonth, err := m.orderRepository.GetTipsPerMonth(ctx, *restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("get tips per month: %w", err)
+	}
+
+	// Going back from the first day of the month, as on the 31st AddDate would
+	// land in the current month again.
+	today := time.Now().UTC()
+	currentMonth := today.Format("2006-01")
+	lastMonth := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0).Format("2006-01")
+
+	var currentMonthTips models.TipsPerMonth
+	var lastMonthTips models.TipsPerMonth
+
+	for _, tips := range tipsPerMonth {
+		switch tips.MonthWithYear {
+		case currentMonth:
+			currentMonthTips = tips
+		case lastMonth:
+			lastMonthTips = tips
+		}
+	}
+
+	var diffFromLastMonth float64
+	if lastMonthTips.TotalInCents > 0 {
+		diffFromLastMonth = (float64(currentMonthTips.TotalInCents) * 100 / float64(lastMonthTips.TotalInCents)) - 100
+	}
+
+	monthlyTipsMetricsResponse := &models.MonthlyTipsMetricsResponse{
+		TotalInCents:      currentMonthTips.TotalInCents,
+		OrdersWithTip:     currentMonthTips.Amount,
+		DiffFromLastMonth: diffFromLastMonth,
+	}
+
+	return monthlyTipsMetricsResponse, nil
+}
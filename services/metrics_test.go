@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/mocks"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMetricsService_GetMonthTips(t *testing.T) {
+	today := time.Now().UTC()
+	currentMonth := today.Format("2006-01")
+	lastMonth := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0).Format("2006-01")
+
+	t.Run("should return tips of the current month compared to the last one", func(t *testing.T) {
+		orderRepository := &mocks.OrderRepository{}
+
+		metricsService := &metricsService{
+			orderRepository: orderRepository,
+		}
+
+		restaurantID := uuid.New()
+		ctx := context.WithValue(context.Background(), internal.RestaurantIDKey, &restaurantID)
+
+		orderRepository.On("GetTipsPerMonth", ctx, restaurantID).Return([]models.TipsPerMonth{
+			{MonthWithYear: lastMonth, TotalInCents: 2000, Amount: 4},
+			{MonthWithYear: currentMonth, TotalInCents: 3000, Amount: 5},
+		}, nil)
+
+		response, err := metricsService.GetMonthTips(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &models.MonthlyTipsMetricsResponse{
+			TotalInCents:      3000,
+			OrdersWithTip:     5,
+			DiffFromLastMonth: 50,
+		}, response)
+	})
+
+	t.Run("should not compare when the last month has no tips", func(t *testing.T) {
+		orderRepository := &mocks.OrderRepository{}
+
+		metricsService := &metricsService{
+			orderRepository: orderRepository,
+		}
+
+		restaurantID := uuid.New()
+		ctx := context.WithValue(context.Background(), internal.RestaurantIDKey, &restaurantID)
+
+		orderRepository.On("GetTipsPerMonth", ctx, restaurantID).Return([]models.TipsPerMonth{
+			{MonthWithYear: currentMonth, TotalInCents: 3000, Amount: 5},
+		}, nil)
+
+		response, err := metricsService.GetMonthTips(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3000, response.TotalInCents)
+		assert.Zero(t, response.DiffFromLastMonth)
+	})
+
+	t.Run("should not divide by zero when the last month total is zero", func(t *testing.T) {
+		orderRepository := &mocks.OrderRepository{}
+
+		metricsService := &metricsService{
+			orderRepository: orderRepository,
+		}
+
+		restaurantID := uuid.New()
+		ctx := context.WithValue(context.Background(), internal.RestaurantIDKey, &restaurantID)
+
+		orderRepository.On("GetTipsPerMonth", ctx, restaurantID).Return([]models.TipsPerMonth{
+			{MonthWithYear: lastMonth, TotalInCents: 0, Amount: 0},
+			{MonthWithYear: currentMonth, TotalInCents: 3000, Amount: 5},
+		}, nil)
+
+		response, err := metricsService.GetMonthTips(ctx)
+
+		assert.NoError(t, err)
+		assert.Zero(t, response.DiffFromLastMonth)
+	})
+
+	t.Run("should return zero when there are no tips", func(t *testing.T) {
+		orderRepository := &mocks.OrderRepository{}
+
+		metricsService := &metricsService{
+			orderRepository: orderRepository,
+		}
+
+		restaurantID := uuid.New()
+		ctx := context.WithValue(context.Background(), internal.RestaurantIDKey, &restaurantID)
+
+		orderRepository.On("GetTipsPerMonth", ctx, restaurantID).Return(nil, nil)
+
+		response, err := metricsService.GetMonthTips(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &models.MonthlyTipsMetricsResponse{}, response)
+	})
+
+	t.Run("should return error when restaurant ID is not in context", func(t *testing.T) {
+		orderRepository := &mocks.OrderRepository{}
+
+		metricsService := &metricsService{
+			orderRepository: orderRepository,
+		}
+
+		response, err := metricsService.GetMonthTips(context.Background())
+
+		assert.ErrorIs(t, err, models.ErrRestaurantNotFound)
+		assert.Nil(t, response)
+
+		orderRepository.AssertNotCalled(t, "GetTipsPerMonth", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		orderRepository := &mocks.OrderRepository{}
+
+		metricsService := &metricsService{
+			orderRepository: orderRepository,
+		}
+
+		restaurantID := uuid.New()
+		ctx := context.WithValue(context.Background(), internal.RestaurantIDKey, &restaurantID)
+
+		orderRepository.On("GetTipsPerMonth", ctx, restaurantID).Return(nil, errors.New("database error"))
+
+		response, err := metricsService.GetMonthTips(ctx)
+
+		assert.Error(t, err)
+		assert.Nil(t, response)
+	})
+}
@@ -3,7 +3,9 @@ package services
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/G-Villarinho/food-shop-api/config"
 	"github.com/G-Villarinho/food-shop-api/internal"
 	"github.com/G-Villarinho/food-shop-api/models"
 	"github.com/G-Villarinho/food-shop-api/repositories"
@@ -16,8 +18,11 @@ type OrderService interface {
 	GetPaginatedOrdersByRestaurantID(ctx context.Context, pagination *models.OrderPagination) (*models.PaginatedResponse[*models.OrderResponse], error)
 	CancelOrder(ctx context.Context, orderID uuid.UUID) error
 	ApproveOrder(ctx context.Context, orderID uuid.UUID) error
-	DispatchOrder(ctx context.Context, orderID uuid.UUID) error
+	DispatchOrder(ctx context.Context, orderID uuid.UUID, courierID *uuid.UUID) error
 	DeliverOrder(ctx context.Context, orderID uuid.UUID) error
+	ReleaseScheduledOrders(ctx context.Context) (int64, error)
+	GetPaginatedDeliveriesByCourierID(ctx context.Context, pagination *models.DeliveryPagination) (*models.PaginatedResponse[*models.DeliveryResponse], error)
+	CompleteDelivery(ctx context.Context, orderID uuid.UUID) error
 }
 
 type orderService struct {
@@ -26,6 +31,7 @@ type orderService struct {
 	orderRepository      repositories.OrderRepository
 	productRepository    repositories.ProductRepository
 	restaurantRepository repositories.RestaurantRepository
+	userRepository       repositories.UserRepository
 }
 
 func NewOrderService(di *internal.Di) (OrderService, error) {
@@ -49,12 +55,18 @@ func NewOrderService(di *internal.Di) (OrderService, error) {
 		return nil, err
 	}
 
+	userRepository, err := internal.Invoke[repositories.UserRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
 	return &orderService{
 		di:                   di,
 		orderItemService:     orderItemService,
 		orderRepository:      orderRepository,
 		productRepository:    productRepository,
 		restaurantRepository: restaurantRepository,
+		userRepository:       userRepository,
 	}, nil
 }
 
@@ -74,8 +86,26 @@ func (o *orderService) CreateOrder(ctx context.Context, custommerID, restaurantI
 		return models.ErrSomeProductsNotFound
 	}
 
-	order := models.NewOrder(custommerID, restaurantID, orderItemSummary.TotalInCents)
-	if err := o.orderRepository.CreateOrderWithItems(ctx, order, orderItemSummary.OrderItems); err != nil {
+	tipInCents, err := payload.Tip.CalculateTipInCents(orderItemSummary.TotalInCents)
+	if err != nil {
+		return err
+	}
+
+	order := models.NewOrder(custommerID, restaurantID, orderItemSummary.TotalInCents, tipInCents)
+	if payload.ScheduledFor != nil {
+		order.Schedule(*payload.ScheduledFor)
+	}
+
+	if payload.DeliveryLocation != nil {
+		order.SetDeliveryLocation(payload.DeliveryLocation)
+	}
+
+	outbox, err := newWebhookEventOutbox(restaurantID, models.OrderCreatedEvent, models.NewOrderCreatedEventData(order))
+	if err != nil {
+		return err
+	}
+
+	if err := o.orderRepository.CreateOrderWithItems(ctx, order, orderItemSummary.OrderItems, outbox); err != nil {
 		return fmt.Errorf("error to create order: %w", err)
 	}
 
@@ -84,7 +114,7 @@ func (o *orderService) CreateOrder(ctx context.Context, custommerID, restaurantI
 
 func (o *orderService) GetPaginatedOrdersByRestaurantID(ctx context.Context, pagination *models.OrderPagination) (*models.PaginatedResponse[*models.OrderResponse], error) {
 	restaurantID, ok := ctx.Value(internal.RestaurantIDKey).(*uuid.UUID)
-	if !ok {
+	if !ok || restaurantID == nil {
 		return nil, models.ErrRestaurantNotFound
 	}
 
@@ -106,7 +136,7 @@ func (o *orderService) GetPaginatedOrdersByRestaurantID(ctx context.Context, pag
 
 func (o *orderService) CancelOrder(ctx context.Context, orderID uuid.UUID) error {
 	restaurantID, ok := ctx.Value(internal.RestaurantIDKey).(*uuid.UUID)
-	if !ok {
+	if !ok || restaurantID == nil {
 		return models.ErrRestaurantNotFound
 	}
 
@@ -123,11 +153,16 @@ func (o *orderService) CancelOrder(ctx context.Context, orderID uuid.UUID) error
 		return models.ErrorOrderDoesNotBelongToRestaurant
 	}
 
-	if order.Status != models.Pending && order.Status != models.Processing {
+	if order.Status != models.Scheduled && order.Status != models.Pending && order.Status != models.Processing {
 		return models.ErrorOrderCannotBeCancelled
 	}
 
-	if err := o.orderRepository.UpdateStatus(ctx, orderID, models.Canceled); err != nil {
+	outbox, err := newStatusChangedOutbox(order, models.Canceled)
+	if err != nil {
+		return err
+	}
+
+	if err := o.orderRepository.UpdateStatus(ctx, orderID, models.Canceled, outbox); err != nil {
 		return fmt.Errorf("update status: %w", err)
 	}
 
@@ -136,7 +171,7 @@ func (o *orderService) CancelOrder(ctx context.Context, orderID uuid.UUID) error
 
 func (o *orderService) ApproveOrder(ctx context.Context, orderID uuid.UUID) error {
 	restaurantID, ok := ctx.Value(internal.RestaurantIDKey).(*uuid.UUID)
-	if !ok {
+	if !ok || restaurantID == nil {
 		return models.ErrRestaurantNotFound
 	}
 
@@ -157,16 +192,21 @@ func (o *orderService) ApproveOrder(ctx context.Context, orderID uuid.UUID) erro
 		return models.ErrOrderCannotBeApproved
 	}
 
-	if err := o.orderRepository.UpdateStatus(ctx, orderID, models.Processing); err != nil {
+	outbox, err := newStatusChangedOutbox(order, models.Processing)
+	if err != nil {
+		return err
+	}
+
+	if err := o.orderRepository.UpdateStatus(ctx, orderID, models.Processing, outbox); err != nil {
 		return fmt.Errorf("update status: %w", err)
 	}
 
 	return nil
 }
 
-func (o *orderService) DispatchOrder(ctx context.Context, orderID uuid.UUID) error {
+func (o *orderService) DispatchOrder(ctx context.Context, orderID uuid.UUID, courierID *uuid.UUID) error {
 	restaurantID, ok := ctx.Value(internal.RestaurantIDKey).(*uuid.UUID)
-	if !ok {
+	if !ok || restaurantID == nil {
 		return models.ErrRestaurantNotFound
 	}
 
@@ -187,7 +227,34 @@ func (o *orderService) DispatchOrder(ctx context.Context, orderID uuid.UUID) err
 		return models.ErrOrderCannotBeDispatched
 	}
 
-	if err := o.orderRepository.UpdateStatus(ctx, orderID, models.Delivering); err != nil {
+	if courierID != nil {
+		courier, err := o.userRepository.GetUserByID(ctx, *courierID)
+		if err != nil {
+			return fmt.Errorf("get user by ID: %w", err)
+		}
+
+		if courier == nil || courier.Role != models.Courier {
+			return models.ErrCourierNotFound
+		}
+
+		outbox, err := newStatusChangedOutbox(order, models.Delivering)
+		if err != nil {
+			return err
+		}
+
+		if err := o.orderRepository.AssignCourier(ctx, orderID, courier.ID, outbox); err != nil {
+			return fmt.Errorf("assign courier: %w", err)
+		}
+
+		return nil
+	}
+
+	outbox, err := newStatusChangedOutbox(order, models.Delivering)
+	if err != nil {
+		return err
+	}
+
+	if err := o.orderRepository.UpdateStatus(ctx, orderID, models.Delivering, outbox); err != nil {
 		return fmt.Errorf("update status: %w", err)
 	}
 
@@ -196,7 +263,7 @@ func (o *orderService) DispatchOrder(ctx context.Context, orderID uuid.UUID) err
 
 func (o *orderService) DeliverOrder(ctx context.Context, orderID uuid.UUID) error {
 	restaurantID, ok := ctx.Value(internal.RestaurantIDKey).(*uuid.UUID)
-	if !ok {
+	if !ok || restaurantID == nil {
 		return models.ErrRestaurantNotFound
 	}
 
@@ -217,9 +284,108 @@ func (o *orderService) DeliverOrder(ctx context.Context, orderID uuid.UUID) erro
 		return models.ErrOrderCannotBeDelivered
 	}
 
-	if err := o.orderRepository.UpdateStatus(ctx, orderID, models.Delivered); err != nil {
+	outbox, err := newStatusChangedOutbox(order, models.Delivered)
+	if err != nil {
+		return err
+	}
+
+	if err := o.orderRepository.UpdateStatus(ctx, orderID, models.Delivered, outbox); err != nil {
+		return fmt.Errorf("update status: %w", err)
+	}
+
+	return nil
+}
+
+func (o *orderService) ReleaseScheduledOrders(ctx context.Context) (int64, error) {
+	releaseBefore := time.Duration(config.Env.Scheduling.ReleaseBefore) * time.Minute
+
+	orders, err := o.orderRepository.GetDueScheduledOrders(ctx, time.Now().UTC().Add(releaseBefore))
+	if err != nil {
+		return 0, fmt.Errorf("get due scheduled orders: %w", err)
+	}
+
+	var released int64
+	for i := range orders {
+		outbox, err := newStatusChangedOutbox(&orders[i], models.Pending)
+		if err != nil {
+			return released, fmt.Errorf("create status changed outbox: %w", err)
+		}
+
+		ok, err := o.orderRepository.ReleaseScheduledOrder(ctx, orders[i].ID, outbox)
+		if err != nil {
+			return released, fmt.Errorf("release scheduled order %s: %w", orders[i].ID, err)
+		}
+
+		if ok {
+			released++
+		}
+	}
+
+	return released, nil
+}
+
+func (o *orderService) GetPaginatedDeliveriesByCourierID(ctx context.Context, pagination *models.DeliveryPagination) (*models.PaginatedResponse[*models.DeliveryResponse], error) {
+	courierID, ok := ctx.Value(internal.UserIDKey).(uuid.UUID)
+	if !ok {
+		return nil, models.ErrUserNotFoundInContext
+	}
+
+	paginatedOrders, err := o.orderRepository.GetPaginatedOrdersByCourierID(ctx, courierID, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("get paginated orders by courier ID: %w", err)
+	}
+
+	if paginatedOrders == nil {
+		return nil, nil
+	}
+
+	paginatedDeliveriesResponse := models.MapPaginatedResult(paginatedOrders, func(order models.Order) *models.DeliveryResponse {
+		return order.ToDeliveryResponse()
+	})
+
+	return paginatedDeliveriesResponse, nil
+}
+
+func (o *orderService) CompleteDelivery(ctx context.Context, orderID uuid.UUID) error {
+	courierID, ok := ctx.Value(internal.UserIDKey).(uuid.UUID)
+	if !ok {
+		return models.ErrUserNotFoundInContext
+	}
+
+	order, err := o.orderRepository.GetOrderByID(ctx, orderID, false)
+	if err != nil {
+		return fmt.Errorf("get order by ID: %w", err)
+	}
+
+	if order == nil {
+		return models.ErrorOrderNotFound
+	}
+
+	if !order.CourierID.Valid || order.CourierID.UUID != courierID {
+		return models.ErrOrderNotAssignedToCourier
+	}
+
+	if order.Status != models.Delivering {
+		return models.ErrOrderCannotBeDelivered
+	}
+
+	outbox, err := newStatusChangedOutbox(order, models.Delivered)
+	if err != nil {
+		return err
+	}
+
+	if err := o.orderRepository.UpdateStatus(ctx, orderID, models.Delivered, outbox); err != nil {
 		return fmt.Errorf("update status: %w", err)
 	}
 
 	return nil
 }
+
+func newStatusChangedOutbox(order *models.Order, status models.OrderStatus) ([]models.OutboxMessage, error) {
+	data := models.OrderStatusChangedEventData{
+		OrderID: order.ID,
+		Status:  status,
+	}
+
+	return newWebhookEventOutbox(order.RestaurantID, models.OrderStatusChangedEvent, data)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/G-Villarinho/food-shop-api/internal"
 	"github.com/G-Villarinho/food-shop-api/mocks"
@@ -56,7 +57,7 @@ func TestOrderService_CreateOrder(t *testing.T) {
 			TotalInCents: 4000,
 		}, nil)
 
-		orderRepository.On("CreateOrderWithItems", mock.Anything, mock.Anything, orderItems).Return(nil)
+		orderRepository.On("CreateOrderWithItems", mock.Anything, mock.Anything, orderItems, webhookEventOutbox(restaurantID, models.OrderCreatedEvent)).Return(nil)
 
 		err := orderService.CreateOrder(context.Background(), custommerID, restaurantID, payload)
 
@@ -64,7 +65,7 @@ func TestOrderService_CreateOrder(t *testing.T) {
 
 		productRepository.AssertCalled(t, "GetProductsByIDsAndRestaurantID", mock.Anything, mock.Anything, restaurantID)
 		orderItemService.AssertCalled(t, "ValidateAndCalculateOrderItems", mock.Anything, products, items)
-		orderRepository.AssertCalled(t, "CreateOrderWithItems", mock.Anything, mock.Anything, orderItems)
+		orderRepository.AssertCalled(t, "CreateOrderWithItems", mock.Anything, mock.Anything, orderItems, mock.Anything)
 	})
 
 	t.Run("should return error when product is not found", func(t *testing.T) {
@@ -98,7 +99,7 @@ func TestOrderService_CreateOrder(t *testing.T) {
 
 		productRepository.AssertCalled(t, "GetProductsByIDsAndRestaurantID", mock.Anything, mock.Anything, restaurantID)
 		orderItemService.AssertNotCalled(t, "ValidateAndCalculateOrderItems", mock.Anything, mock.Anything, mock.Anything)
-		orderRepository.AssertNotCalled(t, "CreateOrderWithItems", mock.Anything, mock.Anything, mock.Anything)
+		orderRepository.AssertNotCalled(t, "CreateOrderWithItems", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when validation of items fails", func(t *testing.T) {
@@ -139,7 +140,142 @@ func TestOrderService_CreateOrder(t *testing.T) {
 
 		productRepository.AssertCalled(t, "GetProductsByIDsAndRestaurantID", mock.Anything, mock.Anything, restaurantID)
 		orderItemService.AssertCalled(t, "ValidateAndCalculateOrderItems", mock.Anything, products, items)
-		orderRepository.AssertNotCalled(t, "CreateOrderWithItems", mock.Anything, mock.Anything, mock.Anything)
+		orderRepository.AssertNotCalled(t, "CreateOrderWithItems", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should store percentage tip separately from total", func(t *testing.T) {
+		orderRepository := &mocks.OrderRepository{}
+		productRepository := &mocks.ProductRepository{}
+		orderItemService := &mocks.OrderItemService{}
+
+		orderService := &orderService{
+			orderRepository:   orderRepository,
+			productRepository: productRepository,
+			orderItemService:  orderItemService,
+		}
+
+		custommerID := uuid.New()
+		restaurantID := uuid.New()
+
+		productID := uuid.New()
+		products := []models.Product{
+			{BaseModel: models.BaseModel{ID: productID}, PriceInCents: 2500},
+		}
+
+		items := []models.CreateOrderItemPayload{
+			{ProductID: productID, Quantity: 2},
+		}
+
+		payload := models.CreateOrderPayload{
+			Items: items,
+			Tip:   &models.CreateOrderTipPayload{Type: models.PercentageTip, Value: 10},
+		}
+
+		orderItems := []models.OrderItem{
+			{ProductID: productID, Quantity: 2, PriceInCents: 2500},
+		}
+
+		productRepository.On("GetProductsByIDsAndRestaurantID", mock.Anything, mock.Anything, restaurantID).Return(products, nil)
+		orderItemService.On("ValidateAndCalculateOrderItems", mock.Anything, products, items).Return(&models.OrderItemSummary{
+			OrderItems:   orderItems,
+			TotalInCents: 5000,
+		}, nil)
+		orderRepository.On("CreateOrderWithItems", mock.Anything, mock.MatchedBy(func(order *models.Order) bool {
+			return order.TotalInCents == 5000 && order.TipInCents == 500
+		}), orderItems, mock.Anything).Return(nil)
+
+		err := orderService.CreateOrder(context.Background(), custommerID, restaurantID, payload)
+
+		assert.NoError(t, err)
+		orderRepository.AssertExpectations(t)
+	})
+
+	t.Run("should return error when tip percentage is greater than 100", func(t *testing.T) {
+		orderRepository := &mocks.OrderRepository{}
+		productRepository := &mocks.ProductRepository{}
+		orderItemService := &mocks.OrderItemService{}
+
+		orderService := &orderService{
+			orderRepository:   orderRepository,
+			productRepository: productRepository,
+			orderItemService:  orderItemService,
+		}
+
+		custommerID := uuid.New()
+		restaurantID := uuid.New()
+
+		productID := uuid.New()
+		products := []models.Product{
+			{BaseModel: models.BaseModel{ID: productID}, PriceInCents: 1000},
+		}
+
+		items := []models.CreateOrderItemPayload{
+			{ProductID: productID, Quantity: 1},
+		}
+
+		payload := models.CreateOrderPayload{
+			Items: items,
+			Tip:   &models.CreateOrderTipPayload{Type: models.PercentageTip, Value: 150},
+		}
+
+		productRepository.On("GetProductsByIDsAndRestaurantID", mock.Anything, mock.Anything, restaurantID).Return(products, nil)
+		orderItemService.On("ValidateAndCalculateOrderItems", mock.Anything, products, items).Return(&models.OrderItemSummary{
+			OrderItems:   []models.OrderItem{{ProductID: productID, Quantity: 1, PriceInCents: 1000}},
+			TotalInCents: 1000,
+		}, nil)
+
+		err := orderService.CreateOrder(context.Background(), custommerID, restaurantID, payload)
+
+		assert.ErrorIs(t, err, models.ErrInvalidTipPercentage)
+		orderRepository.AssertNotCalled(t, "CreateOrderWithItems", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should create scheduled order when scheduledFor is informed", func(t *testing.T) {
+		orderRepository := &mocks.OrderRepository{}
+		productRepository := &mocks.ProductRepository{}
+		orderItemService := &mocks.OrderItemService{}
+
+		orderService := &orderService{
+			orderRepository:   orderRepository,
+			productRepository: productRepository,
+			orderItemService:  orderItemService,
+		}
+
+		custommerID := uuid.New()
+		restaurantID := uuid.New()
+		scheduledFor := time.Now().Add(3 * time.Hour)
+
+		productID := uuid.New()
+		products := []models.Product{
+			{BaseModel: models.BaseModel{ID: productID}, PriceInCents: 1000},
+		}
+
+		items := []models.CreateOrderItemPayload{
+			{ProductID: productID, Quantity: 1},
+		}
+
+		payload := models.CreateOrderPayload{
+			Items:        items,
+			ScheduledFor: &scheduledFor,
+		}
+
+		orderItems := []models.OrderItem{
+			{ProductID: productID, Quantity: 1, PriceInCents: 1000},
+		}
+
+		productRepository.On("GetProductsByIDsAndRestaurantID", mock.Anything, mock.Anything, restaurantID).Return(products, nil)
+		orderItemService.On("ValidateAndCalculateOrderItems", mock.Anything, products, items).Return(&models.OrderItemSummary{
+			OrderItems:   orderItems,
+			TotalInCents: 1000,
+		}, nil)
+		orderRepository.On("CreateOrderWithItems", mock.Anything, mock.MatchedBy(func(order *models.Order) bool {
+			return order.Status == models.Scheduled && order.ScheduledFor.Valid && order.ScheduledFor.Time.Equal(scheduledFor)
+		}), orderItems, mock.Anything).Return(nil)
+
+		err := orderService.CreateOrder(context.Background(), custommerID, restaurantID, payload)
+
+		assert.NoError(t, err)
+		orderRepository.AssertExpectations(t)
 	})
 }
 
@@ -298,14 +434,14 @@ func TestOrderService_CancelOrder(t *testing.T) {
 		}
 
 		orderRepository.On("GetOrderByID", ctx, orderID, false).Return(mockOrder, nil)
-		orderRepository.On("UpdateStatus", ctx, orderID, models.Canceled).Return(nil)
+		orderRepository.On("UpdateStatus", ctx, orderID, models.Canceled, webhookEventOutbox(restaurantID, models.OrderStatusChangedEvent)).Return(nil)
 
 		err := orderService.CancelOrder(ctx, orderID)
 
 		assert.NoError(t, err)
 
 		orderRepository.AssertCalled(t, "GetOrderByID", ctx, orderID, false)
-		orderRepository.AssertCalled(t, "UpdateStatus", ctx, orderID, models.Canceled)
+		orderRepository.AssertCalled(t, "UpdateStatus", ctx, orderID, models.Canceled, mock.Anything)
 	})
 
 	t.Run("should return error when restaurant ID is not in context", func(t *testing.T) {
@@ -323,7 +459,7 @@ func TestOrderService_CancelOrder(t *testing.T) {
 		assert.ErrorIs(t, err, models.ErrRestaurantNotFound)
 
 		orderRepository.AssertNotCalled(t, "GetOrderByID", invalidCtx, mock.Anything, mock.Anything)
-		orderRepository.AssertNotCalled(t, "UpdateStatus", invalidCtx, mock.Anything, mock.Anything)
+		orderRepository.AssertNotCalled(t, "UpdateStatus", invalidCtx, mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when order is not found", func(t *testing.T) {
@@ -345,7 +481,7 @@ func TestOrderService_CancelOrder(t *testing.T) {
 		assert.ErrorIs(t, err, models.ErrorOrderNotFound)
 
 		orderRepository.AssertCalled(t, "GetOrderByID", ctx, orderID, false)
-		orderRepository.AssertNotCalled(t, "UpdateStatus", ctx, mock.Anything, mock.Anything)
+		orderRepository.AssertNotCalled(t, "UpdateStatus", ctx, mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when order does not belong to restaurant", func(t *testing.T) {
@@ -372,7 +508,7 @@ func TestOrderService_CancelOrder(t *testing.T) {
 		assert.ErrorIs(t, err, models.ErrorOrderDoesNotBelongToRestaurant)
 
 		orderRepository.AssertCalled(t, "GetOrderByID", ctx, orderID, false)
-		orderRepository.AssertNotCalled(t, "UpdateStatus", ctx, mock.Anything, mock.Anything)
+		orderRepository.AssertNotCalled(t, "UpdateStatus", ctx, mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when order cannot be canceled", func(t *testing.T) {
@@ -399,7 +535,7 @@ func TestOrderService_CancelOrder(t *testing.T) {
 		assert.ErrorIs(t, err, models.ErrorOrderCannotBeCancelled)
 
 		orderRepository.AssertCalled(t, "GetOrderByID", ctx, orderID, false)
-		orderRepository.AssertNotCalled(t, "UpdateStatus", ctx, mock.Anything, mock.Anything)
+		orderRepository.AssertNotCalled(t, "UpdateStatus", ctx, mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when repository fails to update status", func(t *testing.T) {
@@ -419,7 +555,7 @@ func TestOrderService_CancelOrder(t *testing.T) {
 		}
 
 		orderRepository.On("GetOrderByID", ctx, orderID, false).Return(mockOrder, nil)
-		orderRepository.On("UpdateStatus", ctx, orderID, models.Canceled).Return(fmt.Errorf("database error"))
+		orderRepository.On("UpdateStatus", ctx, orderID, models.Canceled, mock.Anything).Return(fmt.Errorf("database error"))
 
 		err := orderService.CancelOrder(ctx, orderID)
 
@@ -427,7 +563,7 @@ func TestOrderService_CancelOrder(t *testing.T) {
 		assert.Contains(t, err.Error(), "update status")
 
 		orderRepository.AssertCalled(t, "GetOrderByID", ctx, orderID, false)
-		orderRepository.AssertCalled(t, "UpdateStatus", ctx, orderID, models.Canceled)
+		orderRepository.AssertCalled(t, "UpdateStatus", ctx, orderID, models.Canceled, mock.Anything)
 	})
 }
 
@@ -449,14 +585,14 @@ func TestOrderService_ApproveOrder(t *testing.T) {
 		}
 
 		orderRepository.On("GetOrderByID", ctx, orderID, false).Return(mockOrder, nil)
-		orderRepository.On("UpdateStatus", ctx, orderID, models.Processing).Return(nil)
+		orderRepository.On("UpdateStatus", ctx, orderID, models.Processing, mock.Anything).Return(nil)
 
 		err := orderService.ApproveOrder(ctx, orderID)
 
 		assert.NoError(t, err)
 
 		orderRepository.AssertCalled(t, "GetOrderByID", ctx, orderID, false)
-		orderRepository.AssertCalled(t, "UpdateStatus", ctx, orderID, models.Processing)
+		orderRepository.AssertCalled(t, "UpdateStatus", ctx, orderID, models.Processing, mock.Anything)
 	})
 
 	t.Run("should return error when restaurant ID is not in context", func(t *testing.T) {
@@ -474,7 +610,7 @@ func TestOrderService_ApproveOrder(t *testing.T) {
 		assert.ErrorIs(t, err, models.ErrRestaurantNotFound)
 
 		orderRepository.AssertNotCalled(t, "GetOrderByID", invalidCtx, mock.Anything, mock.Anything)
-		orderRepository.AssertNotCalled(t, "UpdateStatus", invalidCtx, mock.Anything, mock.Anything)
+		orderRepository.AssertNotCalled(t, "UpdateStatus", invalidCtx, mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when order is not found", func(t *testing.T) {
@@ -496,7 +632,7 @@ func TestOrderService_ApproveOrder(t *testing.T) {
 		assert.ErrorIs(t, err, models.ErrorOrderNotFound)
 
 		orderRepository.AssertCalled(t, "GetOrderByID", ctx, orderID, false)
-		orderRepository.AssertNotCalled(t, "UpdateStatus", ctx, mock.Anything, mock.Anything)
+		orderRepository.AssertNotCalled(t, "UpdateStatus", ctx, mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when order does not belong to restaurant", func(t *testing.T) {
@@ -523,7 +659,7 @@ func TestOrderService_ApproveOrder(t *testing.T) {
 		assert.ErrorIs(t, err, models.ErrorOrderDoesNotBelongToRestaurant)
 
 		orderRepository.AssertCalled(t, "GetOrderByID", ctx, orderID, false)
-		orderRepository.AssertNotCalled(t, "UpdateStatus", ctx, mock.Anything, mock.Anything)
+		orderRepository.AssertNotCalled(t, "UpdateStatus", ctx, mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when order cannot be approved", func(t *testing.T) {
@@ -550,7 +686,7 @@ func TestOrderService_ApproveOrder(t *testing.T) {
 		assert.ErrorIs(t, err, models.ErrOrderCannotBeApproved)
 
 		orderRepository.AssertCalled(t, "GetOrderByID", ctx, orderID, false)
-		orderRepository.AssertNotCalled(t, "UpdateStatus", ctx, mock.Anything, mock.Anything)
+		orderRepository.AssertNotCalled(t, "UpdateStatus", ctx, mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when repository fails to update status", func(t *testing.T) {
@@ -570,7 +706,7 @@ func TestOrderService_ApproveOrder(t *testing.T) {
 		}
 
 		orderRepository.On("GetOrderByID", ctx, orderID, false).Return(mockOrder, nil)
-		orderRepository.On("UpdateStatus", ctx, orderID, models.Processing).Return(fmt.Errorf("database error"))
+		orderRepository.On("UpdateStatus", ctx, orderID, models.Processing, mock.Anything).Return(fmt.Errorf("database error"))
 
 		err := orderService.ApproveOrder(ctx, orderID)
 
@@ -578,7 +714,7 @@ func TestOrderService_ApproveOrder(t *testing.T) {
 		assert.Contains(t, err.Error(), "update status")
 
 		orderRepository.AssertCalled(t, "GetOrderByID", ctx, orderID, false)
-		orderRepository.AssertCalled(t, "UpdateStatus", ctx, orderID, models.Processing)
+		orderRepository.AssertCalled(t, "UpdateStatus", ctx, orderID, models.Processing, mock.Anything)
 	})
 }
 
@@ -600,14 +736,81 @@ func TestOrderService_DispatchOrder(t *testing.T) {
 		}
 
 		orderRepository.On("GetOrderByID", ctx, orderID, false).Return(mockOrder, nil)
-		orderRepository.On("UpdateStatus", ctx, orderID, models.Delivering).Return(nil)
+		orderRepository.On("UpdateStatus", ctx, orderID, models.Delivering, mock.Anything).Return(nil)
 
-		err := orderService.DispatchOrder(ctx, orderID)
+		err := orderService.DispatchOrder(ctx, orderID, nil)
 
 		assert.NoError(t, err)
 
 		orderRepository.AssertCalled(t, "GetOrderByID", ctx, orderID, false)
-		orderRepository.AssertCalled(t, "UpdateStatus", ctx, orderID, models.Delivering)
+		orderRepository.AssertCalled(t, "UpdateStatus", ctx, orderID, models.Delivering, mock.Anything)
+	})
+
+	t.Run("should dispatch order to courier successfully", func(t *testing.T) {
+		orderRepository := &mocks.OrderRepository{}
+		userRepository := &mocks.UserRepository{}
+		orderService := &orderService{
+			orderRepository: orderRepository,
+			userRepository:  userRepository,
+		}
+
+		restaurantID := uuid.New()
+		ctx := context.WithValue(context.Background(), internal.RestaurantIDKey, &restaurantID)
+
+		orderID := uuid.New()
+		courierID := uuid.New()
+		mockOrder := &models.Order{
+			BaseModel:    models.BaseModel{ID: orderID},
+			RestaurantID: restaurantID,
+			Status:       models.Processing,
+		}
+		courier := &models.User{
+			BaseModel: models.BaseModel{ID: courierID},
+			Role:      models.Courier,
+		}
+
+		orderRepository.On("GetOrderByID", ctx, orderID, false).Return(mockOrder, nil)
+		userRepository.On("GetUserByID", ctx, courierID).Return(courier, nil)
+		orderRepository.On("AssignCourier", ctx, orderID, courierID, mock.Anything).Return(nil)
+
+		err := orderService.DispatchOrder(ctx, orderID, &courierID)
+
+		assert.NoError(t, err)
+
+		orderRepository.AssertCalled(t, "AssignCourier", ctx, orderID, courierID, mock.Anything)
+		orderRepository.AssertNotCalled(t, "UpdateStatus", ctx, orderID, models.Delivering, mock.Anything)
+	})
+
+	t.Run("should return error when assigned user is not a courier", func(t *testing.T) {
+		orderRepository := &mocks.OrderRepository{}
+		userRepository := &mocks.UserRepository{}
+		orderService := &orderService{
+			orderRepository: orderRepository,
+			userRepository:  userRepository,
+		}
+
+		restaurantID := uuid.New()
+		ctx := context.WithValue(context.Background(), internal.RestaurantIDKey, &restaurantID)
+
+		orderID := uuid.New()
+		userID := uuid.New()
+		mockOrder := &models.Order{
+			BaseModel:    models.BaseModel{ID: orderID},
+			RestaurantID: restaurantID,
+			Status:       models.Processing,
+		}
+		customer := &models.User{
+			BaseModel: models.BaseModel{ID: userID},
+			Role:      models.Customer,
+		}
+
+		orderRepository.On("GetOrderByID", ctx, orderID, false).Return(mockOrder, nil)
+		userRepository.On("GetUserByID", ctx, userID).Return(customer, nil)
+
+		err := orderService.DispatchOrder(ctx, orderID, &userID)
+
+		assert.ErrorIs(t, err, models.ErrCourierNotFound)
+		orderRepository.AssertNotCalled(t, "AssignCourier", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when restaurant ID is not in context", func(t *testing.T) {
@@ -619,13 +822,13 @@ func TestOrderService_DispatchOrder(t *testing.T) {
 		invalidCtx := context.Background()
 		orderID := uuid.New()
 
-		err := orderService.DispatchOrder(invalidCtx, orderID)
+		err := orderService.DispatchOrder(invalidCtx, orderID, nil)
 
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, models.ErrRestaurantNotFound)
 
 		orderRepository.AssertNotCalled(t, "GetOrderByID", invalidCtx, mock.Anything, mock.Anything)
-		orderRepository.AssertNotCalled(t, "UpdateStatus", invalidCtx, mock.Anything, mock.Anything)
+		orderRepository.AssertNotCalled(t, "UpdateStatus", invalidCtx, mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when order is not found", func(t *testing.T) {
@@ -641,13 +844,13 @@ func TestOrderService_DispatchOrder(t *testing.T) {
 
 		orderRepository.On("GetOrderByID", ctx, orderID, false).Return(nil, nil)
 
-		err := orderService.DispatchOrder(ctx, orderID)
+		err := orderService.DispatchOrder(ctx, orderID, nil)
 
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, models.ErrorOrderNotFound)
 
 		orderRepository.AssertCalled(t, "GetOrderByID", ctx, orderID, false)
-		orderRepository.AssertNotCalled(t, "UpdateStatus", ctx, mock.Anything, mock.Anything)
+		orderRepository.AssertNotCalled(t, "UpdateStatus", ctx, mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when order does not belong to restaurant", func(t *testing.T) {
@@ -668,13 +871,13 @@ func TestOrderService_DispatchOrder(t *testing.T) {
 
 		orderRepository.On("GetOrderByID", ctx, orderID, false).Return(mockOrder, nil)
 
-		err := orderService.DispatchOrder(ctx, orderID)
+		err := orderService.DispatchOrder(ctx, orderID, nil)
 
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, models.ErrorOrderDoesNotBelongToRestaurant)
 
 		orderRepository.AssertCalled(t, "GetOrderByID", ctx, orderID, false)
-		orderRepository.AssertNotCalled(t, "UpdateStatus", ctx, mock.Anything, mock.Anything)
+		orderRepository.AssertNotCalled(t, "UpdateStatus", ctx, mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when order cannot be dispatched", func(t *testing.T) {
@@ -695,13 +898,13 @@ func TestOrderService_DispatchOrder(t *testing.T) {
 
 		orderRepository.On("GetOrderByID", ctx, orderID, false).Return(mockOrder, nil)
 
-		err := orderService.DispatchOrder(ctx, orderID)
+		err := orderService.DispatchOrder(ctx, orderID, nil)
 
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, models.ErrOrderCannotBeDispatched)
 
 		orderRepository.AssertCalled(t, "GetOrderByID", ctx, orderID, false)
-		orderRepository.AssertNotCalled(t, "UpdateStatus", ctx, mock.Anything, mock.Anything)
+		orderRepository.AssertNotCalled(t, "UpdateStatus", ctx, mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when repository fails to update status", func(t *testing.T) {
@@ -721,15 +924,15 @@ func TestOrderService_DispatchOrder(t *testing.T) {
 		}
 
 		orderRepository.On("GetOrderByID", ctx, orderID, false).Return(mockOrder, nil)
-		orderRepository.On("UpdateStatus", ctx, orderID, models.Delivering).Return(fmt.Errorf("database error"))
+		orderRepository.On("UpdateStatus", ctx, orderID, models.Delivering, mock.Anything).Return(fmt.Errorf("database error"))
 
-		err := orderService.DispatchOrder(ctx, orderID)
+		err := orderService.DispatchOrder(ctx, orderID, nil)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "update status")
 
 		orderRepository.AssertCalled(t, "GetOrderByID", ctx, orderID, false)
-		orderRepository.AssertCalled(t, "UpdateStatus", ctx, orderID, models.Delivering)
+		orderRepository.AssertCalled(t, "UpdateStatus", ctx, orderID, models.Delivering, mock.Anything)
 	})
 }
 
@@ -751,14 +954,14 @@ func TestOrderService_DeliverOrder(t *testing.T) {
 		}
 
 		orderRepository.On("GetOrderByID", ctx, orderID, false).Return(mockOrder, nil)
-		orderRepository.On("UpdateStatus", ctx, orderID, models.Delivered).Return(nil)
+		orderRepository.On("UpdateStatus", ctx, orderID, models.Delivered, mock.Anything).Return(nil)
 
 		err := orderService.DeliverOrder(ctx, orderID)
 
 		assert.NoError(t, err)
 
 		orderRepository.AssertCalled(t, "GetOrderByID", ctx, orderID, false)
-		orderRepository.AssertCalled(t, "UpdateStatus", ctx, orderID, models.Delivered)
+		orderRepository.AssertCalled(t, "UpdateStatus", ctx, orderID, models.Delivered, mock.Anything)
 	})
 
 	t.Run("should return error when restaurant ID is not in context", func(t *testing.T) {
@@ -776,7 +979,7 @@ func TestOrderService_DeliverOrder(t *testing.T) {
 		assert.ErrorIs(t, err, models.ErrRestaurantNotFound)
 
 		orderRepository.AssertNotCalled(t, "GetOrderByID", invalidCtx, mock.Anything, mock.Anything)
-		orderRepository.AssertNotCalled(t, "UpdateStatus", invalidCtx, mock.Anything, mock.Anything)
+		orderRepository.AssertNotCalled(t, "UpdateStatus", invalidCtx, mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when order is not found", func(t *testing.T) {
@@ -798,7 +1001,7 @@ func TestOrderService_DeliverOrder(t *testing.T) {
 		assert.ErrorIs(t, err, models.ErrorOrderNotFound)
 
 		orderRepository.AssertCalled(t, "GetOrderByID", ctx, orderID, false)
-		orderRepository.AssertNotCalled(t, "UpdateStatus", ctx, mock.Anything, mock.Anything)
+		orderRepository.AssertNotCalled(t, "UpdateStatus", ctx, mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when order does not belong to restaurant", func(t *testing.T) {
@@ -825,7 +1028,7 @@ func TestOrderService_DeliverOrder(t *testing.T) {
 		assert.ErrorIs(t, err, models.ErrorOrderDoesNotBelongToRestaurant)
 
 		orderRepository.AssertCalled(t, "GetOrderByID", ctx, orderID, false)
-		orderRepository.AssertNotCalled(t, "UpdateStatus", ctx, mock.Anything, mock.Anything)
+		orderRepository.AssertNotCalled(t, "UpdateStatus", ctx, mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when order cannot be delivered", func(t *testing.T) {
@@ -852,7 +1055,7 @@ func TestOrderService_DeliverOrder(t *testing.T) {
 		assert.ErrorIs(t, err, models.ErrOrderCannotBeDelivered)
 
 		orderRepository.AssertCalled(t, "GetOrderByID", ctx, orderID, false)
-		orderRepository.AssertNotCalled(t, "UpdateStatus", ctx, mock.Anything, mock.Anything)
+		orderRepository.AssertNotCalled(t, "UpdateStatus", ctx, mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when repository fails to update status", func(t *testing.T) {
@@ -872,7 +1075,7 @@ func TestOrderService_DeliverOrder(t *testing.T) {
 		}
 
 		orderRepository.On("GetOrderByID", ctx, orderID, false).Return(mockOrder, nil)
-		orderRepository.On("UpdateStatus", ctx, orderID, models.Delivered).Return(fmt.Errorf("database error"))
+		orderRepository.On("UpdateStatus", ctx, orderID, models.Delivered, mock.Anything).Return(fmt.Errorf("database error"))
 
 		err := orderService.DeliverOrder(ctx, orderID)
 
@@ -880,6 +1083,181 @@ func TestOrderService_DeliverOrder(t *testing.T) {
 		assert.Contains(t, err.Error(), "update status")
 
 		orderRepository.AssertCalled(t, "GetOrderByID", ctx, orderID, false)
-		orderRepository.AssertCalled(t, "UpdateStatus", ctx, orderID, models.Delivered)
+		orderRepository.AssertCalled(t, "UpdateStatus", ctx, orderID, models.Delivered, mock.Anything)
+	})
+}
+
+func TestOrderService_ReleaseScheduledOrders(t *testing.T) {
+	t.Run("should release due orders and record a status change for each", func(t *testing.T) {
+		orderRepository := &mocks.OrderRepository{}
+		orderService := &orderService{
+			orderRepository: orderRepository,
+		}
+
+		ctx := context.Background()
+		restaurantID := uuid.New()
+		orders := []models.Order{
+			{BaseModel: models.BaseModel{ID: uuid.New()}, RestaurantID: restaurantID},
+			{BaseModel: models.BaseModel{ID: uuid.New()}, RestaurantID: restaurantID},
+		}
+
+		orderRepository.On("GetDueScheduledOrders", ctx, mock.AnythingOfType("time.Time")).Return(orders, nil)
+		orderRepository.On("ReleaseScheduledOrder", ctx, orders[0].ID, mock.Anything).Return(true, nil)
+		orderRepository.On("ReleaseScheduledOrder", ctx, orders[1].ID, mock.Anything).Return(false, nil)
+
+		released, err := orderService.ReleaseScheduledOrders(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), released)
+		orderRepository.AssertCalled(t, "ReleaseScheduledOrder", ctx, orders[0].ID, mock.MatchedBy(func(outbox []models.OutboxMessage) bool {
+			return len(outbox) == 1 && outbox[0].Queue == QueueWebhookEvent
+		}))
+	})
+
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		orderRepository := &mocks.OrderRepository{}
+		orderService := &orderService{
+			orderRepository: orderRepository,
+		}
+
+		ctx := context.Background()
+
+		orderRepository.On("GetDueScheduledOrders", ctx, mock.AnythingOfType("time.Time")).Return(nil, fmt.Errorf("database error"))
+
+		released, err := orderService.ReleaseScheduledOrders(ctx)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "get due scheduled orders")
+		assert.Equal(t, int64(0), released)
+	})
+}
+
+func TestOrderService_GetPaginatedDeliveriesByCourierID(t *testing.T) {
+	t.Run("should return paginated deliveries successfully", func(t *testing.T) {
+		orderRepository := &mocks.OrderRepository{}
+		orderService := &orderService{
+			orderRepository: orderRepository,
+		}
+
+		courierID := uuid.New()
+		ctx := context.WithValue(context.Background(), internal.UserIDKey, courierID)
+
+		pagination := &models.DeliveryPagination{
+			Pagination: models.Pagination{
+				Page:  1,
+				Limit: 10,
+			},
+		}
+
+		mockPaginatedOrders := &models.PaginatedResponse[models.Order]{
+			Data: []models.Order{
+				{
+					BaseModel:  models.BaseModel{ID: uuid.New()},
+					Custommer:  models.User{FullName: "Customer 1"},
+					Restaurant: models.Restaurant{Name: "Restaurant 1"},
+					Status:     models.Delivering,
+				},
+			},
+			Total:      1,
+			TotalPages: 1,
+			Page:       1,
+			Limit:      10,
+		}
+
+		orderRepository.On("GetPaginatedOrdersByCourierID", ctx, courierID, pagination).Return(mockPaginatedOrders, nil)
+
+		response, err := orderService.GetPaginatedDeliveriesByCourierID(ctx, pagination)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(response.Data))
+		assert.Equal(t, "Customer 1", response.Data[0].CustommerName)
+		assert.Equal(t, "Restaurant 1", response.Data[0].RestaurantName)
+	})
+
+	t.Run("should return error when user ID is not in context", func(t *testing.T) {
+		orderRepository := &mocks.OrderRepository{}
+		orderService := &orderService{
+			orderRepository: orderRepository,
+		}
+
+		response, err := orderService.GetPaginatedDeliveriesByCourierID(context.Background(), &models.DeliveryPagination{})
+
+		assert.ErrorIs(t, err, models.ErrUserNotFoundInContext)
+		assert.Nil(t, response)
+		orderRepository.AssertNotCalled(t, "GetPaginatedOrdersByCourierID", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestOrderService_CompleteDelivery(t *testing.T) {
+	t.Run("should complete delivery successfully", func(t *testing.T) {
+		orderRepository := &mocks.OrderRepository{}
+		orderService := &orderService{
+			orderRepository: orderRepository,
+		}
+
+		courierID := uuid.New()
+		ctx := context.WithValue(context.Background(), internal.UserIDKey, courierID)
+
+		orderID := uuid.New()
+		mockOrder := &models.Order{
+			BaseModel: models.BaseModel{ID: orderID},
+			Status:    models.Delivering,
+			CourierID: uuid.NullUUID{UUID: courierID, Valid: true},
+		}
+
+		orderRepository.On("GetOrderByID", ctx, orderID, false).Return(mockOrder, nil)
+		orderRepository.On("UpdateStatus", ctx, orderID, models.Delivered, mock.Anything).Return(nil)
+
+		err := orderService.CompleteDelivery(ctx, orderID)
+
+		assert.NoError(t, err)
+		orderRepository.AssertCalled(t, "UpdateStatus", ctx, orderID, models.Delivered, mock.Anything)
+	})
+
+	t.Run("should return error when order is assigned to another courier", func(t *testing.T) {
+		orderRepository := &mocks.OrderRepository{}
+		orderService := &orderService{
+			orderRepository: orderRepository,
+		}
+
+		ctx := context.WithValue(context.Background(), internal.UserIDKey, uuid.New())
+
+		orderID := uuid.New()
+		mockOrder := &models.Order{
+			BaseModel: models.BaseModel{ID: orderID},
+			Status:    models.Delivering,
+			CourierID: uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		}
+
+		orderRepository.On("GetOrderByID", ctx, orderID, false).Return(mockOrder, nil)
+
+		err := orderService.CompleteDelivery(ctx, orderID)
+
+		assert.ErrorIs(t, err, models.ErrOrderNotAssignedToCourier)
+		orderRepository.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return error when order is not delivering", func(t *testing.T) {
+		orderRepository := &mocks.OrderRepository{}
+		orderService := &orderService{
+			orderRepository: orderRepository,
+		}
+
+		courierID := uuid.New()
+		ctx := context.WithValue(context.Background(), internal.UserIDKey, courierID)
+
+		orderID := uuid.New()
+		mockOrder := &models.Order{
+			BaseModel: models.BaseModel{ID: orderID},
+			Status:    models.Delivered,
+			CourierID: uuid.NullUUID{UUID: courierID, Valid: true},
+		}
+
+		orderRepository.On("GetOrderByID", ctx, orderID, false).Return(mockOrder, nil)
+
+		err := orderService.CompleteDelivery(ctx, orderID)
+
+		assert.ErrorIs(t, err, models.ErrOrderCannotBeDelivered)
+		orderRepository.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 	})
 }
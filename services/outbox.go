@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/config"
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/G-Villarinho/food-shop-api/repositories"
+)
+
+const (
+	outboxRelayBatchSize     = 100
+	defaultOutboxRetention   = 7 * 24 * time.Hour
+	defaultOutboxMaxAttempts = 10
+)
+
+//go:generate mockery --name=OutboxService --output=../mocks --outpkg=mocks
+type OutboxService interface {
+	RelayPendingMessages(ctx context.Context) (int, error)
+	DeletePublishedMessages(ctx context.Context) (int64, error)
+}
+
+type outboxService struct {
+	di               *internal.Di
+	queueService     QueueService
+	outboxRepository repositories.OutboxRepository
+}
+
+func NewOutboxService(di *internal.Di) (OutboxService, error) {
+	queueService, err := internal.Invoke[QueueService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	outboxRepository, err := internal.Invoke[repositories.OutboxRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &outboxService{
+		di:               di,
+		queueService:     queueService,
+		outboxRepository: outboxRepository,
+	}, nil
+}
+
+// RelayPendingMessages publishes the pending messages in the order they were
+// written. A message is only marked as published after the broker confirms
+// it, so a crash in between publishes it again: consumers must be idempotent.
+// The relay stops at the first failure to keep the order of the messages, and
+// a message that failed OUTBOX_MAX_ATTEMPTS times is parked so it no longer
+// holds back the ones written after it.
+func (o *outboxService) RelayPendingMessages(ctx context.Context) (int, error) {
+	maxAttempts := config.Env.Outbox.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultOutboxMaxAttempts
+	}
+
+	publish := func(message models.OutboxMessage) error {
+		return o.queueService.Publish(message.Queue, []byte(message.Payload))
+	}
+
+	relayed := 0
+	for {
+		count, err := o.outboxRepository.RelayPendingMessages(ctx, outboxRelayBatchSize, maxAttempts, publish)
+		relayed += count
+		if err != nil {
+			return relayed, fmt.Errorf("relay pending messages: %w", err)
+		}
+
+		if count < outboxRelayBatchSize {
+			return relayed, nil
+		}
+	}
+}
+
+func (o *outboxService) DeletePublishedMessages(ctx context.Context) (int64, error) {
+	retention := time.Duration(config.Env.Outbox.Retention) * time.Hour
+	if retention <= 0 {
+		retention = defaultOutboxRetention
+	}
+
+	deleted, err := o.outboxRepository.DeletePublishedBefore(ctx, time.Now().UTC().Add(-retention))
+	if err != nil {
+		return 0, fmt.Errorf("delete published messages: %w", err)
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/config"
+	"github.com/G-Villarinho/food-shop-api/mocks"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOutboxService_RelayPendingMessages(t *testing.T) {
+	ctx := context.Background()
+	config.Env.Outbox.MaxAttempts = 5
+
+	relayWith := func(messages ...*models.OutboxMessage) func(args mock.Arguments) {
+		return func(args mock.Arguments) {
+			publish := args.Get(3).(func(models.OutboxMessage) error)
+			for _, message := range messages {
+				if err := publish(*message); err != nil {
+					return
+				}
+			}
+		}
+	}
+
+	t.Run("should publish the pending messages through the queue", func(t *testing.T) {
+		queueService := &mocks.QueueService{}
+		outboxRepository := &mocks.OutboxRepository{}
+
+		service := &outboxService{
+			queueService:     queueService,
+			outboxRepository: outboxRepository,
+		}
+
+		first, _ := models.NewOutboxMessage(QueueSendEmail, map[string]string{"to": "user@example.com"})
+		second, _ := models.NewOutboxMessage(QueueWebhookEvent, map[string]string{"event": "order.created"})
+
+		outboxRepository.On("RelayPendingMessages", ctx, outboxRelayBatchSize, 5, mock.Anything).
+			Run(relayWith(first, second)).
+			Return(2, nil)
+		queueService.On("Publish", QueueSendEmail, []byte(first.Payload)).Return(nil)
+		queueService.On("Publish", QueueWebhookEvent, []byte(second.Payload)).Return(nil)
+
+		relayed, err := service.RelayPendingMessages(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, relayed)
+		queueService.AssertExpectations(t)
+		outboxRepository.AssertExpectations(t)
+	})
+
+	t.Run("should fall back to the default max attempts", func(t *testing.T) {
+		config.Env.Outbox.MaxAttempts = 0
+		defer func() { config.Env.Outbox.MaxAttempts = 5 }()
+
+		outboxRepository := &mocks.OutboxRepository{}
+
+		service := &outboxService{
+			outboxRepository: outboxRepository,
+		}
+
+		outboxRepository.On("RelayPendingMessages", ctx, outboxRelayBatchSize, defaultOutboxMaxAttempts, mock.Anything).Return(0, nil)
+
+		relayed, err := service.RelayPendingMessages(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, relayed)
+		outboxRepository.AssertExpectations(t)
+	})
+
+	t.Run("should return the messages relayed before a failure", func(t *testing.T) {
+		queueService := &mocks.QueueService{}
+		outboxRepository := &mocks.OutboxRepository{}
+
+		service := &outboxService{
+			queueService:     queueService,
+			outboxRepository: outboxRepository,
+		}
+
+		first, _ := models.NewOutboxMessage(QueueSendEmail, "first")
+		second, _ := models.NewOutboxMessage(QueueSendEmail, "second")
+
+		outboxRepository.On("RelayPendingMessages", ctx, outboxRelayBatchSize, 5, mock.Anything).
+			Run(relayWith(first, second)).
+			Return(1, errors.New("broker down"))
+		queueService.On("Publish", QueueSendEmail, []byte(first.Payload)).Return(nil)
+		queueService.On("Publish", QueueSendEmail, []byte(second.Payload)).Return(errors.New("broker down"))
+
+		relayed, err := service.RelayPendingMessages(ctx)
+
+		assert.Error(t, err)
+		assert.Equal(t, 1, relayed)
+		outboxRepository.AssertNumberOfCalls(t, "RelayPendingMessages", 1)
+	})
+}
+
+func TestOutboxService_DeletePublishedMessages(t *testing.T) {
+	ctx := context.Background()
+	config.Env.Outbox.Retention = 24
+
+	t.Run("should delete messages published before the retention", func(t *testing.T) {
+		outboxRepository := &mocks.OutboxRepository{}
+
+		service := &outboxService{
+			outboxRepository: outboxRepository,
+		}
+
+		outboxRepository.On("DeletePublishedBefore", ctx, mock.MatchedBy(func(before time.Time) bool {
+			return time.Since(before) >= 24*time.Hour && time.Since(before) < 25*time.Hour
+		})).Return(int64(3), nil)
+
+		deleted, err := service.DeletePublishedMessages(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), deleted)
+		outboxRepository.AssertExpectations(t)
+	})
+}
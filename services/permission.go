@@ -0,0 +1,272 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/cache"
+	"github.com/G-Villarinho/food-shop-api/config"
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/G-Villarinho/food-shop-api/repositories"
+	"github.com/google/uuid"
+)
+
+//go:generate mockery --name=PermissionService --output=../mocks --outpkg=mocks
+type PermissionService interface {
+	HasPermission(ctx context.Context, scope models.RoleScope, role string, permission models.Permission) (bool, error)
+	GetRolePermissions(ctx context.Context) ([]*models.RolePermissionsResponse, error)
+	UpdateRolePermissions(ctx context.Context, scope models.RoleScope, role string, payload models.UpdateRolePermissionsPayload) error
+	HasMemberPermission(ctx context.Context, restaurantID uuid.UUID, role models.MemberRole, permission models.Permission) (bool, error)
+	GetRestaurantRolePermissions(ctx context.Context) ([]*models.RolePermissionsResponse, error)
+	UpdateRestaurantRolePermissions(ctx context.Context, role models.MemberRole, payload models.UpdateRolePermissionsPayload) error
+}
+
+type permissionService struct {
+	di                       *internal.Di
+	cacheService             cache.CacheService
+	rolePermissionRepository repositories.RolePermissionRepository
+}
+
+func NewPermissionService(di *internal.Di) (PermissionService, error) {
+	cacheService, err := internal.Invoke[cache.CacheService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	rolePermissionRepository, err := internal.Invoke[repositories.RolePermissionRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &permissionService{
+		di:                       di,
+		cacheService:             cacheService,
+		rolePermissionRepository: rolePermissionRepository,
+	}, nil
+}
+
+func (p *permissionService) HasPermission(ctx context.Context, scope models.RoleScope, role string, permission models.Permission) (bool, error) {
+	permissions, err := p.getPermissionsByRole(ctx, scope, role)
+	if err != nil {
+		return false, err
+	}
+
+	return slices.Contains(permissions, permission), nil
+}
+
+func (p *permissionService) GetRolePermissions(ctx context.Context) ([]*models.RolePermissionsResponse, error) {
+	rolePermissions, err := p.rolePermissionRepository.GetRolePermissions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get role permissions: %w", err)
+	}
+
+	var response []*models.RolePermissionsResponse
+	for scope, roles := range models.DefaultRolePermissions {
+		for role := range roles {
+			roleResponse := &models.RolePermissionsResponse{
+				Scope:       scope,
+				Role:        role,
+				Permissions: []models.Permission{},
+			}
+
+			for _, rolePermission := range rolePermissions {
+				if rolePermission.Scope == scope && rolePermission.Role == role {
+					roleResponse.Permissions = append(roleResponse.Permissions, rolePermission.Permission)
+				}
+			}
+
+			response = append(response, roleResponse)
+		}
+	}
+
+	slices.SortFunc(response, func(a, b *models.RolePermissionsResponse) int {
+		if scope := cmp.Compare(a.Scope, b.Scope); scope != 0 {
+			return scope
+		}
+		return cmp.Compare(a.Role, b.Role)
+	})
+
+	return response, nil
+}
+
+func (p *permissionService) UpdateRolePermissions(ctx context.Context, scope models.RoleScope, role string, payload models.UpdateRolePermissionsPayload) error {
+	if !models.IsKnownRole(scope, role) {
+		return models.ErrRoleNotFound
+	}
+
+	if err := payload.Validate(); err != nil {
+		return err
+	}
+
+	if err := p.rolePermissionRepository.ReplaceRolePermissions(ctx, scope, role, payload.ToRolePermissions(scope, role)); err != nil {
+		return fmt.Errorf("replace role permissions: %w", err)
+	}
+
+	if err := p.cacheService.Delete(ctx, getRolePermissionsKey(scope, role)); err != nil {
+		return fmt.Errorf("delete role permissions from cache: %w", err)
+	}
+
+	return nil
+}
+
+// HasMemberPermission checks the grants of the member role inside the
+// restaurant, which may override the member scope grants of the role.
+func (p *permissionService) HasMemberPermission(ctx context.Context, restaurantID uuid.UUID, role models.MemberRole, permission models.Permission) (bool, error) {
+	overrides, err := p.getRestaurantRolePermissions(ctx, restaurantID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, override := range overrides {
+		if override.Role == role && override.Permission == permission {
+			return override.Granted, nil
+		}
+	}
+
+	return p.HasPermission(ctx, models.MemberScope, string(role), permission)
+}
+
+func (p *permissionService) GetRestaurantRolePermissions(ctx context.Context) ([]*models.RolePermissionsResponse, error) {
+	restaurantID, ok := ctx.Value(internal.RestaurantIDKey).(*uuid.UUID)
+	if !ok || restaurantID == nil {
+		return nil, models.ErrRestaurantNotFound
+	}
+
+	overrides, err := p.getRestaurantRolePermissions(ctx, *restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response []*models.RolePermissionsResponse
+	for role := range models.DefaultRolePermissions[models.MemberScope] {
+		defaults, err := p.getPermissionsByRole(ctx, models.MemberScope, role)
+		if err != nil {
+			return nil, err
+		}
+
+		roleResponse := &models.RolePermissionsResponse{
+			Scope:       models.MemberScope,
+			Role:        role,
+			Permissions: []models.Permission{},
+		}
+
+		for _, permission := range models.Permissions {
+			granted := slices.Contains(defaults, permission)
+			for _, override := range overrides {
+				if string(override.Role) == role && override.Permission == permission {
+					granted = override.Granted
+				}
+			}
+
+			if granted {
+				roleResponse.Permissions = append(roleResponse.Permissions, permission)
+			}
+		}
+
+		response = append(response, roleResponse)
+	}
+
+	slices.SortFunc(response, func(a, b *models.RolePermissionsResponse) int {
+		return cmp.Compare(a.Role, b.Role)
+	})
+
+	return response, nil
+}
+
+// UpdateRestaurantRolePermissions sets the grants of a member role inside the
+// restaurant of the session. The owner role can't be edited, so a restaurant
+// can't lock itself out of managing its members.
+func (p *permissionService) UpdateRestaurantRolePermissions(ctx context.Context, role models.MemberRole, payload models.UpdateRolePermissionsPayload) error {
+	restaurantID, ok := ctx.Value(internal.RestaurantIDKey).(*uuid.UUID)
+	if !ok || restaurantID == nil {
+		return models.ErrRestaurantNotFound
+	}
+
+	if !models.IsKnownRole(models.MemberScope, string(role)) {
+		return models.ErrRoleNotFound
+	}
+
+	if role == models.OwnerMember {
+		return models.ErrOwnerRoleNotEditable
+	}
+
+	if err := payload.Validate(); err != nil {
+		return err
+	}
+
+	defaults, err := p.getPermissionsByRole(ctx, models.MemberScope, string(role))
+	if err != nil {
+		return err
+	}
+
+	if err := p.rolePermissionRepository.ReplaceRestaurantRolePermissions(ctx, *restaurantID, role, payload.ToRestaurantRolePermissions(*restaurantID, role, defaults)); err != nil {
+		return fmt.Errorf("replace restaurant role permissions: %w", err)
+	}
+
+	if err := p.cacheService.Delete(ctx, getRestaurantRolePermissionsKey(*restaurantID)); err != nil {
+		return fmt.Errorf("delete restaurant role permissions from cache: %w", err)
+	}
+
+	return nil
+}
+
+func (p *permissionService) getPermissionsByRole(ctx context.Context, scope models.RoleScope, role string) ([]models.Permission, error) {
+	var permissions []models.Permission
+	err := p.cacheService.Get(ctx, getRolePermissionsKey(scope, role), &permissions)
+	if err == nil {
+		return permissions, nil
+	}
+
+	if !errors.Is(err, cache.ErrCacheMiss) {
+		return nil, fmt.Errorf("get role permissions from cache: %w", err)
+	}
+
+	permissions, err = p.rolePermissionRepository.GetPermissionsByRole(ctx, scope, role)
+	if err != nil {
+		return nil, fmt.Errorf("get permissions by role: %w", err)
+	}
+
+	ttl := time.Duration(config.Env.Cache.CacheExp) * time.Minute
+	if err := p.cacheService.Set(ctx, getRolePermissionsKey(scope, role), permissions, ttl); err != nil {
+		return nil, fmt.Errorf("set role permissions to cache: %w", err)
+	}
+
+	return permissions, nil
+}
+
+func (p *permissionService) getRestaurantRolePermissions(ctx context.Context, restaurantID uuid.UUID) ([]models.RestaurantRolePermission, error) {
+	var rolePermissions []models.RestaurantRolePermission
+	err := p.cacheService.Get(ctx, getRestaurantRolePermissionsKey(restaurantID), &rolePermissions)
+	if err == nil {
+		return rolePermissions, nil
+	}
+
+	if !errors.Is(err, cache.ErrCacheMiss) {
+		return nil, fmt.Errorf("get restaurant role permissions from cache: %w", err)
+	}
+
+	rolePermissions, err = p.rolePermissionRepository.GetRestaurantRolePermissions(ctx, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("get restaurant role permissions: %w", err)
+	}
+
+	ttl := time.Duration(config.Env.Cache.CacheExp) * time.Minute
+	if err := p.cacheService.Set(ctx, getRestaurantRolePermissionsKey(restaurantID), rolePermissions, ttl); err != nil {
+		return nil, fmt.Errorf("set restaurant role permissions to cache: %w", err)
+	}
+
+	return rolePermissions, nil
+}
+
+func getRolePermissionsKey(scope models.RoleScope, role string) string {
+	return fmt.Sprintf("role-permissions:%s:%s", scope, role)
+}
+
+func getRestaurantRolePermissionsKey(restaurantID uuid.UUID) string {
+	return fmt.Sprintf("restaurant-role-permissions:%s", restaurantID.String())
+}
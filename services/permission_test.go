@@ -0,0 +1,255 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/G-Villarinho/food-shop-api/cache"
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/mocks"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPermissionService_HasPermission(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should use cached permissions when available", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		rolePermissionRepository := &mocks.RolePermissionRepository{}
+
+		service := &permissionService{
+			cacheService:             cacheService,
+			rolePermissionRepository: rolePermissionRepository,
+		}
+
+		cacheService.On("Get", ctx, getRolePermissionsKey(models.PlatformScope, string(models.Manager)), mock.AnythingOfType("*[]models.Permission")).Run(func(args mock.Arguments) {
+			*args.Get(2).(*[]models.Permission) = []models.Permission{models.ListOrdersPermission}
+		}).Return(nil)
+
+		hasPermission, err := service.HasPermission(ctx, models.PlatformScope, string(models.Manager), models.ListOrdersPermission)
+
+		assert.NoError(t, err)
+		assert.True(t, hasPermission)
+		rolePermissionRepository.AssertNotCalled(t, "GetPermissionsByRole", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should load permissions from database on cache miss", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		rolePermissionRepository := &mocks.RolePermissionRepository{}
+
+		service := &permissionService{
+			cacheService:             cacheService,
+			rolePermissionRepository: rolePermissionRepository,
+		}
+
+		key := getRolePermissionsKey(models.MemberScope, string(models.KitchenMember))
+		permissions := []models.Permission{models.ListOrdersPermission, models.ApproveOrderPermission}
+
+		cacheService.On("Get", ctx, key, mock.Anything).Return(cache.ErrCacheMiss)
+		rolePermissionRepository.On("GetPermissionsByRole", ctx, models.MemberScope, string(models.KitchenMember)).Return(permissions, nil)
+		cacheService.On("Set", ctx, key, permissions, mock.Anything).Return(nil)
+
+		hasPermission, err := service.HasPermission(ctx, models.MemberScope, string(models.KitchenMember), models.GetMonthlyMetricsPermission)
+
+		assert.NoError(t, err)
+		assert.False(t, hasPermission)
+		cacheService.AssertExpectations(t)
+	})
+
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		rolePermissionRepository := &mocks.RolePermissionRepository{}
+
+		service := &permissionService{
+			cacheService:             cacheService,
+			rolePermissionRepository: rolePermissionRepository,
+		}
+
+		cacheService.On("Get", ctx, mock.Anything, mock.Anything).Return(cache.ErrCacheMiss)
+		rolePermissionRepository.On("GetPermissionsByRole", ctx, models.PlatformScope, string(models.Customer)).Return(nil, errors.New("database error"))
+
+		hasPermission, err := service.HasPermission(ctx, models.PlatformScope, string(models.Customer), models.CreateOrderPermission)
+
+		assert.Error(t, err)
+		assert.False(t, hasPermission)
+		cacheService.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestPermissionService_UpdateRolePermissions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should replace permissions and invalidate cache", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		rolePermissionRepository := &mocks.RolePermissionRepository{}
+
+		service := &permissionService{
+			cacheService:             cacheService,
+			rolePermissionRepository: rolePermissionRepository,
+		}
+
+		payload := models.UpdateRolePermissionsPayload{
+			Permissions: []models.Permission{models.ListOrdersPermission, models.ListOrdersPermission, models.ApproveOrderPermission},
+		}
+
+		rolePermissionRepository.On("ReplaceRolePermissions", ctx, models.MemberScope, string(models.CashierMember), mock.MatchedBy(func(rolePermissions []models.RolePermission) bool {
+			return len(rolePermissions) == 2
+		})).Return(nil)
+		cacheService.On("Delete", ctx, getRolePermissionsKey(models.MemberScope, string(models.CashierMember))).Return(nil)
+
+		err := service.UpdateRolePermissions(ctx, models.MemberScope, string(models.CashierMember), payload)
+
+		assert.NoError(t, err)
+		rolePermissionRepository.AssertExpectations(t)
+		cacheService.AssertExpectations(t)
+	})
+
+	t.Run("should return error when role is unknown", func(t *testing.T) {
+		rolePermissionRepository := &mocks.RolePermissionRepository{}
+
+		service := &permissionService{
+			rolePermissionRepository: rolePermissionRepository,
+		}
+
+		err := service.UpdateRolePermissions(ctx, models.MemberScope, "waiter", models.UpdateRolePermissionsPayload{})
+
+		assert.ErrorIs(t, err, models.ErrRoleNotFound)
+		rolePermissionRepository.AssertNotCalled(t, "ReplaceRolePermissions", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return error when permission is unknown", func(t *testing.T) {
+		rolePermissionRepository := &mocks.RolePermissionRepository{}
+
+		service := &permissionService{
+			rolePermissionRepository: rolePermissionRepository,
+		}
+
+		payload := models.UpdateRolePermissionsPayload{
+			Permissions: []models.Permission{"fly_drone"},
+		}
+
+		err := service.UpdateRolePermissions(ctx, models.PlatformScope, string(models.Courier), payload)
+
+		assert.ErrorIs(t, err, models.ErrUnknownPermission)
+		rolePermissionRepository.AssertNotCalled(t, "ReplaceRolePermissions", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestPermissionService_HasMemberPermission(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should use the restaurant override of the role", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		rolePermissionRepository := &mocks.RolePermissionRepository{}
+
+		service := &permissionService{
+			cacheService:             cacheService,
+			rolePermissionRepository: rolePermissionRepository,
+		}
+
+		restaurantID := uuid.New()
+
+		cacheService.On("Get", ctx, getRestaurantRolePermissionsKey(restaurantID), mock.AnythingOfType("*[]models.RestaurantRolePermission")).Run(func(args mock.Arguments) {
+			*args.Get(2).(*[]models.RestaurantRolePermission) = []models.RestaurantRolePermission{
+				{RestaurantID: restaurantID, Role: models.KitchenMember, Permission: models.ApproveOrderPermission, Granted: false},
+			}
+		}).Return(nil)
+
+		hasPermission, err := service.HasMemberPermission(ctx, restaurantID, models.KitchenMember, models.ApproveOrderPermission)
+
+		assert.NoError(t, err)
+		assert.False(t, hasPermission)
+		cacheService.AssertNotCalled(t, "Get", ctx, getRolePermissionsKey(models.MemberScope, string(models.KitchenMember)), mock.Anything)
+	})
+
+	t.Run("should follow the member scope grants without an override", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		rolePermissionRepository := &mocks.RolePermissionRepository{}
+
+		service := &permissionService{
+			cacheService:             cacheService,
+			rolePermissionRepository: rolePermissionRepository,
+		}
+
+		restaurantID := uuid.New()
+		key := getRestaurantRolePermissionsKey(restaurantID)
+
+		cacheService.On("Get", ctx, key, mock.Anything).Return(cache.ErrCacheMiss)
+		rolePermissionRepository.On("GetRestaurantRolePermissions", ctx, restaurantID).Return([]models.RestaurantRolePermission{}, nil)
+		cacheService.On("Set", ctx, key, []models.RestaurantRolePermission{}, mock.Anything).Return(nil)
+		cacheService.On("Get", ctx, getRolePermissionsKey(models.MemberScope, string(models.KitchenMember)), mock.AnythingOfType("*[]models.Permission")).Run(func(args mock.Arguments) {
+			*args.Get(2).(*[]models.Permission) = []models.Permission{models.ApproveOrderPermission}
+		}).Return(nil)
+
+		hasPermission, err := service.HasMemberPermission(ctx, restaurantID, models.KitchenMember, models.ApproveOrderPermission)
+
+		assert.NoError(t, err)
+		assert.True(t, hasPermission)
+		cacheService.AssertExpectations(t)
+	})
+}
+
+func TestPermissionService_UpdateRestaurantRolePermissions(t *testing.T) {
+	restaurantID := uuid.New()
+	ctx := context.WithValue(context.Background(), internal.RestaurantIDKey, &restaurantID)
+
+	t.Run("should store only the permissions that differ from the defaults", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		rolePermissionRepository := &mocks.RolePermissionRepository{}
+
+		service := &permissionService{
+			cacheService:             cacheService,
+			rolePermissionRepository: rolePermissionRepository,
+		}
+
+		payload := models.UpdateRolePermissionsPayload{
+			Permissions: []models.Permission{models.ListOrdersPermission, models.UpdateMenuPermission},
+		}
+
+		cacheService.On("Get", ctx, getRolePermissionsKey(models.MemberScope, string(models.KitchenMember)), mock.AnythingOfType("*[]models.Permission")).Run(func(args mock.Arguments) {
+			*args.Get(2).(*[]models.Permission) = []models.Permission{models.ListOrdersPermission, models.ApproveOrderPermission}
+		}).Return(nil)
+		rolePermissionRepository.On("ReplaceRestaurantRolePermissions", ctx, restaurantID, models.KitchenMember, mock.MatchedBy(func(rolePermissions []models.RestaurantRolePermission) bool {
+			return len(rolePermissions) == 2 &&
+				rolePermissions[0].Permission == models.ApproveOrderPermission && !rolePermissions[0].Granted &&
+				rolePermissions[1].Permission == models.UpdateMenuPermission && rolePermissions[1].Granted
+		})).Return(nil)
+		cacheService.On("Delete", ctx, getRestaurantRolePermissionsKey(restaurantID)).Return(nil)
+
+		err := service.UpdateRestaurantRolePermissions(ctx, models.KitchenMember, payload)
+
+		assert.NoError(t, err)
+		rolePermissionRepository.AssertExpectations(t)
+		cacheService.AssertExpectations(t)
+	})
+
+	t.Run("should return error when role is the owner", func(t *testing.T) {
+		rolePermissionRepository := &mocks.RolePermissionRepository{}
+
+		service := &permissionService{
+			rolePermissionRepository: rolePermissionRepository,
+		}
+
+		err := service.UpdateRestaurantRolePermissions(ctx, models.OwnerMember, models.UpdateRolePermissionsPayload{})
+
+		assert.ErrorIs(t, err, models.ErrOwnerRoleNotEditable)
+		rolePermissionRepository.AssertNotCalled(t, "ReplaceRestaurantRolePermissions", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return error when restaurant ID is not in context", func(t *testing.T) {
+		rolePermissionRepository := &mocks.RolePermissionRepository{}
+
+		service := &permissionService{
+			rolePermissionRepository: rolePermissionRepository,
+		}
+
+		err := service.UpdateRestaurantRolePermissions(context.Background(), models.KitchenMember, models.UpdateRolePermissionsPayload{})
+
+		assert.ErrorIs(t, err, models.ErrRestaurantNotFound)
+		rolePermissionRepository.AssertNotCalled(t, "ReplaceRestaurantRolePermissions", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
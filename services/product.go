@@ -38,7 +38,7 @@ func NewProductService(di *internal.Di) (ProductService, error) {
 
 func (p *productService) GetPopularProducts(ctx context.Context) ([]models.PopularProductResponse, error) {
 	restaurantID, ok := ctx.Value(internal.RestaurantIDKey).(*uuid.UUID)
-	if !ok {
+	if !ok || restaurantID == nil {
 		return nil, models.ErrRestaurantNotFound
 	}
 
@@ -63,7 +63,7 @@ func (p *productService) GetPopularProducts(ctx context.Context) ([]models.Popul
 
 func (p *productService) CreateProduct(ctx context.Context, payload *models.CreateOrUpdateProductPayload) (*models.Product, error) {
 	restaurantID, ok := ctx.Value(internal.RestaurantIDKey).(*uuid.UUID)
-	if !ok {
+	if !ok || restaurantID == nil {
 		return nil, models.ErrRestaurantNotFound
 	}
 
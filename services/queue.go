@@ -2,19 +2,38 @@ package services
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/G-Villarinho/food-shop-api/client"
+	"github.com/G-Villarinho/food-shop-api/config"
 	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/models"
 )
 
 const (
-	QueueSendEmail = "send_email_queue"
+	QueueSendEmail       = "send_email_queue"
+	QueueWebhookDelivery = "webhook_delivery_queue"
+	QueueWebhookEvent    = "webhook_event_queue"
+)
+
+const (
+	defaultQueueMaxRetries = 5
+	defaultQueueRetryDelay = 10 * time.Second
+
+	retryCountHeader    = "x-retry-count"
+	errorHeader         = "x-error"
+	failedAtHeader      = "x-failed-at"
+	originalQueueHeader = "x-original-queue"
 )
 
 //go:generate mockery --name=QueueService --output=../mocks --outpkg=mocks
 type QueueService interface {
 	Publish(queueName string, message []byte) error
-	Consume(queueName string) (<-chan []byte, error)
+	Consume(queueName string) (<-chan *client.Message, error)
+	Retry(queueName string, message *client.Message, cause error) error
+	DeadLetter(queueName string, message *client.Message, cause error) error
+	GetDeadLetters(queueName string, limit int) ([]models.DeadLetter, error)
+	ReplayDeadLetters(queueName string, limit int) (int, error)
 }
 
 type queueService struct {
@@ -42,7 +61,23 @@ func (q *queueService) Publish(queueName string, message []byte) error {
 	return nil
 }
 
-func (q *queueService) Consume(queueName string) (<-chan []byte, error) {
+// Consume declares the retry and dead-letter queues of queueName before
+// subscribing to it. Every message must be acknowledged, retried or
+// dead-lettered by the consumer.
+func (q *queueService) Consume(queueName string) (<-chan *client.Message, error) {
+	for attempt := 1; attempt <= maxQueueRetries(); attempt++ {
+		delay := queueRetryDelay(attempt)
+		options := client.QueueOptions{MessageTTL: delay, DeadLetterQueue: queueName}
+
+		if err := q.rabbitMQClient.DeclareQueue(retryQueueName(queueName, delay), options); err != nil {
+			return nil, fmt.Errorf("declaring retry queue: %w", err)
+		}
+	}
+
+	if err := q.rabbitMQClient.DeclareQueue(deadLetterQueueName(queueName), client.QueueOptions{}); err != nil {
+		return nil, fmt.Errorf("declaring dead-letter queue: %w", err)
+	}
+
 	messages, err := q.rabbitMQClient.Consume(queueName)
 	if err != nil {
 		return nil, fmt.Errorf("consuming message from queue: %w", err)
@@ -50,3 +85,163 @@ func (q *queueService) Consume(queueName string) (<-chan []byte, error) {
 
 	return messages, nil
 }
+
+// Retry sends the message to the delay queue of its next attempt, which hands
+// it back to queueName once the delay expires. Messages out of retries are
+// dead-lettered.
+func (q *queueService) Retry(queueName string, message *client.Message, cause error) error {
+	retries := retryCount(message.Headers)
+	if retries >= maxQueueRetries() {
+		return q.DeadLetter(queueName, message, cause)
+	}
+
+	headers := map[string]any{
+		retryCountHeader: int32(retries + 1),
+		errorHeader:      cause.Error(),
+	}
+
+	if err := q.rabbitMQClient.PublishWithHeaders(retryQueueName(queueName, queueRetryDelay(retries+1)), message.Body, headers); err != nil {
+		return fmt.Errorf("publishing message to retry queue: %w", err)
+	}
+
+	if err := message.Ack(); err != nil {
+		return fmt.Errorf("acknowledging message: %w", err)
+	}
+
+	return nil
+}
+
+func (q *queueService) DeadLetter(queueName string, message *client.Message, cause error) error {
+	headers := map[string]any{
+		retryCountHeader:    int32(retryCount(message.Headers)),
+		errorHeader:         cause.Error(),
+		failedAtHeader:      time.Now().UTC().Format(time.RFC3339),
+		originalQueueHeader: queueName,
+	}
+
+	if err := q.rabbitMQClient.PublishWithHeaders(deadLetterQueueName(queueName), message.Body, headers); err != nil {
+		return fmt.Errorf("publishing message to dead-letter queue: %w", err)
+	}
+
+	if err := message.Ack(); err != nil {
+		return fmt.Errorf("acknowledging message: %w", err)
+	}
+
+	return nil
+}
+
+// GetDeadLetters peeks at up to limit dead-lettered messages. They are held
+// unacknowledged while reading and returned to the queue afterwards.
+func (q *queueService) GetDeadLetters(queueName string, limit int) ([]models.DeadLetter, error) {
+	var messages []*client.Message
+	defer func() {
+		for _, message := range messages {
+			_ = message.Reject(true)
+		}
+	}()
+
+	deadLetters := make([]models.DeadLetter, 0, limit)
+	for len(deadLetters) < limit {
+		message, err := q.rabbitMQClient.Get(deadLetterQueueName(queueName))
+		if err != nil {
+			return nil, fmt.Errorf("getting message from dead-letter queue: %w", err)
+		}
+
+		if message == nil {
+			break
+		}
+
+		messages = append(messages, message)
+		deadLetters = append(deadLetters, toDeadLetter(queueName, message))
+	}
+
+	return deadLetters, nil
+}
+
+// ReplayDeadLetters moves up to limit dead-lettered messages back to the queue
+// they came from with a fresh retry budget.
+func (q *queueService) ReplayDeadLetters(queueName string, limit int) (int, error) {
+	replayed := 0
+	for replayed < limit {
+		message, err := q.rabbitMQClient.Get(deadLetterQueueName(queueName))
+		if err != nil {
+			return replayed, fmt.Errorf("getting message from dead-letter queue: %w", err)
+		}
+
+		if message == nil {
+			break
+		}
+
+		target := queueName
+		if original, ok := message.Headers[originalQueueHeader].(string); ok && original != "" {
+			target = original
+		}
+
+		if err := q.rabbitMQClient.Publish(target, message.Body); err != nil {
+			_ = message.Reject(true)
+			return replayed, fmt.Errorf("publishing message to queue: %w", err)
+		}
+
+		if err := message.Ack(); err != nil {
+			return replayed, fmt.Errorf("acknowledging message: %w", err)
+		}
+
+		replayed++
+	}
+
+	return replayed, nil
+}
+
+func toDeadLetter(queueName string, message *client.Message) models.DeadLetter {
+	deadLetter := models.DeadLetter{
+		Queue:   queueName,
+		Body:    string(message.Body),
+		Retries: retryCount(message.Headers),
+	}
+
+	deadLetter.Error, _ = message.Headers[errorHeader].(string)
+	deadLetter.FailedAt, _ = message.Headers[failedAtHeader].(string)
+
+	return deadLetter
+}
+
+func retryCount(headers map[string]any) int {
+	switch value := headers[retryCountHeader].(type) {
+	case int32:
+		return int(value)
+	case int64:
+		return int(value)
+	case int:
+		return value
+	default:
+		return 0
+	}
+}
+
+func maxQueueRetries() int {
+	if config.Env.Queue.MaxRetries <= 0 {
+		return defaultQueueMaxRetries
+	}
+
+	return config.Env.Queue.MaxRetries
+}
+
+// queueRetryDelay doubles the base delay on every attempt: 10s, 20s, 40s...
+func queueRetryDelay(attempt int) time.Duration {
+	delay := time.Duration(config.Env.Queue.RetryDelay) * time.Second
+	if delay <= 0 {
+		delay = defaultQueueRetryDelay
+	}
+
+	return delay << (attempt - 1)
+}
+
+// retryQueueName includes the delay so changing QUEUE_RETRY_DELAY declares new
+// queues instead of clashing with the TTL of the existing ones.
+func retryQueueName(queueName string, delay time.Duration) string {
+	return fmt.Sprintf("%s.retry.%s", queueName, delay)
+}
+
+func deadLetterQueueName(queueName string) string {
+	return queueName + ".dlq"
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/client"
+	"github.com/G-Villarinho/food-shop-api/config"
+	"github.com/G-Villarinho/food-shop-api/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestQueueService_Consume(t *testing.T) {
+	config.Env.Queue.MaxRetries = 2
+	config.Env.Queue.RetryDelay = 5
+
+	t.Run("should declare retry and dead-letter queues before consuming", func(t *testing.T) {
+		rabbitMQClient := &mocks.RabbitMQClient{}
+		service := &queueService{rabbitMQClient: rabbitMQClient}
+
+		messages := make(<-chan *client.Message)
+
+		rabbitMQClient.On("DeclareQueue", "orders.retry.5s", client.QueueOptions{MessageTTL: 5 * time.Second, DeadLetterQueue: "orders"}).Return(nil)
+		rabbitMQClient.On("DeclareQueue", "orders.retry.10s", client.QueueOptions{MessageTTL: 10 * time.Second, DeadLetterQueue: "orders"}).Return(nil)
+		rabbitMQClient.On("DeclareQueue", "orders.dlq", client.QueueOptions{}).Return(nil)
+		rabbitMQClient.On("Consume", "orders").Return(messages, nil)
+
+		result, err := service.Consume("orders")
+
+		assert.NoError(t, err)
+		assert.Equal(t, messages, result)
+		rabbitMQClient.AssertExpectations(t)
+	})
+}
+
+func TestQueueService_Retry(t *testing.T) {
+	config.Env.Queue.MaxRetries = 3
+	config.Env.Queue.RetryDelay = 10
+
+	t.Run("should send message to the delay queue of the next attempt", func(t *testing.T) {
+		rabbitMQClient := &mocks.RabbitMQClient{}
+		service := &queueService{rabbitMQClient: rabbitMQClient}
+
+		message := client.NewMessage([]byte("payload"), map[string]any{retryCountHeader: int32(1)})
+
+		rabbitMQClient.On("PublishWithHeaders", "orders.retry.20s", []byte("payload"), map[string]any{
+			retryCountHeader: int32(2),
+			errorHeader:      "smtp down",
+		}).Return(nil)
+
+		err := service.Retry("orders", message, errors.New("smtp down"))
+
+		assert.NoError(t, err)
+		rabbitMQClient.AssertExpectations(t)
+	})
+
+	t.Run("should dead-letter message when retries are exhausted", func(t *testing.T) {
+		rabbitMQClient := &mocks.RabbitMQClient{}
+		service := &queueService{rabbitMQClient: rabbitMQClient}
+
+		message := client.NewMessage([]byte("payload"), map[string]any{retryCountHeader: int32(3)})
+
+		rabbitMQClient.On("PublishWithHeaders", "orders.dlq", []byte("payload"), mock.MatchedBy(func(headers map[string]any) bool {
+			return headers[retryCountHeader] == int32(3) &&
+				headers[errorHeader] == "smtp down" &&
+				headers[originalQueueHeader] == "orders" &&
+				headers[failedAtHeader] != nil
+		})).Return(nil)
+
+		err := service.Retry("orders", message, errors.New("smtp down"))
+
+		assert.NoError(t, err)
+		rabbitMQClient.AssertExpectations(t)
+		rabbitMQClient.AssertNotCalled(t, "PublishWithHeaders", "orders.retry.80s", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return error when the retry can't be published", func(t *testing.T) {
+		rabbitMQClient := &mocks.RabbitMQClient{}
+		service := &queueService{rabbitMQClient: rabbitMQClient}
+
+		message := client.NewMessage([]byte("payload"), nil)
+
+		rabbitMQClient.On("PublishWithHeaders", "orders.retry.10s", []byte("payload"), mock.Anything).Return(errors.New("channel closed"))
+
+		err := service.Retry("orders", message, errors.New("smtp down"))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestQueueService_GetDeadLetters(t *testing.T) {
+	t.Run("should read dead letters up to the limit", func(t *testing.T) {
+		rabbitMQClient := &mocks.RabbitMQClient{}
+		service := &queueService{rabbitMQClient: rabbitMQClient}
+
+		message := client.NewMessage([]byte("payload"), map[string]any{
+			retryCountHeader: int32(5),
+			errorHeader:      "smtp down",
+			failedAtHeader:   "2024-01-01T00:00:00Z",
+		})
+
+		rabbitMQClient.On("Get", "orders.dlq").Return(message, nil).Once()
+		rabbitMQClient.On("Get", "orders.dlq").Return(nil, nil).Once()
+
+		deadLetters, err := service.GetDeadLetters("orders", 10)
+
+		assert.NoError(t, err)
+		assert.Len(t, deadLetters, 1)
+		assert.Equal(t, "payload", deadLetters[0].Body)
+		assert.Equal(t, "smtp down", deadLetters[0].Error)
+		assert.Equal(t, 5, deadLetters[0].Retries)
+		assert.Equal(t, "2024-01-01T00:00:00Z", deadLetters[0].FailedAt)
+	})
+}
+
+func TestQueueService_ReplayDeadLetters(t *testing.T) {
+	t.Run("should publish dead letters back to their original queue", func(t *testing.T) {
+		rabbitMQClient := &mocks.RabbitMQClient{}
+		service := &queueService{rabbitMQClient: rabbitMQClient}
+
+		first := client.NewMessage([]byte("first"), map[string]any{originalQueueHeader: "orders"})
+		second := client.NewMessage([]byte("second"), nil)
+
+		rabbitMQClient.On("Get", "orders.dlq").Return(first, nil).Once()
+		rabbitMQClient.On("Get", "orders.dlq").Return(second, nil).Once()
+		rabbitMQClient.On("Publish", "orders", mock.Anything).Return(nil)
+
+		replayed, err := service.ReplayDeadLetters("orders", 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, replayed)
+		rabbitMQClient.AssertCalled(t, "Publish", "orders", []byte("first"))
+		rabbitMQClient.AssertCalled(t, "Publish", "orders", []byte("second"))
+	})
+
+	t.Run("should stop when the dead-letter queue is empty", func(t *testing.T) {
+		rabbitMQClient := &mocks.RabbitMQClient{}
+		service := &queueService{rabbitMQClient: rabbitMQClient}
+
+		rabbitMQClient.On("Get", "orders.dlq").Return(nil, nil)
+
+		replayed, err := service.ReplayDeadLetters("orders", 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, replayed)
+		rabbitMQClient.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+	})
+}
+
+func TestQueueService_WithMemoryBroker(t *testing.T) {
+	t.Run("should dead-letter a message and replay it to the original queue", func(t *testing.T) {
+		rabbitMQClient, _ := client.NewMemoryRabbitMQClient(nil)
+		defer rabbitMQClient.Disconnect()
+
+		service := &queueService{rabbitMQClient: rabbitMQClient}
+
+		messages, err := service.Consume("orders")
+		assert.NoError(t, err)
+
+		assert.NoError(t, service.Publish("orders", []byte("payload")))
+
+		message := <-messages
+		assert.NoError(t, service.DeadLetter("orders", message, errors.New("invalid payload")))
+
+		deadLetters, err := service.GetDeadLetters("orders", 10)
+		assert.NoError(t, err)
+		assert.Len(t, deadLetters, 1)
+		assert.Equal(t, "invalid payload", deadLetters[0].Error)
+
+		replayed, err := service.ReplayDeadLetters("orders", 10)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, replayed)
+
+		select {
+		case message := <-messages:
+			assert.Equal(t, []byte("payload"), message.Body)
+			assert.NoError(t, message.Ack())
+		case <-time.After(time.Second):
+			t.Fatal("replayed message was not delivered")
+		}
+	})
+}
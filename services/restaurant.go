@@ -3,7 +3,9 @@ package services
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/G-Villarinho/food-shop-api/config"
 	"github.com/G-Villarinho/food-shop-api/internal"
 	"github.com/G-Villarinho/food-shop-api/models"
 	"github.com/G-Villarinho/food-shop-api/repositories"
@@ -75,9 +77,41 @@ func (r *restaurantService) CreateOrder(ctx context.Context, restaurantID uuid.U
 		return models.ErrRestaurantNotFound
 	}
 
+	if restaurant.Status == models.SuspendedRestaurant {
+		return models.ErrRestaurantSuspended
+	}
+
+	if payload.ScheduledFor != nil {
+		if err := validateScheduledFor(restaurant, *payload.ScheduledFor); err != nil {
+			return err
+		}
+	}
+
 	if err := r.orderService.CreateOrder(ctx, custommerID, restaurantID, payload); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+func validateScheduledFor(restaurant *models.Restaurant, scheduledFor time.Time) error {
+	minLeadTime := time.Duration(config.Env.Scheduling.MinLeadTime) * time.Minute
+	if scheduledFor.Before(time.Now().UTC().Add(minLeadTime)) {
+		return models.ErrScheduledTimeTooSoon
+	}
+
+	location := time.UTC
+	if config.Env.Scheduling.Timezone != "" {
+		loc, err := time.LoadLocation(config.Env.Scheduling.Timezone)
+		if err != nil {
+			return fmt.Errorf("load restaurant timezone: %w", err)
+		}
+		location = loc
+	}
+
+	if !restaurant.IsOpenAt(scheduledFor.In(location)) {
+		return models.ErrRestaurantClosedAtScheduledTime
+	}
+
+	return nil
+}
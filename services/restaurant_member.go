@@ -0,0 +1,275 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/G-Villarinho/food-shop-api/cache"
+	"github.com/G-Villarinho/food-shop-api/config"
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/G-Villarinho/food-shop-api/repositories"
+	"github.com/G-Villarinho/food-shop-api/services/email"
+	"github.com/google/uuid"
+)
+
+//go:generate mockery --name=RestaurantMemberService --output=../mocks --outpkg=mocks
+type RestaurantMemberService interface {
+	InviteMember(ctx context.Context, payload models.InviteMemberPayload) error
+	AcceptInvitation(ctx context.Context, code uuid.UUID) (*models.AuthTokenResponse, error)
+	GetMembers(ctx context.Context) ([]*models.RestaurantMemberResponse, error)
+	RemoveMember(ctx context.Context, userID uuid.UUID) error
+}
+
+type restaurantMemberService struct {
+	di                         *internal.Di
+	emailFactory               email.EmailFactory
+	cacheService               cache.CacheService
+	outboxRepository           repositories.OutboxRepository
+	sessionService             SessionService
+	restaurantRepository       repositories.RestaurantRepository
+	restaurantMemberRepository repositories.RestaurantMemberRepository
+	userRepository             repositories.UserRepository
+}
+
+func NewRestaurantMemberService(di *internal.Di) (RestaurantMemberService, error) {
+	cacheService, err := internal.Invoke[cache.CacheService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionService, err := internal.Invoke[SessionService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	restaurantRepository, err := internal.Invoke[repositories.RestaurantRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	restaurantMemberRepository, err := internal.Invoke[repositories.RestaurantMemberRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	userRepository, err := internal.Invoke[repositories.UserRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	outboxRepository, err := internal.Invoke[repositories.OutboxRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &restaurantMemberService{
+		di:                         di,
+		emailFactory:               *email.NewEmailTaskFactory(),
+		cacheService:               cacheService,
+		outboxRepository:           outboxRepository,
+		sessionService:             sessionService,
+		restaurantRepository:       restaurantRepository,
+		restaurantMemberRepository: restaurantMemberRepository,
+		userRepository:             userRepository,
+	}, nil
+}
+
+func (r *restaurantMemberService) InviteMember(ctx context.Context, payload models.InviteMemberPayload) error {
+	restaurantID, ok := ctx.Value(internal.RestaurantIDKey).(*uuid.UUID)
+	if !ok || restaurantID == nil {
+		return models.ErrRestaurantNotFound
+	}
+
+	userID, ok := ctx.Value(internal.UserIDKey).(uuid.UUID)
+	if !ok {
+		return models.ErrUserNotFoundInContext
+	}
+
+	user, err := r.userRepository.GetUserByEmail(ctx, payload.Email)
+	if err != nil {
+		return fmt.Errorf("get user by email: %w", err)
+	}
+
+	if user != nil {
+		if err := r.ensureCanJoinRestaurant(ctx, user); err != nil {
+			return err
+		}
+	}
+
+	restaurant, err := r.restaurantRepository.GetRestaurantByID(ctx, *restaurantID)
+	if err != nil {
+		return fmt.Errorf("get restaurant by id: %w", err)
+	}
+
+	if restaurant == nil {
+		return models.ErrRestaurantNotFound
+	}
+
+	code, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("generate code: %w", err)
+	}
+
+	ttl := config.InvitationExp()
+	if err := r.cacheService.Set(ctx, getMemberInvitationKey(code), payload.ToMemberInvitation(*restaurantID, userID), ttl); err != nil {
+		return fmt.Errorf("set member invitation: %w", err)
+	}
+
+	invitationLink := fmt.Sprintf("%s/restaurants/invitations/accept?code=%s&redirect=%s", config.Env.APIBaseURL, url.QueryEscape(code.String()), url.QueryEscape(config.Env.RedirectURL))
+
+	message, err := models.NewOutboxMessage(QueueSendEmail, r.emailFactory.CreateRestaurantInvitationEmail(payload.Email, payload.FullName, restaurant.Name, string(payload.Role), invitationLink, int(ttl.Hours())))
+	if err != nil {
+		return fmt.Errorf("create email message: %w", err)
+	}
+
+	if err := r.outboxRepository.CreateMessages(ctx, []models.OutboxMessage{*message}); err != nil {
+		return fmt.Errorf("create outbox messages: %w", err)
+	}
+
+	return nil
+}
+
+func (r *restaurantMemberService) AcceptInvitation(ctx context.Context, code uuid.UUID) (*models.AuthTokenResponse, error) {
+	var invitation models.MemberInvitation
+	if err := r.cacheService.Get(ctx, getMemberInvitationKey(code), &invitation); err != nil {
+		if errors.Is(err, cache.ErrCacheMiss) {
+			return nil, models.ErrInvitationNotFound
+		}
+		return nil, fmt.Errorf("get member invitation: %w", err)
+	}
+
+	user, err := r.userRepository.GetUserByEmail(ctx, invitation.Email)
+	if err != nil {
+		return nil, fmt.Errorf("get user by email: %w", err)
+	}
+
+	if user == nil {
+		user = invitation.ToCreateUserPayload().ToUser(models.Manager)
+		if err := r.userRepository.CreateUser(ctx, *user); err != nil {
+			return nil, fmt.Errorf("create user: %w", err)
+		}
+	} else if err := r.ensureCanJoinRestaurant(ctx, user); err != nil {
+		return nil, err
+	}
+
+	member := models.NewRestaurantMember(invitation.RestaurantID, user.ID, invitation.Role)
+	if err := r.restaurantMemberRepository.CreateMember(ctx, *member); err != nil {
+		return nil, fmt.Errorf("create member: %w", err)
+	}
+
+	if err := r.cacheService.Delete(ctx, getMemberInvitationKey(code)); err != nil {
+		return nil, fmt.Errorf("delete member invitation: %w", err)
+	}
+
+	session, err := r.sessionService.CreateSession(ctx, user.ID, &member.RestaurantID, user.Role, &member.Role)
+	if err != nil {
+		return nil, fmt.Errorf("create session: %w", err)
+	}
+
+	return &models.AuthTokenResponse{
+		Token:        session.Token,
+		RefreshToken: session.RefreshToken,
+	}, nil
+}
+
+func (r *restaurantMemberService) GetMembers(ctx context.Context) ([]*models.RestaurantMemberResponse, error) {
+	restaurantID, ok := ctx.Value(internal.RestaurantIDKey).(*uuid.UUID)
+	if !ok || restaurantID == nil {
+		return nil, models.ErrRestaurantNotFound
+	}
+
+	members, err := r.restaurantMemberRepository.GetMembersByRestaurantID(ctx, *restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("get members by restaurant id: %w", err)
+	}
+
+	response := make([]*models.RestaurantMemberResponse, len(members))
+	for i, member := range members {
+		response[i] = member.ToRestaurantMemberResponse()
+	}
+
+	return response, nil
+}
+
+func (r *restaurantMemberService) RemoveMember(ctx context.Context, userID uuid.UUID) error {
+	restaurantID, ok := ctx.Value(internal.RestaurantIDKey).(*uuid.UUID)
+	if !ok || restaurantID == nil {
+		return models.ErrRestaurantNotFound
+	}
+
+	member, err := r.restaurantMemberRepository.GetMemberByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get member by user id: %w", err)
+	}
+
+	if member == nil || member.RestaurantID != *restaurantID {
+		return models.ErrMemberNotFound
+	}
+
+	if member.Role == models.OwnerMember {
+		return models.ErrCannotRemoveRestaurantOwner
+	}
+
+	if err := r.restaurantMemberRepository.DeleteMember(ctx, member.ID); err != nil {
+		return fmt.Errorf("delete member: %w", err)
+	}
+
+	if err := r.sessionService.DeleteAllSessions(ctx, userID); err != nil && !errors.Is(err, models.ErrSessionNotFound) {
+		return fmt.Errorf("delete all sessions: %w", err)
+	}
+
+	if err := r.cacheService.Delete(ctx, getUserKey(userID)); err != nil {
+		return fmt.Errorf("delete user from cache: %w", err)
+	}
+
+	return nil
+}
+
+func (r *restaurantMemberService) ensureCanJoinRestaurant(ctx context.Context, user *models.User) error {
+	if user.Status == models.Blocked {
+		return models.ErrUserBlocked
+	}
+
+	if user.Role != models.Manager {
+		return models.ErrUserCannotJoinRestaurant
+	}
+
+	member, err := r.restaurantMemberRepository.GetMemberByUserID(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("get member by user id: %w", err)
+	}
+
+	if member != nil {
+		return models.ErrUserAlreadyMember
+	}
+
+	return nil
+}
+
+// getSessionMembership returns the restaurant and member role that a session of
+// the user acts on. Only managers have them, and a manager removed from their
+// restaurant keeps the role, so they can be invited again, but has nothing to
+// manage until then.
+func getSessionMembership(ctx context.Context, restaurantMemberRepository repositories.RestaurantMemberRepository, user *models.User) (*uuid.UUID, *models.MemberRole, error) {
+	if user.Role != models.Manager {
+		return nil, nil, nil
+	}
+
+	member, err := restaurantMemberRepository.GetMemberByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get member by user id: %w", err)
+	}
+
+	if member == nil {
+		return nil, nil, models.ErrRestaurantNotFound
+	}
+
+	return &member.RestaurantID, &member.Role, nil
+}
+
+func getMemberInvitationKey(code uuid.UUID) string {
+	return fmt.Sprintf("member-invitation:%s", code.String())
+}
@@ -0,0 +1,257 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/cache"
+	"github.com/G-Villarinho/food-shop-api/config"
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/mocks"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRestaurantMemberService_InviteMember(t *testing.T) {
+	restaurantID := uuid.New()
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), internal.RestaurantIDKey, &restaurantID)
+	ctx = context.WithValue(ctx, internal.UserIDKey, userID)
+
+	payload := models.InviteMemberPayload{
+		FullName: "Kitchen Staff",
+		Email:    "kitchen@example.com",
+		Role:     models.KitchenMember,
+	}
+
+	t.Run("should return error when user already belongs to a restaurant", func(t *testing.T) {
+		userRepository := &mocks.UserRepository{}
+		restaurantMemberRepository := &mocks.RestaurantMemberRepository{}
+		outboxRepository := &mocks.OutboxRepository{}
+
+		service := &restaurantMemberService{
+			userRepository:             userRepository,
+			restaurantMemberRepository: restaurantMemberRepository,
+			outboxRepository:           outboxRepository,
+		}
+
+		user := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, Role: models.Manager}
+
+		userRepository.On("GetUserByEmail", ctx, payload.Email).Return(user, nil)
+		restaurantMemberRepository.On("GetMemberByUserID", ctx, user.ID).Return(&models.RestaurantMember{UserID: user.ID}, nil)
+
+		err := service.InviteMember(ctx, payload)
+
+		assert.ErrorIs(t, err, models.ErrUserAlreadyMember)
+		outboxRepository.AssertNotCalled(t, "CreateMessages", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return error when user is a customer", func(t *testing.T) {
+		userRepository := &mocks.UserRepository{}
+		restaurantMemberRepository := &mocks.RestaurantMemberRepository{}
+
+		service := &restaurantMemberService{
+			userRepository:             userRepository,
+			restaurantMemberRepository: restaurantMemberRepository,
+		}
+
+		user := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, Role: models.Customer}
+
+		userRepository.On("GetUserByEmail", ctx, payload.Email).Return(user, nil)
+
+		err := service.InviteMember(ctx, payload)
+
+		assert.ErrorIs(t, err, models.ErrUserCannotJoinRestaurant)
+		restaurantMemberRepository.AssertNotCalled(t, "GetMemberByUserID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should store invitation and publish email", func(t *testing.T) {
+		userRepository := &mocks.UserRepository{}
+		restaurantRepository := &mocks.RestaurantRepository{}
+		cacheService := &mocks.CacheService{}
+		outboxRepository := &mocks.OutboxRepository{}
+
+		service := &restaurantMemberService{
+			userRepository:       userRepository,
+			restaurantRepository: restaurantRepository,
+			cacheService:         cacheService,
+			outboxRepository:     outboxRepository,
+		}
+
+		userRepository.On("GetUserByEmail", ctx, payload.Email).Return(nil, nil)
+		restaurantRepository.On("GetRestaurantByID", ctx, restaurantID).Return(&models.Restaurant{Name: "Test Restaurant"}, nil)
+		cacheService.On("Set", ctx, mock.AnythingOfType("string"), mock.MatchedBy(func(invitation *models.MemberInvitation) bool {
+			return invitation.RestaurantID == restaurantID && invitation.InvitedBy == userID && invitation.Role == models.KitchenMember
+		}), mock.Anything).Return(nil)
+		outboxRepository.On("CreateMessages", mock.Anything, emailOutbox()).Return(nil)
+
+		err := service.InviteMember(ctx, payload)
+
+		assert.NoError(t, err)
+		cacheService.AssertExpectations(t)
+		outboxRepository.AssertExpectations(t)
+	})
+
+	t.Run("should expire the invitation after the default and escape the link", func(t *testing.T) {
+		userRepository := &mocks.UserRepository{}
+		restaurantRepository := &mocks.RestaurantRepository{}
+		cacheService := &mocks.CacheService{}
+		outboxRepository := &mocks.OutboxRepository{}
+
+		service := &restaurantMemberService{
+			userRepository:       userRepository,
+			restaurantRepository: restaurantRepository,
+			cacheService:         cacheService,
+			outboxRepository:     outboxRepository,
+		}
+
+		invitationExp, redirectURL := config.Env.Cache.InvitationExp, config.Env.RedirectURL
+		config.Env.Cache.InvitationExp = 0
+		config.Env.RedirectURL = "http://localhost:3000/?next=orders&tab=all"
+		defer func() {
+			config.Env.Cache.InvitationExp, config.Env.RedirectURL = invitationExp, redirectURL
+		}()
+
+		userRepository.On("GetUserByEmail", ctx, payload.Email).Return(nil, nil)
+		restaurantRepository.On("GetRestaurantByID", ctx, restaurantID).Return(&models.Restaurant{Name: "Test Restaurant"}, nil)
+		cacheService.On("Set", ctx, mock.AnythingOfType("string"), mock.Anything, 72*time.Hour).Return(nil)
+		outboxRepository.On("CreateMessages", mock.Anything, mock.MatchedBy(func(messages []models.OutboxMessage) bool {
+			return len(messages) == 1 && strings.Contains(messages[0].Payload, "redirect=http%3A%2F%2Flocalhost%3A3000%2F%3Fnext%3Dorders%26tab%3Dall")
+		})).Return(nil)
+
+		err := service.InviteMember(ctx, payload)
+
+		assert.NoError(t, err)
+		cacheService.AssertExpectations(t)
+		outboxRepository.AssertExpectations(t)
+	})
+}
+
+func TestRestaurantMemberService_AcceptInvitation(t *testing.T) {
+	ctx := context.Background()
+	code := uuid.New()
+
+	t.Run("should return error when invitation is not found", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+
+		service := &restaurantMemberService{
+			cacheService: cacheService,
+		}
+
+		cacheService.On("Get", ctx, getMemberInvitationKey(code), mock.Anything).Return(cache.ErrCacheMiss)
+
+		token, err := service.AcceptInvitation(ctx, code)
+
+		assert.ErrorIs(t, err, models.ErrInvitationNotFound)
+		assert.Empty(t, token)
+	})
+
+	t.Run("should create user, member and session for a new user", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		userRepository := &mocks.UserRepository{}
+		restaurantMemberRepository := &mocks.RestaurantMemberRepository{}
+		sessionService := &mocks.SessionService{}
+
+		service := &restaurantMemberService{
+			cacheService:               cacheService,
+			userRepository:             userRepository,
+			restaurantMemberRepository: restaurantMemberRepository,
+			sessionService:             sessionService,
+		}
+
+		invitation := models.MemberInvitation{
+			RestaurantID: uuid.New(),
+			FullName:     "Cashier Staff",
+			Email:        "cashier@example.com",
+			Role:         models.CashierMember,
+		}
+
+		cacheService.On("Get", ctx, getMemberInvitationKey(code), mock.AnythingOfType("*models.MemberInvitation")).Run(func(args mock.Arguments) {
+			*args.Get(2).(*models.MemberInvitation) = invitation
+		}).Return(nil)
+		userRepository.On("GetUserByEmail", ctx, invitation.Email).Return(nil, nil)
+		userRepository.On("CreateUser", ctx, mock.MatchedBy(func(user models.User) bool {
+			return user.Email == invitation.Email && user.Role == models.Manager
+		})).Return(nil)
+		restaurantMemberRepository.On("CreateMember", ctx, mock.MatchedBy(func(member models.RestaurantMember) bool {
+			return member.RestaurantID == invitation.RestaurantID && member.Role == models.CashierMember
+		})).Return(nil)
+		cacheService.On("Delete", ctx, getMemberInvitationKey(code)).Return(nil)
+		sessionService.On("CreateSession", ctx, mock.Anything, &invitation.RestaurantID, models.Manager, mock.MatchedBy(func(role *models.MemberRole) bool {
+			return role != nil && *role == models.CashierMember
+		})).Return(&models.Session{Token: "token"}, nil)
+
+		token, err := service.AcceptInvitation(ctx, code)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "token", token.Token)
+		userRepository.AssertExpectations(t)
+		restaurantMemberRepository.AssertExpectations(t)
+		sessionService.AssertExpectations(t)
+	})
+}
+
+func TestRestaurantMemberService_RemoveMember(t *testing.T) {
+	restaurantID := uuid.New()
+	ctx := context.WithValue(context.Background(), internal.RestaurantIDKey, &restaurantID)
+	userID := uuid.New()
+
+	t.Run("should not remove the restaurant owner", func(t *testing.T) {
+		restaurantMemberRepository := &mocks.RestaurantMemberRepository{}
+
+		service := &restaurantMemberService{
+			restaurantMemberRepository: restaurantMemberRepository,
+		}
+
+		restaurantMemberRepository.On("GetMemberByUserID", ctx, userID).Return(&models.RestaurantMember{RestaurantID: restaurantID, UserID: userID, Role: models.OwnerMember}, nil)
+
+		err := service.RemoveMember(ctx, userID)
+
+		assert.ErrorIs(t, err, models.ErrCannotRemoveRestaurantOwner)
+		restaurantMemberRepository.AssertNotCalled(t, "DeleteMember", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return error when member belongs to another restaurant", func(t *testing.T) {
+		restaurantMemberRepository := &mocks.RestaurantMemberRepository{}
+
+		service := &restaurantMemberService{
+			restaurantMemberRepository: restaurantMemberRepository,
+		}
+
+		restaurantMemberRepository.On("GetMemberByUserID", ctx, userID).Return(&models.RestaurantMember{RestaurantID: uuid.New(), UserID: userID, Role: models.KitchenMember}, nil)
+
+		err := service.RemoveMember(ctx, userID)
+
+		assert.ErrorIs(t, err, models.ErrMemberNotFound)
+	})
+
+	t.Run("should remove member and revoke sessions", func(t *testing.T) {
+		restaurantMemberRepository := &mocks.RestaurantMemberRepository{}
+		sessionService := &mocks.SessionService{}
+		cacheService := &mocks.CacheService{}
+
+		service := &restaurantMemberService{
+			restaurantMemberRepository: restaurantMemberRepository,
+			sessionService:             sessionService,
+			cacheService:               cacheService,
+		}
+
+		member := &models.RestaurantMember{BaseModel: models.BaseModel{ID: uuid.New()}, RestaurantID: restaurantID, UserID: userID, Role: models.KitchenMember}
+
+		restaurantMemberRepository.On("GetMemberByUserID", ctx, userID).Return(member, nil)
+		restaurantMemberRepository.On("DeleteMember", ctx, member.ID).Return(nil)
+		sessionService.On("DeleteAllSessions", ctx, userID).Return(nil)
+		cacheService.On("Delete", ctx, getUserKey(userID)).Return(nil)
+
+		err := service.RemoveMember(ctx, userID)
+
+		assert.NoError(t, err)
+		restaurantMemberRepository.AssertExpectations(t)
+		sessionService.AssertExpectations(t)
+		cacheService.AssertExpectations(t)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/G-Villarinho/food-shop-api/internal"
 	"github.com/G-Villarinho/food-shop-api/mocks"
@@ -162,6 +163,30 @@ func TestRestaurantService_CreateOrder(t *testing.T) {
 		orderService.AssertNotCalled(t, "CreateOrder", invalidCtx, mock.Anything, mock.Anything, mock.Anything)
 	})
 
+	t.Run("should return error when restaurant is suspended", func(t *testing.T) {
+		orderService := &mocks.OrderService{}
+		restaurantRepository := &mocks.RestaurantRepository{}
+
+		restaurantService := &restaurantService{
+			orderService:         orderService,
+			restaurantRepository: restaurantRepository,
+		}
+
+		restaurantID := uuid.New()
+		payload := models.CreateOrderPayload{}
+		restaurant := &models.Restaurant{
+			BaseModel: models.BaseModel{ID: restaurantID},
+			Status:    models.SuspendedRestaurant,
+		}
+
+		restaurantRepository.On("GetRestaurantByID", ctx, restaurantID).Return(restaurant, nil)
+
+		err := restaurantService.CreateOrder(ctx, restaurantID, payload)
+
+		assert.ErrorIs(t, err, models.ErrRestaurantSuspended)
+		orderService.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
 	t.Run("should return error when restaurant is not found", func(t *testing.T) {
 		orderService := &mocks.OrderService{}
 		restaurantRepository := &mocks.RestaurantRepository{}
@@ -234,4 +259,83 @@ func TestRestaurantService_CreateOrder(t *testing.T) {
 		restaurantRepository.AssertCalled(t, "GetRestaurantByID", ctx, restaurantID)
 		orderService.AssertCalled(t, "CreateOrder", ctx, custommerID, restaurantID, payload)
 	})
+	t.Run("should return error when scheduled time does not respect lead time", func(t *testing.T) {
+		orderService := &mocks.OrderService{}
+		restaurantRepository := &mocks.RestaurantRepository{}
+
+		restaurantService := &restaurantService{
+			orderService:         orderService,
+			restaurantRepository: restaurantRepository,
+		}
+
+		restaurantID := uuid.New()
+		scheduledFor := time.Now().Add(-time.Minute)
+		payload := models.CreateOrderPayload{ScheduledFor: &scheduledFor}
+		restaurant := &models.Restaurant{
+			BaseModel: models.BaseModel{ID: restaurantID},
+		}
+
+		restaurantRepository.On("GetRestaurantByID", ctx, restaurantID).Return(restaurant, nil)
+
+		err := restaurantService.CreateOrder(ctx, restaurantID, payload)
+
+		assert.ErrorIs(t, err, models.ErrScheduledTimeTooSoon)
+		orderService.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return error when restaurant is closed at scheduled time", func(t *testing.T) {
+		orderService := &mocks.OrderService{}
+		restaurantRepository := &mocks.RestaurantRepository{}
+
+		restaurantService := &restaurantService{
+			orderService:         orderService,
+			restaurantRepository: restaurantRepository,
+		}
+
+		restaurantID := uuid.New()
+		tomorrow := time.Now().UTC().AddDate(0, 0, 1)
+		scheduledFor := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 3, 0, 0, 0, time.UTC)
+		payload := models.CreateOrderPayload{ScheduledFor: &scheduledFor}
+		restaurant := &models.Restaurant{
+			BaseModel: models.BaseModel{ID: restaurantID},
+			OpensAt:   "08:00",
+			ClosesAt:  "22:00",
+		}
+
+		restaurantRepository.On("GetRestaurantByID", ctx, restaurantID).Return(restaurant, nil)
+
+		err := restaurantService.CreateOrder(ctx, restaurantID, payload)
+
+		assert.ErrorIs(t, err, models.ErrRestaurantClosedAtScheduledTime)
+		orderService.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should create scheduled order when restaurant is open at scheduled time", func(t *testing.T) {
+		orderService := &mocks.OrderService{}
+		restaurantRepository := &mocks.RestaurantRepository{}
+
+		restaurantService := &restaurantService{
+			orderService:         orderService,
+			restaurantRepository: restaurantRepository,
+		}
+
+		custommerID := ctx.Value(internal.UserIDKey).(uuid.UUID)
+		restaurantID := uuid.New()
+		tomorrow := time.Now().UTC().AddDate(0, 0, 1)
+		scheduledFor := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 12, 0, 0, 0, time.UTC)
+		payload := models.CreateOrderPayload{ScheduledFor: &scheduledFor}
+		restaurant := &models.Restaurant{
+			BaseModel: models.BaseModel{ID: restaurantID},
+			OpensAt:   "08:00",
+			ClosesAt:  "22:00",
+		}
+
+		restaurantRepository.On("GetRestaurantByID", ctx, restaurantID).Return(restaurant, nil)
+		orderService.On("CreateOrder", ctx, custommerID, restaurantID, payload).Return(nil)
+
+		err := restaurantService.CreateOrder(ctx, restaurantID, payload)
+
+		assert.NoError(t, err)
+		orderService.AssertCalled(t, "CreateOrder", ctx, custommerID, restaurantID, payload)
+	})
 }
@@ -2,6 +2,10 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"time"
 
@@ -12,13 +16,19 @@ import (
 	"github.com/google/uuid"
 )
 
+const lastSeenInterval = time.Minute
+
 //go:generate mockery --name=SessionService --output=../mocks --outpkg=mocks
 type SessionService interface {
-	CreateSession(ctx context.Context, userID uuid.UUID, restaurantID *uuid.UUID, role models.Role) (*models.Session, error)
+	CreateSession(ctx context.Context, userID uuid.UUID, restaurantID *uuid.UUID, role models.Role, memberRole *models.MemberRole) (*models.Session, error)
+	CreateImpersonationSession(ctx context.Context, userID uuid.UUID, restaurantID *uuid.UUID, role models.Role, memberRole *models.MemberRole, impersonatedBy uuid.UUID) (*models.Session, error)
 	GetSessionByToken(ctx context.Context, token string) (*models.Session, error)
+	RefreshSession(ctx context.Context, refreshToken string) (*models.Session, error)
 	GetSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]*models.Session, error)
 	DeleteSession(ctx context.Context, sessionID uuid.UUID) error
 	DeleteAllSessions(ctx context.Context, userID uuid.UUID) error
+	DeleteOtherSessions(ctx context.Context, userID uuid.UUID, currentSessionID uuid.UUID) error
+	TouchSession(ctx context.Context, session *models.Session) error
 }
 
 type sessionService struct {
@@ -45,52 +55,125 @@ func NewSessionService(di *internal.Di) (SessionService, error) {
 	}, nil
 }
 
-func (s *sessionService) CreateSession(ctx context.Context, userID uuid.UUID, restaurantID *uuid.UUID, role models.Role) (*models.Session, error) {
-	sessionID, err := uuid.NewV7()
+func (s *sessionService) CreateSession(ctx context.Context, userID uuid.UUID, restaurantID *uuid.UUID, role models.Role, memberRole *models.MemberRole) (*models.Session, error) {
+	return s.createSession(ctx, &models.Session{
+		UserID:       userID,
+		RestaurantID: restaurantID,
+		Role:         role,
+		MemberRole:   memberRole,
+	})
+}
+
+func (s *sessionService) CreateImpersonationSession(ctx context.Context, userID uuid.UUID, restaurantID *uuid.UUID, role models.Role, memberRole *models.MemberRole, impersonatedBy uuid.UUID) (*models.Session, error) {
+	return s.createSession(ctx, &models.Session{
+		UserID:         userID,
+		RestaurantID:   restaurantID,
+		Role:           role,
+		MemberRole:     memberRole,
+		ImpersonatedBy: &impersonatedBy,
+	})
+}
+
+func (s *sessionService) GetSessionByToken(ctx context.Context, token string) (*models.Session, error) {
+	sessionID, err := s.tokenService.ExtractSessionID(token)
 	if err != nil {
-		return nil, err
+		return nil, models.ErrSessionNotFound
 	}
 
-	token, err := s.tokenService.CreateToken(userID, sessionID)
+	revoked, err := s.cacheService.Exists(ctx, getRevokedSessionKey(sessionID))
 	if err != nil {
 		return nil, err
 	}
 
-	session := &models.Session{
-		UserID:       userID,
-		SessionID:    sessionID,
-		RestaurantID: restaurantID,
-		Role:         role,
-		Token:        token,
-		CreatedAt:    time.Now().Unix(),
+	if revoked {
+		return nil, s.sessionNotFoundError(ctx, token)
 	}
 
-	ttl := time.Duration(config.Env.Cache.SessionExp) * time.Hour
-
-	if err := s.cacheService.Set(ctx, getSessionKey(sessionID), session, ttl); err != nil {
+	session, err := s.getSession(ctx, sessionID)
+	if err != nil {
 		return nil, err
 	}
 
-	if err := s.cacheService.AddToSet(ctx, getUserSessionsKey(userID), sessionID.String(), ttl); err != nil {
+	if session == nil {
+		return nil, s.sessionNotFoundError(ctx, token)
+	}
+
+	if session.Token != token {
+		return nil, models.ErrSessionNotFound
+	}
+
+	if err := s.loadLastSeenAt(ctx, session); err != nil {
 		return nil, err
 	}
 
 	return session, nil
 }
 
-func (s *sessionService) GetSessionByToken(ctx context.Context, token string) (*models.Session, error) {
-	sessionID, err := s.tokenService.ExtractSessionID(token)
+// RefreshSession rotates the access and refresh tokens of a session. A refresh
+// token can only be used once: presenting an already rotated one means it leaked,
+// so the whole session is revoked. The token is read and deleted in a single
+// command, so two concurrent refreshes can't both rotate it.
+func (s *sessionService) RefreshSession(ctx context.Context, refreshToken string) (*models.Session, error) {
+	hash := hashRefreshToken(refreshToken)
+
+	var entry models.RefreshTokenEntry
+	if err := s.cacheService.GetAndDelete(ctx, getRefreshTokenKey(hash), &entry); err != nil {
+		if !errors.Is(err, cache.ErrCacheMiss) {
+			return nil, err
+		}
+
+		return nil, s.refreshTokenNotFoundError(ctx, hash)
+	}
+
+	session, err := s.getSession(ctx, entry.SessionID)
 	if err != nil {
-		return nil, models.ErrSessionNotFound
+		return nil, err
 	}
 
-	session, err := s.getSession(ctx, sessionID)
+	if session == nil {
+		blocked, err := s.cacheService.Exists(ctx, getBlockedUserKey(entry.UserID))
+		if err != nil {
+			return nil, err
+		}
+
+		if blocked {
+			return nil, models.ErrUserBlocked
+		}
+
+		return nil, models.ErrInvalidRefreshToken
+	}
+
+	if session.RefreshTokenHash != hash {
+		if err := s.DeleteSession(ctx, session.SessionID); err != nil && !errors.Is(err, models.ErrSessionNotFound) {
+			return nil, err
+		}
+
+		return nil, models.ErrRefreshTokenReused
+	}
+
+	ttl := remainingSessionTTL(session)
+	if ttl <= 0 {
+		return nil, models.ErrInvalidRefreshToken
+	}
+
+	if err := s.cacheService.Set(ctx, getUsedRefreshTokenKey(hash), entry, ttl); err != nil {
+		return nil, err
+	}
+
+	token, err := s.tokenService.CreateToken(session.UserID, session.SessionID)
 	if err != nil {
 		return nil, err
 	}
 
-	if session.Token != token {
-		return nil, models.ErrSessionNotFound
+	session.Token = token
+	session.LastSeenAt = time.Now().Unix()
+
+	if err := s.issueRefreshToken(ctx, session, ttl); err != nil {
+		return nil, err
+	}
+
+	if err := s.cacheService.Set(ctx, getSessionKey(session.SessionID), session, ttl); err != nil {
+		return nil, err
 	}
 
 	return session, nil
@@ -118,11 +201,15 @@ func (s *sessionService) GetSessionsByUserID(ctx context.Context, userID uuid.UU
 			continue
 		}
 
-		if session != nil {
+		if session == nil {
 			_ = s.cacheService.RemoveFromSet(ctx, getUserSessionsKey(userID), sessionIDStr)
 			continue
 		}
 
+		if err := s.loadLastSeenAt(ctx, session); err != nil {
+			continue
+		}
+
 		activeSessions = append(activeSessions, session)
 	}
 
@@ -139,10 +226,18 @@ func (s *sessionService) DeleteSession(ctx context.Context, sessionID uuid.UUID)
 		return models.ErrSessionNotFound
 	}
 
+	if err := s.revokeSession(ctx, session); err != nil {
+		return err
+	}
+
 	if err := s.cacheService.Delete(ctx, getSessionKey(sessionID)); err != nil {
 		return err
 	}
 
+	if err := s.cacheService.Delete(ctx, getSessionLastSeenKey(sessionID)); err != nil {
+		return err
+	}
+
 	return s.cacheService.RemoveFromSet(ctx, getUserSessionsKey(session.UserID), sessionID.String())
 }
 
@@ -167,6 +262,156 @@ func (s *sessionService) DeleteAllSessions(ctx context.Context, userID uuid.UUID
 	return s.cacheService.Delete(ctx, getUserSessionsKey(userID))
 }
 
+func (s *sessionService) DeleteOtherSessions(ctx context.Context, userID uuid.UUID, currentSessionID uuid.UUID) error {
+	var sessionIDs []string
+	if err := s.cacheService.GetSetMembers(ctx, getUserSessionsKey(userID), &sessionIDs); err != nil {
+		return err
+	}
+
+	for _, sessionIDStr := range sessionIDs {
+		sessionID, err := uuid.Parse(sessionIDStr)
+		if err != nil || sessionID == currentSessionID {
+			continue
+		}
+		_ = s.DeleteSession(ctx, sessionID)
+	}
+
+	return nil
+}
+
+// TouchSession refreshes LastSeenAt at most once per lastSeenInterval to avoid a Redis write on every request.
+// It is kept under its own key, so writing it never brings back tokens that a
+// concurrent refresh has just rotated.
+func (s *sessionService) TouchSession(ctx context.Context, session *models.Session) error {
+	now := time.Now()
+	if now.Sub(time.Unix(session.LastSeenAt, 0)) < lastSeenInterval {
+		return nil
+	}
+
+	ttl := remainingSessionTTL(session)
+	if ttl <= 0 {
+		return nil
+	}
+
+	session.LastSeenAt = now.Unix()
+	return s.cacheService.Set(ctx, getSessionLastSeenKey(session.SessionID), session.LastSeenAt, ttl)
+}
+
+// revokeSession keeps the session ID in a revocation list until its token expires,
+// so a copied JWT is refused without depending on the session lookup.
+func (s *sessionService) revokeSession(ctx context.Context, session *models.Session) error {
+	ttl := remainingSessionTTL(session)
+	if ttl <= 0 {
+		return nil
+	}
+
+	return s.cacheService.Set(ctx, getRevokedSessionKey(session.SessionID), true, ttl)
+}
+
+func (s *sessionService) createSession(ctx context.Context, session *models.Session) (*models.Session, error) {
+	sessionID, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.tokenService.CreateToken(session.UserID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	session.SessionID = sessionID
+	session.Token = token
+	session.CreatedAt = time.Now().Unix()
+	session.LastSeenAt = session.CreatedAt
+
+	if userAgent, ok := ctx.Value(internal.UserAgentKey).(string); ok {
+		session.UserAgent = userAgent
+	}
+
+	if clientIP, ok := ctx.Value(internal.ClientIPKey).(string); ok {
+		session.IPAddress = clientIP
+	}
+
+	ttl := time.Duration(config.Env.Cache.SessionExp) * time.Hour
+
+	// Impersonation sessions end with their access token.
+	if session.ImpersonatedBy == nil {
+		if err := s.issueRefreshToken(ctx, session, ttl); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.cacheService.Set(ctx, getSessionKey(sessionID), session, ttl); err != nil {
+		return nil, err
+	}
+
+	if err := s.cacheService.AddToSet(ctx, getUserSessionsKey(session.UserID), sessionID.String(), ttl); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (s *sessionService) issueRefreshToken(ctx context.Context, session *models.Session, ttl time.Duration) error {
+	buffer := make([]byte, 32)
+	if _, err := rand.Read(buffer); err != nil {
+		return err
+	}
+
+	refreshToken := base64.RawURLEncoding.EncodeToString(buffer)
+	hash := hashRefreshToken(refreshToken)
+
+	entry := models.RefreshTokenEntry{
+		SessionID: session.SessionID,
+		UserID:    session.UserID,
+	}
+
+	if err := s.cacheService.Set(ctx, getRefreshTokenKey(hash), entry, ttl); err != nil {
+		return err
+	}
+
+	session.RefreshToken = refreshToken
+	session.RefreshTokenHash = hash
+	return nil
+}
+
+// refreshTokenNotFoundError revokes the session when the token was already rotated.
+func (s *sessionService) refreshTokenNotFoundError(ctx context.Context, hash string) error {
+	var entry models.RefreshTokenEntry
+	if err := s.cacheService.Get(ctx, getUsedRefreshTokenKey(hash), &entry); err != nil {
+		if errors.Is(err, cache.ErrCacheMiss) {
+			return models.ErrInvalidRefreshToken
+		}
+		return err
+	}
+
+	if err := s.DeleteSession(ctx, entry.SessionID); err != nil && !errors.Is(err, models.ErrSessionNotFound) {
+		return err
+	}
+
+	return models.ErrRefreshTokenReused
+}
+
+// sessionNotFoundError tells a session revoked by a block apart from an expired one,
+// so clients can show the account-suspended screen.
+func (s *sessionService) sessionNotFoundError(ctx context.Context, token string) error {
+	userID, err := s.tokenService.ExtractUserID(token)
+	if err != nil {
+		return models.ErrSessionNotFound
+	}
+
+	blocked, err := s.cacheService.Exists(ctx, getBlockedUserKey(userID))
+	if err != nil {
+		return err
+	}
+
+	if blocked {
+		return models.ErrUserBlocked
+	}
+
+	return models.ErrSessionNotFound
+}
+
 func (s *sessionService) getSession(ctx context.Context, sessionID uuid.UUID) (*models.Session, error) {
 	var session *models.Session
 	if err := s.cacheService.Get(ctx, getSessionKey(sessionID), &session); err != nil {
@@ -180,10 +425,55 @@ func (s *sessionService) getSession(ctx context.Context, sessionID uuid.UUID) (*
 	return session, nil
 }
 
+// loadLastSeenAt applies the last time TouchSession saw the session, when it
+// is later than the one stored with the session.
+func (s *sessionService) loadLastSeenAt(ctx context.Context, session *models.Session) error {
+	var lastSeenAt int64
+	if err := s.cacheService.Get(ctx, getSessionLastSeenKey(session.SessionID), &lastSeenAt); err != nil {
+		if errors.Is(err, cache.ErrCacheMiss) {
+			return nil
+		}
+
+		return err
+	}
+
+	session.LastSeenAt = max(session.LastSeenAt, lastSeenAt)
+	return nil
+}
+
+func remainingSessionTTL(session *models.Session) time.Duration {
+	return time.Until(time.Unix(session.CreatedAt, 0).Add(time.Duration(config.Env.Cache.SessionExp) * time.Hour))
+}
+
 func getSessionKey(sessionID uuid.UUID) string {
 	return "session:" + sessionID.String()
 }
 
+func getSessionLastSeenKey(sessionID uuid.UUID) string {
+	return "session_last_seen:" + sessionID.String()
+}
+
 func getUserSessionsKey(userID uuid.UUID) string {
 	return "user_sessions:" + userID.String()
 }
+
+func getBlockedUserKey(userID uuid.UUID) string {
+	return "blocked_user:" + userID.String()
+}
+
+func hashRefreshToken(refreshToken string) string {
+	hash := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(hash[:])
+}
+
+func getRefreshTokenKey(hash string) string {
+	return "refresh_token:" + hash
+}
+
+func getUsedRefreshTokenKey(hash string) string {
+	return "used_refresh_token:" + hash
+}
+
+func getRevokedSessionKey(sessionID uuid.UUID) string {
+	return "revoked_session:" + sessionID.String()
+}
@@ -4,7 +4,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/G-Villarinho/food-shop-api/cache"
+	"github.com/G-Villarinho/food-shop-api/config"
+	"github.com/G-Villarinho/food-shop-api/internal"
 	"github.com/G-Villarinho/food-shop-api/mocks"
 	"github.com/G-Villarinho/food-shop-api/models"
 	"github.com/google/uuid"
@@ -30,10 +34,16 @@ func TestSessionService_CreateSession(t *testing.T) {
 		cacheService.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 		cacheService.On("AddToSet", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-		session, err := sessionService.CreateSession(context.Background(), userID, &restaurantID, role)
+		ctx := context.WithValue(context.Background(), internal.UserAgentKey, "Mozilla/5.0")
+		ctx = context.WithValue(ctx, internal.ClientIPKey, "127.0.0.1")
+
+		session, err := sessionService.CreateSession(ctx, userID, &restaurantID, role, nil)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, session)
+		assert.Equal(t, "Mozilla/5.0", session.UserAgent)
+		assert.Equal(t, "127.0.0.1", session.IPAddress)
+		assert.Equal(t, session.CreatedAt, session.LastSeenAt)
 		assert.Equal(t, userID, session.UserID)
 		assert.Equal(t, restaurantID, *session.RestaurantID)
 		assert.Equal(t, role, session.Role)
@@ -59,7 +69,7 @@ func TestSessionService_CreateSession(t *testing.T) {
 
 		tokenService.On("CreateToken", userID, mock.AnythingOfType("uuid.UUID")).Return("", errors.New("token creation failed"))
 
-		session, err := sessionService.CreateSession(context.Background(), userID, &restaurantID, role)
+		session, err := sessionService.CreateSession(context.Background(), userID, &restaurantID, role, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, session)
@@ -84,6 +94,7 @@ func TestSessionService_CreateSession(t *testing.T) {
 		token := "mock-token"
 
 		tokenService.On("CreateToken", userID, mock.AnythingOfType("uuid.UUID")).Return(token, nil)
+		cacheService.On("Set", mock.Anything, mock.Anything, mock.AnythingOfType("models.RefreshTokenEntry"), mock.Anything).Return(nil)
 		cacheService.On(
 			"Set",
 			mock.Anything,
@@ -92,7 +103,7 @@ func TestSessionService_CreateSession(t *testing.T) {
 			mock.Anything,
 		).Return(errors.New("cache set failed"))
 
-		session, err := sessionService.CreateSession(context.Background(), userID, &restaurantID, role)
+		session, err := sessionService.CreateSession(context.Background(), userID, &restaurantID, role, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, session)
@@ -124,10 +135,11 @@ func TestSessionService_CreateSession(t *testing.T) {
 		token := "mock-token"
 
 		tokenService.On("CreateToken", userID, mock.AnythingOfType("uuid.UUID")).Return(token, nil)
+		cacheService.On("Set", mock.Anything, mock.Anything, mock.AnythingOfType("models.RefreshTokenEntry"), mock.Anything).Return(nil)
 		cacheService.On("Set", mock.Anything, mock.Anything, mock.AnythingOfType("*models.Session"), mock.Anything).Return(nil)
 		cacheService.On("AddToSet", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("add to set failed"))
 
-		session, err := sessionService.CreateSession(context.Background(), userID, &restaurantID, role)
+		session, err := sessionService.CreateSession(context.Background(), userID, &restaurantID, role, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, session)
@@ -138,3 +150,358 @@ func TestSessionService_CreateSession(t *testing.T) {
 		cacheService.AssertCalled(t, "AddToSet", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 	})
 }
+
+func TestSessionService_GetSessionByToken(t *testing.T) {
+	ctx := context.Background()
+	token := "mock-token"
+
+	t.Run("should return session when token matches", func(t *testing.T) {
+		tokenService := &mocks.TokenService{}
+		cacheService := &mocks.CacheService{}
+		sessionService := &sessionService{
+			tokenService: tokenService,
+			cacheService: cacheService,
+		}
+
+		sessionID := uuid.New()
+
+		tokenService.On("ExtractSessionID", token).Return(sessionID, nil)
+		cacheService.On("Exists", ctx, getRevokedSessionKey(sessionID)).Return(false, nil)
+		cacheService.On("Get", ctx, getSessionKey(sessionID), mock.Anything).Run(func(args mock.Arguments) {
+			*(args.Get(2).(**models.Session)) = &models.Session{SessionID: sessionID, Token: token, LastSeenAt: 100}
+		}).Return(nil)
+		cacheService.On("Get", ctx, getSessionLastSeenKey(sessionID), mock.AnythingOfType("*int64")).Run(func(args mock.Arguments) {
+			*(args.Get(2).(*int64)) = 200
+		}).Return(nil)
+
+		session, err := sessionService.GetSessionByToken(ctx, token)
+
+		assert.NoError(t, err)
+		assert.Equal(t, sessionID, session.SessionID)
+		assert.Equal(t, int64(200), session.LastSeenAt)
+	})
+
+	t.Run("should return blocked error when session was revoked by a block", func(t *testing.T) {
+		tokenService := &mocks.TokenService{}
+		cacheService := &mocks.CacheService{}
+		sessionService := &sessionService{
+			tokenService: tokenService,
+			cacheService: cacheService,
+		}
+
+		sessionID := uuid.New()
+		userID := uuid.New()
+
+		tokenService.On("ExtractSessionID", token).Return(sessionID, nil)
+		tokenService.On("ExtractUserID", token).Return(userID, nil)
+		cacheService.On("Exists", ctx, getRevokedSessionKey(sessionID)).Return(false, nil)
+		cacheService.On("Get", ctx, getSessionKey(sessionID), mock.Anything).Return(cache.ErrCacheMiss)
+		cacheService.On("Exists", ctx, getBlockedUserKey(userID)).Return(true, nil)
+
+		session, err := sessionService.GetSessionByToken(ctx, token)
+
+		assert.ErrorIs(t, err, models.ErrUserBlocked)
+		assert.Nil(t, session)
+	})
+
+	t.Run("should return not found when session expired", func(t *testing.T) {
+		tokenService := &mocks.TokenService{}
+		cacheService := &mocks.CacheService{}
+		sessionService := &sessionService{
+			tokenService: tokenService,
+			cacheService: cacheService,
+		}
+
+		sessionID := uuid.New()
+		userID := uuid.New()
+
+		tokenService.On("ExtractSessionID", token).Return(sessionID, nil)
+		tokenService.On("ExtractUserID", token).Return(userID, nil)
+		cacheService.On("Exists", ctx, getRevokedSessionKey(sessionID)).Return(false, nil)
+		cacheService.On("Get", ctx, getSessionKey(sessionID), mock.Anything).Return(cache.ErrCacheMiss)
+		cacheService.On("Exists", ctx, getBlockedUserKey(userID)).Return(false, nil)
+
+		session, err := sessionService.GetSessionByToken(ctx, token)
+
+		assert.ErrorIs(t, err, models.ErrSessionNotFound)
+		assert.Nil(t, session)
+	})
+
+	t.Run("should reject a revoked token before looking up the session", func(t *testing.T) {
+		tokenService := &mocks.TokenService{}
+		cacheService := &mocks.CacheService{}
+		sessionService := &sessionService{
+			tokenService: tokenService,
+			cacheService: cacheService,
+		}
+
+		sessionID := uuid.New()
+		userID := uuid.New()
+
+		tokenService.On("ExtractSessionID", token).Return(sessionID, nil)
+		tokenService.On("ExtractUserID", token).Return(userID, nil)
+		cacheService.On("Exists", ctx, getRevokedSessionKey(sessionID)).Return(true, nil)
+		cacheService.On("Exists", ctx, getBlockedUserKey(userID)).Return(false, nil)
+
+		session, err := sessionService.GetSessionByToken(ctx, token)
+
+		assert.ErrorIs(t, err, models.ErrSessionNotFound)
+		assert.Nil(t, session)
+		cacheService.AssertNotCalled(t, "Get", ctx, getSessionKey(sessionID), mock.Anything)
+	})
+}
+
+func TestSessionService_GetSessionsByUserID(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return live sessions and prune expired ones", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		sessionService := &sessionService{
+			cacheService: cacheService,
+		}
+
+		userID := uuid.New()
+		liveSessionID := uuid.New()
+		expiredSessionID := uuid.New()
+
+		cacheService.On("GetSetMembers", ctx, getUserSessionsKey(userID), mock.Anything).Run(func(args mock.Arguments) {
+			*(args.Get(2).(*[]string)) = []string{liveSessionID.String(), expiredSessionID.String()}
+		}).Return(nil)
+		cacheService.On("Get", ctx, getSessionKey(liveSessionID), mock.Anything).Run(func(args mock.Arguments) {
+			*(args.Get(2).(**models.Session)) = &models.Session{SessionID: liveSessionID, UserID: userID}
+		}).Return(nil)
+		cacheService.On("Get", ctx, getSessionLastSeenKey(liveSessionID), mock.AnythingOfType("*int64")).Return(cache.ErrCacheMiss)
+		cacheService.On("Get", ctx, getSessionKey(expiredSessionID), mock.Anything).Return(cache.ErrCacheMiss)
+		cacheService.On("RemoveFromSet", ctx, getUserSessionsKey(userID), expiredSessionID.String()).Return(nil)
+
+		sessions, err := sessionService.GetSessionsByUserID(ctx, userID)
+
+		assert.NoError(t, err)
+		assert.Len(t, sessions, 1)
+		assert.Equal(t, liveSessionID, sessions[0].SessionID)
+		cacheService.AssertCalled(t, "RemoveFromSet", ctx, getUserSessionsKey(userID), expiredSessionID.String())
+	})
+}
+
+func TestSessionService_DeleteOtherSessions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should keep the current session", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		sessionService := &sessionService{
+			cacheService: cacheService,
+		}
+
+		userID := uuid.New()
+		currentSessionID := uuid.New()
+		otherSessionID := uuid.New()
+
+		cacheService.On("GetSetMembers", ctx, getUserSessionsKey(userID), mock.Anything).Run(func(args mock.Arguments) {
+			*(args.Get(2).(*[]string)) = []string{currentSessionID.String(), otherSessionID.String()}
+		}).Return(nil)
+		cacheService.On("Get", ctx, getSessionKey(otherSessionID), mock.Anything).Run(func(args mock.Arguments) {
+			*(args.Get(2).(**models.Session)) = &models.Session{SessionID: otherSessionID, UserID: userID}
+		}).Return(nil)
+		cacheService.On("Delete", ctx, getSessionKey(otherSessionID)).Return(nil)
+		cacheService.On("Delete", ctx, getSessionLastSeenKey(otherSessionID)).Return(nil)
+		cacheService.On("RemoveFromSet", ctx, getUserSessionsKey(userID), otherSessionID.String()).Return(nil)
+
+		err := sessionService.DeleteOtherSessions(ctx, userID, currentSessionID)
+
+		assert.NoError(t, err)
+		cacheService.AssertCalled(t, "Delete", ctx, getSessionKey(otherSessionID))
+		cacheService.AssertNotCalled(t, "Delete", ctx, getSessionKey(currentSessionID))
+	})
+}
+
+func TestSessionService_DeleteSession(t *testing.T) {
+	ctx := context.Background()
+	config.Env.Cache.SessionExp = 6
+
+	t.Run("should add session to revocation list", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		sessionService := &sessionService{
+			cacheService: cacheService,
+		}
+
+		userID := uuid.New()
+		sessionID := uuid.New()
+
+		cacheService.On("Get", ctx, getSessionKey(sessionID), mock.Anything).Run(func(args mock.Arguments) {
+			*(args.Get(2).(**models.Session)) = &models.Session{SessionID: sessionID, UserID: userID, CreatedAt: time.Now().Unix()}
+		}).Return(nil)
+		cacheService.On("Set", ctx, getRevokedSessionKey(sessionID), true, mock.AnythingOfType("time.Duration")).Return(nil)
+		cacheService.On("Delete", ctx, getSessionKey(sessionID)).Return(nil)
+		cacheService.On("Delete", ctx, getSessionLastSeenKey(sessionID)).Return(nil)
+		cacheService.On("RemoveFromSet", ctx, getUserSessionsKey(userID), sessionID.String()).Return(nil)
+
+		err := sessionService.DeleteSession(ctx, sessionID)
+
+		assert.NoError(t, err)
+		cacheService.AssertExpectations(t)
+	})
+}
+
+func TestSessionService_RefreshSession(t *testing.T) {
+	ctx := context.Background()
+	config.Env.Cache.SessionExp = 6
+
+	t.Run("should rotate access and refresh tokens", func(t *testing.T) {
+		tokenService := &mocks.TokenService{}
+		cacheService := &mocks.CacheService{}
+		sessionService := &sessionService{
+			tokenService: tokenService,
+			cacheService: cacheService,
+		}
+
+		userID := uuid.New()
+		sessionID := uuid.New()
+		refreshToken := "refresh-token"
+		hash := hashRefreshToken(refreshToken)
+		entry := models.RefreshTokenEntry{SessionID: sessionID, UserID: userID}
+
+		cacheService.On("GetAndDelete", ctx, getRefreshTokenKey(hash), mock.AnythingOfType("*models.RefreshTokenEntry")).Run(func(args mock.Arguments) {
+			*(args.Get(2).(*models.RefreshTokenEntry)) = entry
+		}).Return(nil)
+		cacheService.On("Get", ctx, getSessionKey(sessionID), mock.Anything).Run(func(args mock.Arguments) {
+			*(args.Get(2).(**models.Session)) = &models.Session{
+				SessionID:        sessionID,
+				UserID:           userID,
+				Token:            "old-token",
+				RefreshTokenHash: hash,
+				CreatedAt:        time.Now().Unix(),
+			}
+		}).Return(nil)
+		cacheService.On("Set", ctx, getUsedRefreshTokenKey(hash), entry, mock.AnythingOfType("time.Duration")).Return(nil)
+		cacheService.On("Set", ctx, mock.MatchedBy(func(key string) bool { return key != getRefreshTokenKey(hash) }), entry, mock.AnythingOfType("time.Duration")).Return(nil)
+		cacheService.On("Set", ctx, getSessionKey(sessionID), mock.AnythingOfType("*models.Session"), mock.AnythingOfType("time.Duration")).Return(nil)
+		tokenService.On("CreateToken", userID, sessionID).Return("new-token", nil)
+
+		session, err := sessionService.RefreshSession(ctx, refreshToken)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "new-token", session.Token)
+		assert.NotEmpty(t, session.RefreshToken)
+		assert.NotEqual(t, refreshToken, session.RefreshToken)
+		assert.Equal(t, hashRefreshToken(session.RefreshToken), session.RefreshTokenHash)
+		cacheService.AssertCalled(t, "Set", ctx, getUsedRefreshTokenKey(hash), entry, mock.AnythingOfType("time.Duration"))
+		cacheService.AssertCalled(t, "Set", ctx, getRefreshTokenKey(session.RefreshTokenHash), entry, mock.AnythingOfType("time.Duration"))
+	})
+
+	t.Run("should revoke the session when a rotated refresh token is reused", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		sessionService := &sessionService{
+			cacheService: cacheService,
+		}
+
+		userID := uuid.New()
+		sessionID := uuid.New()
+		refreshToken := "rotated-refresh-token"
+		hash := hashRefreshToken(refreshToken)
+
+		cacheService.On("GetAndDelete", ctx, getRefreshTokenKey(hash), mock.AnythingOfType("*models.RefreshTokenEntry")).Return(cache.ErrCacheMiss)
+		cacheService.On("Get", ctx, getUsedRefreshTokenKey(hash), mock.AnythingOfType("*models.RefreshTokenEntry")).Run(func(args mock.Arguments) {
+			*(args.Get(2).(*models.RefreshTokenEntry)) = models.RefreshTokenEntry{SessionID: sessionID, UserID: userID}
+		}).Return(nil)
+		cacheService.On("Get", ctx, getSessionKey(sessionID), mock.Anything).Run(func(args mock.Arguments) {
+			*(args.Get(2).(**models.Session)) = &models.Session{SessionID: sessionID, UserID: userID, CreatedAt: time.Now().Unix()}
+		}).Return(nil)
+		cacheService.On("Set", ctx, getRevokedSessionKey(sessionID), true, mock.AnythingOfType("time.Duration")).Return(nil)
+		cacheService.On("Delete", ctx, getSessionKey(sessionID)).Return(nil)
+		cacheService.On("Delete", ctx, getSessionLastSeenKey(sessionID)).Return(nil)
+		cacheService.On("RemoveFromSet", ctx, getUserSessionsKey(userID), sessionID.String()).Return(nil)
+
+		session, err := sessionService.RefreshSession(ctx, refreshToken)
+
+		assert.ErrorIs(t, err, models.ErrRefreshTokenReused)
+		assert.Nil(t, session)
+		cacheService.AssertExpectations(t)
+	})
+
+	t.Run("should return error when refresh token is unknown", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		sessionService := &sessionService{
+			cacheService: cacheService,
+		}
+
+		hash := hashRefreshToken("unknown")
+
+		cacheService.On("GetAndDelete", ctx, getRefreshTokenKey(hash), mock.AnythingOfType("*models.RefreshTokenEntry")).Return(cache.ErrCacheMiss)
+		cacheService.On("Get", ctx, getUsedRefreshTokenKey(hash), mock.AnythingOfType("*models.RefreshTokenEntry")).Return(cache.ErrCacheMiss)
+
+		session, err := sessionService.RefreshSession(ctx, "unknown")
+
+		assert.ErrorIs(t, err, models.ErrInvalidRefreshToken)
+		assert.Nil(t, session)
+		cacheService.AssertNotCalled(t, "Delete", ctx, mock.Anything)
+	})
+
+	t.Run("should return blocked error when the session was revoked by a block", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		sessionService := &sessionService{
+			cacheService: cacheService,
+		}
+
+		userID := uuid.New()
+		sessionID := uuid.New()
+		hash := hashRefreshToken("refresh-token")
+
+		cacheService.On("GetAndDelete", ctx, getRefreshTokenKey(hash), mock.AnythingOfType("*models.RefreshTokenEntry")).Run(func(args mock.Arguments) {
+			*(args.Get(2).(*models.RefreshTokenEntry)) = models.RefreshTokenEntry{SessionID: sessionID, UserID: userID}
+		}).Return(nil)
+		cacheService.On("Get", ctx, getSessionKey(sessionID), mock.Anything).Return(cache.ErrCacheMiss)
+		cacheService.On("Exists", ctx, getBlockedUserKey(userID)).Return(true, nil)
+
+		session, err := sessionService.RefreshSession(ctx, "refresh-token")
+
+		assert.ErrorIs(t, err, models.ErrUserBlocked)
+		assert.Nil(t, session)
+	})
+}
+
+func TestSessionService_TouchSession(t *testing.T) {
+	ctx := context.Background()
+	config.Env.Cache.SessionExp = 6
+
+	t.Run("should only write the last seen time", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		sessionService := &sessionService{
+			cacheService: cacheService,
+		}
+
+		sessionID := uuid.New()
+		session := &models.Session{
+			SessionID:  sessionID,
+			Token:      "stale-token",
+			CreatedAt:  time.Now().Add(-time.Hour).Unix(),
+			LastSeenAt: time.Now().Add(-time.Hour).Unix(),
+		}
+
+		cacheService.On("Set", ctx, getSessionLastSeenKey(sessionID), mock.AnythingOfType("int64"), mock.AnythingOfType("time.Duration")).Return(nil)
+
+		err := sessionService.TouchSession(ctx, session)
+
+		assert.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), time.Unix(session.LastSeenAt, 0), time.Second)
+		cacheService.AssertNotCalled(t, "Set", ctx, getSessionKey(sessionID), mock.Anything, mock.Anything)
+		cacheService.AssertExpectations(t)
+	})
+
+	t.Run("should not write when the session was seen recently", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		sessionService := &sessionService{
+			cacheService: cacheService,
+		}
+
+		session := &models.Session{
+			SessionID:  uuid.New(),
+			CreatedAt:  time.Now().Unix(),
+			LastSeenAt: time.Now().Unix(),
+		}
+
+		err := sessionService.TouchSession(ctx, session)
+
+		assert.NoError(t, err)
+		cacheService.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -1,9 +1,6 @@
 package services
 
 import (
-	"crypto/ecdsa"
-	"crypto/x509"
-	"encoding/pem"
 	"errors"
 	"time"
 
@@ -17,31 +14,39 @@ import (
 type TokenService interface {
 	CreateToken(userID uuid.UUID, sessionID uuid.UUID) (string, error)
 	ExtractSessionID(token string) (uuid.UUID, error)
+	ExtractUserID(token string) (uuid.UUID, error)
 }
 
 type tokenService struct {
-	di *internal.Di
+	di      *internal.Di
+	keyRing KeyRing
 }
 
 func NewTokenService(di *internal.Di) (TokenService, error) {
-	return &tokenService{di: di}, nil
+	keyRing, err := internal.Invoke[KeyRing](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tokenService{
+		di:      di,
+		keyRing: keyRing,
+	}, nil
 }
 
 func (t *tokenService) CreateToken(userID uuid.UUID, sessionID uuid.UUID) (string, error) {
-	privateKey, err := parseECPrivateKey(config.Env.PrivateKey)
-	if err != nil {
-		return "", err
-	}
+	keyID, privateKey := t.keyRing.SigningKey()
 
 	claims := jwt.MapClaims{
 		"userId": userID.String(),
-		"exp":    time.Now().Add(time.Duration(config.Env.Cache.SessionExp) * time.Hour).Unix(),
+		"exp":    time.Now().Add(config.AccessTokenExp()).Unix(),
 		"sid":    sessionID.String(),
 		"iat":    time.Now().Unix(),
 		"iss":    "level-up.com",
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["kid"] = keyID
 
 	signedToken, err := token.SignedString(privateKey)
 	if err != nil {
@@ -52,17 +57,7 @@ func (t *tokenService) CreateToken(userID uuid.UUID, sessionID uuid.UUID) (strin
 }
 
 func (t *tokenService) ExtractSessionID(token string) (uuid.UUID, error) {
-	publicKey, err := parseECPublicKey(config.Env.PublicKey)
-	if err != nil {
-		return uuid.Nil, err
-	}
-
-	claims := jwt.MapClaims{}
-
-	_, err = jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (any, error) {
-		return publicKey, nil
-	})
-
+	claims, err := t.parseClaims(token)
 	if err != nil {
 		return uuid.Nil, err
 	}
@@ -79,35 +74,45 @@ func (t *tokenService) ExtractSessionID(token string) (uuid.UUID, error) {
 	return sessionID, nil
 }
 
-func parseECPrivateKey(pemKey string) (*ecdsa.PrivateKey, error) {
-	block, _ := pem.Decode([]byte(pemKey))
-	if block == nil || block.Type != "EC PRIVATE KEY" {
-		return nil, errors.New("failed to parse EC private key")
+func (t *tokenService) ExtractUserID(token string) (uuid.UUID, error) {
+	claims, err := t.parseClaims(token)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	userIDClaim, ok := claims["userId"].(string)
+	if !ok {
+		return uuid.Nil, errors.New("user ID (userId) not found or invalid in token claims")
 	}
 
-	privateKey, err := x509.ParseECPrivateKey(block.Bytes)
+	userID, err := uuid.Parse(userIDClaim)
 	if err != nil {
-		return nil, err
+		return uuid.Nil, err
 	}
-
-	return privateKey, nil
+	return userID, nil
 }
 
-func parseECPublicKey(pemKey string) (*ecdsa.PublicKey, error) {
-	block, _ := pem.Decode([]byte(pemKey))
-	if block == nil || block.Type != "PUBLIC KEY" {
-		return nil, errors.New("failed to parse EC public key")
-	}
+func (t *tokenService) parseClaims(token string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+
+	_, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (any, error) {
+		keyID, _ := token.Header["kid"].(string)
+		publicKeys, err := t.keyRing.VerificationKeys(keyID)
+		if err != nil {
+			return nil, err
+		}
+
+		keySet := jwt.VerificationKeySet{Keys: make([]jwt.VerificationKey, 0, len(publicKeys))}
+		for _, publicKey := range publicKeys {
+			keySet.Keys = append(keySet.Keys, publicKey)
+		}
+
+		return keySet, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodES256.Alg()}))
 
-	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
 	if err != nil {
 		return nil, err
 	}
 
-	ecdsaPubKey, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		return nil, errors.New("key is not a valid ECDSA public key")
-	}
-
-	return ecdsaPubKey, nil
+	return claims, nil
 }
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/G-Villarinho/food-shop-api/cache"
+	"github.com/G-Villarinho/food-shop-api/config"
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/G-Villarinho/food-shop-api/repositories"
+	"github.com/google/uuid"
+)
+
+//go:generate mockery --name=TrackingService --output=../mocks --outpkg=mocks
+type TrackingService interface {
+	UpdateCourierLocation(ctx context.Context, orderID uuid.UUID, payload models.UpdateCourierLocationPayload) error
+	GetOrderTracking(ctx context.Context, orderID uuid.UUID) (*models.OrderTrackingResponse, error)
+}
+
+type trackingService struct {
+	di              *internal.Di
+	cacheService    cache.CacheService
+	orderRepository repositories.OrderRepository
+}
+
+func NewTrackingService(di *internal.Di) (TrackingService, error) {
+	cacheService, err := internal.Invoke[cache.CacheService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	orderRepository, err := internal.Invoke[repositories.OrderRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &trackingService{
+		di:              di,
+		cacheService:    cacheService,
+		orderRepository: orderRepository,
+	}, nil
+}
+
+func (t *trackingService) UpdateCourierLocation(ctx context.Context, orderID uuid.UUID, payload models.UpdateCourierLocationPayload) error {
+	courierID, ok := ctx.Value(internal.UserIDKey).(uuid.UUID)
+	if !ok {
+		return models.ErrUserNotFoundInContext
+	}
+
+	order, err := t.orderRepository.GetOrderByID(ctx, orderID, false)
+	if err != nil {
+		return fmt.Errorf("get order by ID: %w", err)
+	}
+
+	if order == nil {
+		return models.ErrorOrderNotFound
+	}
+
+	if !order.CourierID.Valid || order.CourierID.UUID != courierID {
+		return models.ErrOrderNotAssignedToCourier
+	}
+
+	if order.Status != models.Delivering {
+		return models.ErrOrderIsNotBeingDelivered
+	}
+
+	if err := t.cacheService.Set(ctx, getCourierLocationKey(orderID), payload.ToCourierLocation(), config.CourierLocationExp()); err != nil {
+		return fmt.Errorf("set courier location: %w", err)
+	}
+
+	return nil
+}
+
+func (t *trackingService) GetOrderTracking(ctx context.Context, orderID uuid.UUID) (*models.OrderTrackingResponse, error) {
+	custommerID, ok := ctx.Value(internal.UserIDKey).(uuid.UUID)
+	if !ok {
+		return nil, models.ErrUserNotFoundInContext
+	}
+
+	order, err := t.orderRepository.GetOrderByID(ctx, orderID, false)
+	if err != nil {
+		return nil, fmt.Errorf("get order by ID: %w", err)
+	}
+
+	if order == nil {
+		return nil, models.ErrorOrderNotFound
+	}
+
+	if order.CustommerID != custommerID {
+		return nil, models.ErrOrderDoesNotBelongToCustomer
+	}
+
+	if order.Status != models.Delivering {
+		return nil, models.ErrOrderIsNotBeingDelivered
+	}
+
+	var location models.CourierLocation
+	if err := t.cacheService.Get(ctx, getCourierLocationKey(orderID), &location); err != nil {
+		if errors.Is(err, cache.ErrCacheMiss) {
+			return nil, models.ErrCourierLocationNotFound
+		}
+		return nil, fmt.Errorf("get courier location: %w", err)
+	}
+
+	return location.ToOrderTrackingResponse(order, float64(config.Env.Tracking.AverageSpeed)), nil
+}
+
+func getCourierLocationKey(orderID uuid.UUID) string {
+	return fmt.Sprintf("courier-location:%s", orderID.String())
+}
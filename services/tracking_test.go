@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/cache"
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/mocks"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTrackingService_UpdateCourierLocation(t *testing.T) {
+	latitude := -23.55052
+	longitude := -46.633308
+	payload := models.UpdateCourierLocationPayload{
+		LocationPayload: models.LocationPayload{Latitude: &latitude, Longitude: &longitude},
+	}
+
+	t.Run("should store courier location with the default expiration", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		orderRepository := &mocks.OrderRepository{}
+
+		trackingService := &trackingService{
+			cacheService:    cacheService,
+			orderRepository: orderRepository,
+		}
+
+		courierID := uuid.New()
+		ctx := context.WithValue(context.Background(), internal.UserIDKey, courierID)
+
+		orderID := uuid.New()
+		order := &models.Order{
+			BaseModel: models.BaseModel{ID: orderID},
+			Status:    models.Delivering,
+			CourierID: uuid.NullUUID{UUID: courierID, Valid: true},
+		}
+
+		orderRepository.On("GetOrderByID", ctx, orderID, false).Return(order, nil)
+		cacheService.On("Set", ctx, getCourierLocationKey(orderID), mock.MatchedBy(func(location *models.CourierLocation) bool {
+			return location.Latitude == latitude && location.Longitude == longitude
+		}), 5*time.Minute).Return(nil)
+
+		err := trackingService.UpdateCourierLocation(ctx, orderID, payload)
+
+		assert.NoError(t, err)
+		cacheService.AssertExpectations(t)
+	})
+
+	t.Run("should return error when order is assigned to another courier", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		orderRepository := &mocks.OrderRepository{}
+
+		trackingService := &trackingService{
+			cacheService:    cacheService,
+			orderRepository: orderRepository,
+		}
+
+		ctx := context.WithValue(context.Background(), internal.UserIDKey, uuid.New())
+
+		orderID := uuid.New()
+		order := &models.Order{
+			BaseModel: models.BaseModel{ID: orderID},
+			Status:    models.Delivering,
+			CourierID: uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		}
+
+		orderRepository.On("GetOrderByID", ctx, orderID, false).Return(order, nil)
+
+		err := trackingService.UpdateCourierLocation(ctx, orderID, payload)
+
+		assert.ErrorIs(t, err, models.ErrOrderNotAssignedToCourier)
+		cacheService.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return error when order is not being delivered", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		orderRepository := &mocks.OrderRepository{}
+
+		trackingService := &trackingService{
+			cacheService:    cacheService,
+			orderRepository: orderRepository,
+		}
+
+		courierID := uuid.New()
+		ctx := context.WithValue(context.Background(), internal.UserIDKey, courierID)
+
+		orderID := uuid.New()
+		order := &models.Order{
+			BaseModel: models.BaseModel{ID: orderID},
+			Status:    models.Delivered,
+			CourierID: uuid.NullUUID{UUID: courierID, Valid: true},
+		}
+
+		orderRepository.On("GetOrderByID", ctx, orderID, false).Return(order, nil)
+
+		err := trackingService.UpdateCourierLocation(ctx, orderID, payload)
+
+		assert.ErrorIs(t, err, models.ErrOrderIsNotBeingDelivered)
+		cacheService.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestTrackingService_GetOrderTracking(t *testing.T) {
+	t.Run("should return courier location with ETA successfully", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		orderRepository := &mocks.OrderRepository{}
+
+		trackingService := &trackingService{
+			cacheService:    cacheService,
+			orderRepository: orderRepository,
+		}
+
+		custommerID := uuid.New()
+		ctx := context.WithValue(context.Background(), internal.UserIDKey, custommerID)
+
+		orderID := uuid.New()
+		order := &models.Order{
+			BaseModel:         models.BaseModel{ID: orderID},
+			CustommerID:       custommerID,
+			Status:            models.Delivering,
+			DeliveryLatitude:  sql.NullFloat64{Float64: -23.56, Valid: true},
+			DeliveryLongitude: sql.NullFloat64{Float64: -46.64, Valid: true},
+		}
+
+		speed := 30.0
+		orderRepository.On("GetOrderByID", ctx, orderID, false).Return(order, nil)
+		cacheService.On("Get", ctx, getCourierLocationKey(orderID), mock.Anything).Run(func(args mock.Arguments) {
+			location := args.Get(2).(*models.CourierLocation)
+			location.Latitude = -23.55
+			location.Longitude = -46.63
+			location.SpeedInKmh = &speed
+		}).Return(nil)
+
+		response, err := trackingService.GetOrderTracking(ctx, orderID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, -23.55, response.Latitude)
+		assert.NotNil(t, response.DistanceInMeters)
+		assert.NotNil(t, response.ETAInMinutes)
+		assert.Greater(t, *response.ETAInMinutes, 0)
+	})
+
+	t.Run("should return error when order does not belong to customer", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		orderRepository := &mocks.OrderRepository{}
+
+		trackingService := &trackingService{
+			cacheService:    cacheService,
+			orderRepository: orderRepository,
+		}
+
+		ctx := context.WithValue(context.Background(), internal.UserIDKey, uuid.New())
+
+		orderID := uuid.New()
+		order := &models.Order{
+			BaseModel:   models.BaseModel{ID: orderID},
+			CustommerID: uuid.New(),
+			Status:      models.Delivering,
+		}
+
+		orderRepository.On("GetOrderByID", ctx, orderID, false).Return(order, nil)
+
+		response, err := trackingService.GetOrderTracking(ctx, orderID)
+
+		assert.ErrorIs(t, err, models.ErrOrderDoesNotBelongToCustomer)
+		assert.Nil(t, response)
+		cacheService.AssertNotCalled(t, "Get", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return error when courier location is not available", func(t *testing.T) {
+		cacheService := &mocks.CacheService{}
+		orderRepository := &mocks.OrderRepository{}
+
+		trackingService := &trackingService{
+			cacheService:    cacheService,
+			orderRepository: orderRepository,
+		}
+
+		custommerID := uuid.New()
+		ctx := context.WithValue(context.Background(), internal.UserIDKey, custommerID)
+
+		orderID := uuid.New()
+		order := &models.Order{
+			BaseModel:   models.BaseModel{ID: orderID},
+			CustommerID: custommerID,
+			Status:      models.Delivering,
+		}
+
+		orderRepository.On("GetOrderByID", ctx, orderID, false).Return(order, nil)
+		cacheService.On("Get", ctx, getCourierLocationKey(orderID), mock.Anything).Return(cache.ErrCacheMiss)
+
+		response, err := trackingService.GetOrderTracking(ctx, orderID)
+
+		assert.ErrorIs(t, err, models.ErrCourierLocationNotFound)
+		assert.Nil(t, response)
+	})
+}
@@ -2,7 +2,9 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/G-Villarinho/food-shop-api/cache"
@@ -17,12 +19,16 @@ import (
 type UserService interface {
 	CreateUser(ctx context.Context, payload models.CreateUserPayload, role models.Role) (uuid.UUID, error)
 	GetUser(ctx context.Context) (*models.UserResponse, error)
+	GetSessions(ctx context.Context) ([]*models.SessionResponse, error)
+	RevokeSession(ctx context.Context, sessionID uuid.UUID) error
+	RevokeOtherSessions(ctx context.Context) error
 }
 
 type userService struct {
 	di                   *internal.Di
 	authService          AuthService
 	cacheService         cache.CacheService
+	sessionService       SessionService
 	restaurantRepository repositories.RestaurantRepository
 	userRepository       repositories.UserRepository
 }
@@ -38,6 +44,11 @@ func NewUserService(di *internal.Di) (UserService, error) {
 		return nil, err
 	}
 
+	sessionService, err := internal.Invoke[SessionService](di)
+	if err != nil {
+		return nil, err
+	}
+
 	restaurantRepository, err := internal.Invoke[repositories.RestaurantRepository](di)
 	if err != nil {
 		return nil, err
@@ -52,6 +63,7 @@ func NewUserService(di *internal.Di) (UserService, error) {
 		di:                   di,
 		authService:          authService,
 		cacheService:         cacheService,
+		sessionService:       sessionService,
 		restaurantRepository: restaurantRepository,
 		userRepository:       userRepository,
 	}, nil
@@ -125,6 +137,70 @@ func (u *userService) GetUser(ctx context.Context) (*models.UserResponse, error)
 	return &userResponse, nil
 }
 
+func (u *userService) GetSessions(ctx context.Context) ([]*models.SessionResponse, error) {
+	userID, ok := ctx.Value(internal.UserIDKey).(uuid.UUID)
+	if !ok {
+		return nil, models.ErrUserNotFoundInContext
+	}
+
+	currentSessionID, _ := ctx.Value(internal.SessionIDKey).(uuid.UUID)
+
+	sessions, err := u.sessionService.GetSessionsByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, models.ErrSessionNotFound) {
+		return nil, fmt.Errorf("get sessions by user id: %w", err)
+	}
+
+	response := make([]*models.SessionResponse, len(sessions))
+	for i, session := range sessions {
+		response[i] = session.ToSessionResponse(currentSessionID)
+	}
+
+	return response, nil
+}
+
+func (u *userService) RevokeSession(ctx context.Context, sessionID uuid.UUID) error {
+	userID, ok := ctx.Value(internal.UserIDKey).(uuid.UUID)
+	if !ok {
+		return models.ErrUserNotFoundInContext
+	}
+
+	sessions, err := u.sessionService.GetSessionsByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, models.ErrSessionNotFound) {
+			return models.ErrSessionNotFound
+		}
+		return fmt.Errorf("get sessions by user id: %w", err)
+	}
+
+	if !slices.ContainsFunc(sessions, func(session *models.Session) bool { return session.SessionID == sessionID }) {
+		return models.ErrSessionNotFound
+	}
+
+	if err := u.sessionService.DeleteSession(ctx, sessionID); err != nil {
+		return fmt.Errorf("delete session: %w", err)
+	}
+
+	return nil
+}
+
+func (u *userService) RevokeOtherSessions(ctx context.Context) error {
+	userID, ok := ctx.Value(internal.UserIDKey).(uuid.UUID)
+	if !ok {
+		return models.ErrUserNotFoundInContext
+	}
+
+	sessionID, ok := ctx.Value(internal.SessionIDKey).(uuid.UUID)
+	if !ok {
+		return models.ErrSessionNotFound
+	}
+
+	if err := u.sessionService.DeleteOtherSessions(ctx, userID, sessionID); err != nil {
+		return fmt.Errorf("delete other sessions: %w", err)
+	}
+
+	return nil
+}
+
 func getUserKey(userID uuid.UUID) string {
 	return fmt.Sprintf("user:%s", userID.String())
 }
@@ -313,3 +313,73 @@ func TestUserService_GetUser(t *testing.T) {
 		cacheService.AssertCalled(t, "Set", ctx, getUserKey(userID), mock.Anything, mock.Anything)
 	})
 }
+
+func TestUserService_RevokeSession(t *testing.T) {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), internal.UserIDKey, userID)
+
+	setup := func() (*userService, *mocks.SessionService) {
+		sessionService := &mocks.SessionService{}
+
+		userService := &userService{
+			sessionService: sessionService,
+		}
+
+		return userService, sessionService
+	}
+
+	t.Run("should revoke a session that belongs to the user", func(t *testing.T) {
+		userService, sessionService := setup()
+
+		sessionID := uuid.New()
+
+		sessionService.On("GetSessionsByUserID", ctx, userID).Return([]*models.Session{{SessionID: sessionID, UserID: userID}}, nil)
+		sessionService.On("DeleteSession", ctx, sessionID).Return(nil)
+
+		err := userService.RevokeSession(ctx, sessionID)
+
+		assert.NoError(t, err)
+		sessionService.AssertCalled(t, "DeleteSession", ctx, sessionID)
+	})
+
+	t.Run("should not revoke a session from another user", func(t *testing.T) {
+		userService, sessionService := setup()
+
+		sessionService.On("GetSessionsByUserID", ctx, userID).Return([]*models.Session{{SessionID: uuid.New(), UserID: userID}}, nil)
+
+		err := userService.RevokeSession(ctx, uuid.New())
+
+		assert.ErrorIs(t, err, models.ErrSessionNotFound)
+		sessionService.AssertNotCalled(t, "DeleteSession", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserService_GetSessions(t *testing.T) {
+	userID := uuid.New()
+	currentSessionID := uuid.New()
+	ctx := context.WithValue(context.Background(), internal.UserIDKey, userID)
+	ctx = context.WithValue(ctx, internal.SessionIDKey, currentSessionID)
+
+	t.Run("should flag the current session", func(t *testing.T) {
+		sessionService := &mocks.SessionService{}
+
+		userService := &userService{
+			sessionService: sessionService,
+		}
+
+		sessions := []*models.Session{
+			{SessionID: currentSessionID, UserID: userID, UserAgent: "Firefox", IPAddress: "10.0.0.1"},
+			{SessionID: uuid.New(), UserID: userID, UserAgent: "Safari", IPAddress: "10.0.0.2"},
+		}
+
+		sessionService.On("GetSessionsByUserID", ctx, userID).Return(sessions, nil)
+
+		response, err := userService.GetSessions(ctx)
+
+		assert.NoError(t, err)
+		assert.Len(t, response, 2)
+		assert.True(t, response[0].Current)
+		assert.Equal(t, "Firefox", response[0].UserAgent)
+		assert.False(t, response[1].Current)
+	})
+}
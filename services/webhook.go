@@ -0,0 +1,485 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/G-Villarinho/food-shop-api/repositories"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+)
+
+const (
+	maxWebhookDeliveryAttempts = 6
+	webhookRetryBaseDelay      = 30 * time.Second
+	webhookRetryMaxDelay       = time.Hour
+	webhookDeliveryTimeout     = 10 * time.Second
+	webhookRetryBatchSize      = 100
+	webhookSecretPrefix        = "whsec_"
+)
+
+var errWebhookAddressNotAllowed = errors.New("webhook address is not public")
+
+//go:generate mockery --name=WebhookService --output=../mocks --outpkg=mocks
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, payload models.CreateWebhookPayload) (*models.CreateWebhookResponse, error)
+	GetWebhooks(ctx context.Context) ([]*models.WebhookResponse, error)
+	DeleteWebhook(ctx context.Context, webhookID uuid.UUID) error
+	GetPaginatedDeliveries(ctx context.Context, webhookID uuid.UUID, pagination *models.Pagination) (*models.PaginatedResponse[*models.WebhookDeliveryResponse], error)
+	PingWebhook(ctx context.Context, webhookID uuid.UUID) (*models.WebhookDeliveryResponse, error)
+	Dispatch(ctx context.Context, task models.WebhookEventTask) error
+	DeliverWebhook(ctx context.Context, deliveryID uuid.UUID) error
+	RetryDueDeliveries(ctx context.Context) (int, error)
+}
+
+type webhookService struct {
+	di                *internal.Di
+	httpClient        *http.Client
+	queueService      QueueService
+	webhookRepository repositories.WebhookRepository
+}
+
+func NewWebhookService(di *internal.Di) (WebhookService, error) {
+	queueService, err := internal.Invoke[QueueService](di)
+	if err != nil {
+		return nil, err
+	}
+
+	webhookRepository, err := internal.Invoke[repositories.WebhookRepository](di)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhookService{
+		di:                di,
+		httpClient:        newWebhookHTTPClient(),
+		queueService:      queueService,
+		webhookRepository: webhookRepository,
+	}, nil
+}
+
+func (w *webhookService) CreateWebhook(ctx context.Context, payload models.CreateWebhookPayload) (*models.CreateWebhookResponse, error) {
+	restaurantID, ok := ctx.Value(internal.RestaurantIDKey).(*uuid.UUID)
+	if !ok || restaurantID == nil {
+		return nil, models.ErrRestaurantNotFound
+	}
+
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, fmt.Errorf("generate webhook secret: %w", err)
+	}
+
+	webhook := payload.ToWebhook(*restaurantID, secret)
+	if err := w.webhookRepository.CreateWebhook(ctx, *webhook); err != nil {
+		return nil, fmt.Errorf("create webhook: %w", err)
+	}
+
+	return &models.CreateWebhookResponse{
+		WebhookResponse: *webhook.ToWebhookResponse(),
+		Secret:          secret,
+	}, nil
+}
+
+func (w *webhookService) GetWebhooks(ctx context.Context) ([]*models.WebhookResponse, error) {
+	restaurantID, ok := ctx.Value(internal.RestaurantIDKey).(*uuid.UUID)
+	if !ok || restaurantID == nil {
+		return nil, models.ErrRestaurantNotFound
+	}
+
+	webhooks, err := w.webhookRepository.GetWebhooksByRestaurantID(ctx, *restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("get webhooks by restaurant id: %w", err)
+	}
+
+	response := make([]*models.WebhookResponse, len(webhooks))
+	for i, webhook := range webhooks {
+		response[i] = webhook.ToWebhookResponse()
+	}
+
+	return response, nil
+}
+
+func (w *webhookService) DeleteWebhook(ctx context.Context, webhookID uuid.UUID) error {
+	restaurantID, ok := ctx.Value(internal.RestaurantIDKey).(*uuid.UUID)
+	if !ok || restaurantID == nil {
+		return models.ErrRestaurantNotFound
+	}
+
+	deleted, err := w.webhookRepository.DeleteWebhook(ctx, webhookID, *restaurantID)
+	if err != nil {
+		return fmt.Errorf("delete webhook: %w", err)
+	}
+
+	if !deleted {
+		return models.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (w *webhookService) GetPaginatedDeliveries(ctx context.Context, webhookID uuid.UUID, pagination *models.Pagination) (*models.PaginatedResponse[*models.WebhookDeliveryResponse], error) {
+	webhook, err := w.getRestaurantWebhook(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	paginatedDeliveries, err := w.webhookRepository.GetPaginatedDeliveriesByWebhookID(ctx, webhook.ID, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("get paginated deliveries by webhook id: %w", err)
+	}
+
+	paginatedDeliveriesResponse := models.MapPaginatedResult(paginatedDeliveries, func(delivery models.WebhookDelivery) *models.WebhookDeliveryResponse {
+		return delivery.ToWebhookDeliveryResponse()
+	})
+
+	return paginatedDeliveriesResponse, nil
+}
+
+// PingWebhook delivers a ping event right away so the manager can check the
+// endpoint and the signature. Pings are never retried.
+func (w *webhookService) PingWebhook(ctx context.Context, webhookID uuid.UUID) (*models.WebhookDeliveryResponse, error) {
+	webhook, err := w.getRestaurantWebhook(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	eventID, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("generate event id: %w", err)
+	}
+
+	delivery, err := newWebhookDelivery(webhook.ID, eventID, models.PingEvent, map[string]uuid.UUID{"webhookId": webhook.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := w.webhookRepository.CreateDeliveries(ctx, []models.WebhookDelivery{*delivery}); err != nil {
+		return nil, fmt.Errorf("create deliveries: %w", err)
+	}
+
+	if err := w.attemptDelivery(ctx, webhook, delivery); err != nil {
+		return nil, err
+	}
+
+	return delivery.ToWebhookDeliveryResponse(), nil
+}
+
+// Dispatch records a delivery for every webhook of the restaurant subscribed
+// to the event and hands them to the delivery worker. A failed publish is not
+// an error: the delivery stays pending and is picked up by RetryDueDeliveries.
+// Events relayed more than once by the outbox don't create new deliveries.
+func (w *webhookService) Dispatch(ctx context.Context, task models.WebhookEventTask) error {
+	webhooks, err := w.webhookRepository.GetWebhooksByRestaurantID(ctx, task.RestaurantID)
+	if err != nil {
+		return fmt.Errorf("get webhooks by restaurant id: %w", err)
+	}
+
+	var deliveries []models.WebhookDelivery
+	for _, webhook := range webhooks {
+		if !slices.Contains(webhook.EventList(), task.Event) {
+			continue
+		}
+
+		delivery, err := newWebhookDelivery(webhook.ID, task.ID, task.Event, task.Data)
+		if err != nil {
+			return err
+		}
+
+		delivery.NextAttemptAt = sql.NullTime{Time: time.Now().UTC().Add(webhookRetryBaseDelay), Valid: true}
+		deliveries = append(deliveries, *delivery)
+	}
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	if err := w.webhookRepository.CreateDeliveries(ctx, deliveries); err != nil {
+		return fmt.Errorf("create deliveries: %w", err)
+	}
+
+	for _, delivery := range deliveries {
+		_ = w.publishDelivery(delivery.ID)
+	}
+
+	return nil
+}
+
+func (w *webhookService) DeliverWebhook(ctx context.Context, deliveryID uuid.UUID) error {
+	delivery, err := w.webhookRepository.GetDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		return fmt.Errorf("get delivery by id: %w", err)
+	}
+
+	if delivery == nil {
+		return models.ErrWebhookDeliveryNotFound
+	}
+
+	// The same delivery can be queued twice when a retry races the worker.
+	if delivery.Status != models.PendingDelivery {
+		return nil
+	}
+
+	// Webhooks are soft deleted, so the preload leaves an empty webhook behind.
+	if delivery.Webhook.ID == uuid.Nil {
+		delivery.Status = models.FailedDelivery
+		delivery.NextAttemptAt = sql.NullTime{}
+		delivery.Error = sql.NullString{String: models.ErrWebhookNotFound.Error(), Valid: true}
+
+		if err := w.webhookRepository.UpdateDelivery(ctx, *delivery); err != nil {
+			return fmt.Errorf("update delivery: %w", err)
+		}
+
+		return nil
+	}
+
+	return w.attemptDelivery(ctx, &delivery.Webhook, delivery)
+}
+
+// RetryDueDeliveries publishes again every pending delivery whose next attempt
+// is due, pushing the attempt forward so it isn't queued twice by the next run.
+func (w *webhookService) RetryDueDeliveries(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+
+	deliveries, err := w.webhookRepository.GetDueDeliveries(ctx, now, webhookRetryBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("get due deliveries: %w", err)
+	}
+
+	for _, delivery := range deliveries {
+		delivery.NextAttemptAt = sql.NullTime{Time: now.Add(webhookRetryBaseDelay), Valid: true}
+		if err := w.webhookRepository.UpdateDelivery(ctx, delivery); err != nil {
+			return 0, fmt.Errorf("update delivery: %w", err)
+		}
+
+		if err := w.publishDelivery(delivery.ID); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+func (w *webhookService) getRestaurantWebhook(ctx context.Context, webhookID uuid.UUID) (*models.Webhook, error) {
+	restaurantID, ok := ctx.Value(internal.RestaurantIDKey).(*uuid.UUID)
+	if !ok || restaurantID == nil {
+		return nil, models.ErrRestaurantNotFound
+	}
+
+	webhook, err := w.webhookRepository.GetWebhookByID(ctx, webhookID, *restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook by id: %w", err)
+	}
+
+	if webhook == nil {
+		return nil, models.ErrWebhookNotFound
+	}
+
+	return webhook, nil
+}
+
+func (w *webhookService) publishDelivery(deliveryID uuid.UUID) error {
+	message, err := jsoniter.Marshal(models.WebhookDeliveryTask{DeliveryID: deliveryID})
+	if err != nil {
+		return fmt.Errorf("marshal webhook delivery task: %w", err)
+	}
+
+	if err := w.queueService.Publish(QueueWebhookDelivery, message); err != nil {
+		return fmt.Errorf("publish webhook delivery task: %w", err)
+	}
+
+	return nil
+}
+
+// attemptDelivery sends the delivery once and records the outcome. A failed
+// request is not an error, it is scheduled again with exponential backoff
+// until maxWebhookDeliveryAttempts is reached.
+func (w *webhookService) attemptDelivery(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery) error {
+	now := time.Now().UTC()
+	statusCode, err := w.send(ctx, webhook, delivery, now)
+
+	delivery.Attempts++
+	delivery.ResponseStatus = sql.NullInt32{Int32: int32(statusCode), Valid: statusCode != 0}
+	delivery.NextAttemptAt = sql.NullTime{}
+
+	switch {
+	case err == nil:
+		delivery.Status = models.SucceededDelivery
+		delivery.Error = sql.NullString{}
+		delivery.DeliveredAt = sql.NullTime{Time: now, Valid: true}
+	case delivery.Event == models.PingEvent || delivery.Attempts >= maxWebhookDeliveryAttempts:
+		delivery.Status = models.FailedDelivery
+		delivery.Error = sql.NullString{String: truncate(err.Error(), 1000), Valid: true}
+	default:
+		delivery.Error = sql.NullString{String: truncate(err.Error(), 1000), Valid: true}
+		delivery.NextAttemptAt = sql.NullTime{Time: now.Add(webhookRetryDelay(delivery.Attempts)), Valid: true}
+	}
+
+	if err := w.webhookRepository.UpdateDelivery(ctx, *delivery); err != nil {
+		return fmt.Errorf("update delivery: %w", err)
+	}
+
+	return nil
+}
+
+func (w *webhookService) send(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery, now time.Time) (int, error) {
+	body := []byte(delivery.Payload)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("create request: %w", err)
+	}
+
+	// Webhooks registered before the URL was validated may still use http.
+	if request.URL.Scheme != "https" {
+		return 0, models.ErrWebhookURLNotAllowed
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "food-shop-webhooks/1.0")
+	request.Header.Set("X-Webhook-Id", delivery.ID.String())
+	request.Header.Set("X-Webhook-Event", string(delivery.Event))
+	request.Header.Set("X-Webhook-Timestamp", timestamp)
+	request.Header.Set("X-Webhook-Signature", "sha256="+signWebhookPayload(webhook.Secret, timestamp, body))
+
+	response, err := w.httpClient.Do(request)
+	if err != nil {
+		return 0, fmt.Errorf("send request: %w", err)
+	}
+	defer response.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response.StatusCode, fmt.Errorf("unexpected status code %d", response.StatusCode)
+	}
+
+	return response.StatusCode, nil
+}
+
+// newWebhookHTTPClient refuses to connect to private, loopback and link-local
+// addresses. The check runs on the address being dialed, after DNS resolution,
+// so a public host name resolving to an internal address is refused as well.
+// Redirects are not followed, since they could point anywhere.
+func newWebhookHTTPClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: webhookDeliveryTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			if ip := net.ParseIP(host); ip == nil || !models.IsPublicIP(ip) {
+				return errWebhookAddressNotAllowed
+			}
+
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   webhookDeliveryTimeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// newWebhookEventOutbox builds the outbox message that makes the webhook
+// worker dispatch the event once the surrounding transaction commits.
+func newWebhookEventOutbox(restaurantID uuid.UUID, event models.WebhookEvent, data any) ([]models.OutboxMessage, error) {
+	task, err := models.NewWebhookEventTask(restaurantID, event, data)
+	if err != nil {
+		return nil, fmt.Errorf("marshal webhook event data: %w", err)
+	}
+
+	message, err := models.NewOutboxMessage(QueueWebhookEvent, task)
+	if err != nil {
+		return nil, fmt.Errorf("create webhook event message: %w", err)
+	}
+
+	return []models.OutboxMessage{*message}, nil
+}
+
+// newWebhookDelivery uses the event ID as the envelope ID, so receivers can
+// deduplicate the event across webhooks and retries.
+func newWebhookDelivery(webhookID, eventID uuid.UUID, event models.WebhookEvent, data any) (*models.WebhookDelivery, error) {
+	delivery := models.NewWebhookDelivery(webhookID, eventID, event, "")
+
+	payload, err := jsoniter.Marshal(models.WebhookEventEnvelope{
+		ID:        eventID,
+		Event:     event,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		Data:      data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal webhook payload: %w", err)
+	}
+
+	delivery.Payload = string(payload)
+	return delivery, nil
+}
+
+// signWebhookPayload signs "<timestamp>.<body>" so receivers can reject
+// replayed requests by checking the timestamp header.
+func signWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookRetryDelay doubles the wait after every failed attempt: 30s, 1m, 2m...
+func webhookRetryDelay(attempts int) time.Duration {
+	delay := webhookRetryBaseDelay << (attempts - 1)
+	if delay <= 0 || delay > webhookRetryMaxDelay {
+		return webhookRetryMaxDelay
+	}
+
+	return delay
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return webhookSecretPrefix + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+func truncate(value string, size int) string {
+	if len(value) <= size {
+		return value
+	}
+
+	return value[:size]
+}
@@ -0,0 +1,399 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/mocks"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWebhookService_CreateWebhook(t *testing.T) {
+	restaurantID := uuid.New()
+	ctx := context.WithValue(context.Background(), internal.RestaurantIDKey, &restaurantID)
+
+	t.Run("should create webhook and return the secret once", func(t *testing.T) {
+		webhookRepository := &mocks.WebhookRepository{}
+
+		service := &webhookService{
+			webhookRepository: webhookRepository,
+		}
+
+		payload := models.CreateWebhookPayload{
+			URL:    "https://example.com/hooks",
+			Events: []models.WebhookEvent{models.OrderCreatedEvent, models.OrderCreatedEvent, models.MenuUpdatedEvent},
+		}
+
+		webhookRepository.On("CreateWebhook", ctx, mock.MatchedBy(func(webhook models.Webhook) bool {
+			return webhook.RestaurantID == restaurantID && webhook.Events == "order.created,menu.updated"
+		})).Return(nil)
+
+		response, err := service.CreateWebhook(ctx, payload)
+
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(response.Secret, webhookSecretPrefix))
+		assert.Equal(t, []models.WebhookEvent{models.OrderCreatedEvent, models.MenuUpdatedEvent}, response.Events)
+		webhookRepository.AssertExpectations(t)
+	})
+
+	t.Run("should return error when restaurant is not in context", func(t *testing.T) {
+		service := &webhookService{}
+
+		response, err := service.CreateWebhook(context.Background(), models.CreateWebhookPayload{})
+
+		assert.ErrorIs(t, err, models.ErrRestaurantNotFound)
+		assert.Nil(t, response)
+	})
+
+	t.Run("should reject URLs that are not https or not public", func(t *testing.T) {
+		webhookRepository := &mocks.WebhookRepository{}
+
+		service := &webhookService{
+			webhookRepository: webhookRepository,
+		}
+
+		for _, url := range []string{
+			"http://example.com/hooks",
+			"https://localhost/hooks",
+			"https://127.0.0.1/hooks",
+			"https://10.0.0.5/hooks",
+			"https://169.254.169.254/latest/meta-data",
+			"https://[::1]/hooks",
+		} {
+			response, err := service.CreateWebhook(ctx, models.CreateWebhookPayload{URL: url, Events: []models.WebhookEvent{models.OrderCreatedEvent}})
+
+			assert.ErrorIs(t, err, models.ErrWebhookURLNotAllowed, url)
+			assert.Nil(t, response)
+		}
+
+		webhookRepository.AssertNotCalled(t, "CreateWebhook", mock.Anything, mock.Anything)
+	})
+}
+
+func TestNewWebhookHTTPClient(t *testing.T) {
+	t.Run("should refuse to connect to a loopback address", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client := newWebhookHTTPClient()
+		client.Transport.(*http.Transport).TLSClientConfig = server.Client().Transport.(*http.Transport).TLSClientConfig
+
+		_, err := client.Get(server.URL)
+
+		assert.ErrorIs(t, err, errWebhookAddressNotAllowed)
+	})
+
+	t.Run("should not follow redirects", func(t *testing.T) {
+		client := newWebhookHTTPClient()
+
+		assert.ErrorIs(t, client.CheckRedirect(nil, nil), http.ErrUseLastResponse)
+	})
+}
+
+func TestWebhookService_Dispatch(t *testing.T) {
+	ctx := context.Background()
+	restaurantID := uuid.New()
+
+	t.Run("should record and queue a delivery only for subscribed webhooks", func(t *testing.T) {
+		webhookRepository := &mocks.WebhookRepository{}
+		queueService := &mocks.QueueService{}
+
+		service := &webhookService{
+			queueService:      queueService,
+			webhookRepository: webhookRepository,
+		}
+
+		subscribed := models.Webhook{BaseModel: models.BaseModel{ID: uuid.New()}, Events: "order.created,order.status_changed"}
+		other := models.Webhook{BaseModel: models.BaseModel{ID: uuid.New()}, Events: "menu.updated"}
+
+		task, _ := models.NewWebhookEventTask(restaurantID, models.OrderStatusChangedEvent, models.OrderStatusChangedEventData{OrderID: uuid.New(), Status: models.Processing})
+
+		webhookRepository.On("GetWebhooksByRestaurantID", ctx, restaurantID).Return([]models.Webhook{subscribed, other}, nil)
+		webhookRepository.On("CreateDeliveries", ctx, mock.MatchedBy(func(deliveries []models.WebhookDelivery) bool {
+			return len(deliveries) == 1 &&
+				deliveries[0].WebhookID == subscribed.ID &&
+				deliveries[0].EventID == task.ID &&
+				strings.Contains(deliveries[0].Payload, `"id":"`+task.ID.String()+`"`) &&
+				deliveries[0].Status == models.PendingDelivery &&
+				deliveries[0].NextAttemptAt.Valid &&
+				strings.Contains(deliveries[0].Payload, `"event":"order.status_changed"`)
+		})).Return(nil)
+		queueService.On("Publish", QueueWebhookDelivery, mock.Anything).Return(nil)
+
+		err := service.Dispatch(ctx, *task)
+
+		assert.NoError(t, err)
+		webhookRepository.AssertExpectations(t)
+		queueService.AssertNumberOfCalls(t, "Publish", 1)
+	})
+
+	t.Run("should not fail when the delivery can't be queued", func(t *testing.T) {
+		webhookRepository := &mocks.WebhookRepository{}
+		queueService := &mocks.QueueService{}
+
+		service := &webhookService{
+			queueService:      queueService,
+			webhookRepository: webhookRepository,
+		}
+
+		webhook := models.Webhook{BaseModel: models.BaseModel{ID: uuid.New()}, Events: "menu.updated"}
+
+		webhookRepository.On("GetWebhooksByRestaurantID", ctx, restaurantID).Return([]models.Webhook{webhook}, nil)
+		webhookRepository.On("CreateDeliveries", ctx, mock.Anything).Return(nil)
+		queueService.On("Publish", QueueWebhookDelivery, mock.Anything).Return(errors.New("broker down"))
+
+		task, _ := models.NewWebhookEventTask(restaurantID, models.MenuUpdatedEvent, models.MenuUpdatedEventData{})
+
+		err := service.Dispatch(ctx, *task)
+
+		assert.NoError(t, err)
+		webhookRepository.AssertExpectations(t)
+	})
+
+	t.Run("should do nothing when no webhook is subscribed", func(t *testing.T) {
+		webhookRepository := &mocks.WebhookRepository{}
+
+		service := &webhookService{
+			webhookRepository: webhookRepository,
+		}
+
+		webhookRepository.On("GetWebhooksByRestaurantID", ctx, restaurantID).Return([]models.Webhook{}, nil)
+
+		task, _ := models.NewWebhookEventTask(restaurantID, models.EvaluationCreatedEvent, nil)
+
+		err := service.Dispatch(ctx, *task)
+
+		assert.NoError(t, err)
+		webhookRepository.AssertNotCalled(t, "CreateDeliveries", mock.Anything, mock.Anything)
+	})
+}
+
+func TestWebhookService_DeliverWebhook(t *testing.T) {
+	ctx := context.Background()
+
+	newDelivery := func(url string, attempts int) *models.WebhookDelivery {
+		delivery := models.NewWebhookDelivery(uuid.New(), uuid.New(), models.OrderCreatedEvent, `{"event":"order.created"}`)
+		delivery.Attempts = attempts
+		delivery.Webhook = models.Webhook{BaseModel: models.BaseModel{ID: delivery.WebhookID}, URL: url, Secret: "whsec_test"}
+		return delivery
+	}
+
+	t.Run("should send signed payload and mark delivery as succeeded", func(t *testing.T) {
+		webhookRepository := &mocks.WebhookRepository{}
+
+		var signature, timestamp, body string
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			payload, _ := io.ReadAll(r.Body)
+			body = string(payload)
+			signature = r.Header.Get("X-Webhook-Signature")
+			timestamp = r.Header.Get("X-Webhook-Timestamp")
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		service := &webhookService{
+			httpClient:        server.Client(),
+			webhookRepository: webhookRepository,
+		}
+
+		delivery := newDelivery(server.URL, 0)
+
+		webhookRepository.On("GetDeliveryByID", ctx, delivery.ID).Return(delivery, nil)
+		webhookRepository.On("UpdateDelivery", ctx, mock.MatchedBy(func(updated models.WebhookDelivery) bool {
+			return updated.Status == models.SucceededDelivery &&
+				updated.Attempts == 1 &&
+				updated.ResponseStatus.Int32 == http.StatusNoContent &&
+				updated.DeliveredAt.Valid &&
+				!updated.NextAttemptAt.Valid
+		})).Return(nil)
+
+		err := service.DeliverWebhook(ctx, delivery.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, delivery.Payload, body)
+		assert.Equal(t, "sha256="+signWebhookPayload("whsec_test", timestamp, []byte(body)), signature)
+		webhookRepository.AssertExpectations(t)
+	})
+
+	t.Run("should schedule a retry with backoff when the endpoint fails", func(t *testing.T) {
+		webhookRepository := &mocks.WebhookRepository{}
+
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		service := &webhookService{
+			httpClient:        server.Client(),
+			webhookRepository: webhookRepository,
+		}
+
+		delivery := newDelivery(server.URL, 1)
+
+		webhookRepository.On("GetDeliveryByID", ctx, delivery.ID).Return(delivery, nil)
+		webhookRepository.On("UpdateDelivery", ctx, mock.MatchedBy(func(updated models.WebhookDelivery) bool {
+			delay := time.Until(updated.NextAttemptAt.Time)
+			return updated.Status == models.PendingDelivery &&
+				updated.Attempts == 2 &&
+				updated.ResponseStatus.Int32 == http.StatusInternalServerError &&
+				updated.Error.Valid &&
+				delay > 50*time.Second && delay <= time.Minute
+		})).Return(nil)
+
+		err := service.DeliverWebhook(ctx, delivery.ID)
+
+		assert.NoError(t, err)
+		webhookRepository.AssertExpectations(t)
+	})
+
+	t.Run("should mark delivery as failed after the last attempt", func(t *testing.T) {
+		webhookRepository := &mocks.WebhookRepository{}
+
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		service := &webhookService{
+			httpClient:        server.Client(),
+			webhookRepository: webhookRepository,
+		}
+
+		delivery := newDelivery(server.URL, maxWebhookDeliveryAttempts-1)
+
+		webhookRepository.On("GetDeliveryByID", ctx, delivery.ID).Return(delivery, nil)
+		webhookRepository.On("UpdateDelivery", ctx, mock.MatchedBy(func(updated models.WebhookDelivery) bool {
+			return updated.Status == models.FailedDelivery && !updated.NextAttemptAt.Valid
+		})).Return(nil)
+
+		err := service.DeliverWebhook(ctx, delivery.ID)
+
+		assert.NoError(t, err)
+		webhookRepository.AssertExpectations(t)
+	})
+
+	t.Run("should skip deliveries that are no longer pending", func(t *testing.T) {
+		webhookRepository := &mocks.WebhookRepository{}
+
+		service := &webhookService{
+			webhookRepository: webhookRepository,
+		}
+
+		delivery := newDelivery("http://localhost", 1)
+		delivery.Status = models.SucceededDelivery
+
+		webhookRepository.On("GetDeliveryByID", ctx, delivery.ID).Return(delivery, nil)
+
+		err := service.DeliverWebhook(ctx, delivery.ID)
+
+		assert.NoError(t, err)
+		webhookRepository.AssertNotCalled(t, "UpdateDelivery", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should fail delivery when the webhook was deleted", func(t *testing.T) {
+		webhookRepository := &mocks.WebhookRepository{}
+
+		service := &webhookService{
+			webhookRepository: webhookRepository,
+		}
+
+		delivery := newDelivery("", 0)
+		delivery.Webhook = models.Webhook{}
+
+		webhookRepository.On("GetDeliveryByID", ctx, delivery.ID).Return(delivery, nil)
+		webhookRepository.On("UpdateDelivery", ctx, mock.MatchedBy(func(updated models.WebhookDelivery) bool {
+			return updated.Status == models.FailedDelivery &&
+				updated.Attempts == 0 &&
+				!updated.NextAttemptAt.Valid
+		})).Return(nil)
+
+		err := service.DeliverWebhook(ctx, delivery.ID)
+
+		assert.NoError(t, err)
+		webhookRepository.AssertExpectations(t)
+	})
+
+	t.Run("should return error when delivery does not exist", func(t *testing.T) {
+		webhookRepository := &mocks.WebhookRepository{}
+
+		service := &webhookService{
+			webhookRepository: webhookRepository,
+		}
+
+		deliveryID := uuid.New()
+		webhookRepository.On("GetDeliveryByID", ctx, deliveryID).Return(nil, nil)
+
+		err := service.DeliverWebhook(ctx, deliveryID)
+
+		assert.ErrorIs(t, err, models.ErrWebhookDeliveryNotFound)
+	})
+}
+
+func TestWebhookService_RetryDueDeliveries(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should push the next attempt forward and queue due deliveries", func(t *testing.T) {
+		webhookRepository := &mocks.WebhookRepository{}
+		queueService := &mocks.QueueService{}
+
+		service := &webhookService{
+			queueService:      queueService,
+			webhookRepository: webhookRepository,
+		}
+
+		due := *models.NewWebhookDelivery(uuid.New(), uuid.New(), models.OrderCreatedEvent, "{}")
+		due.NextAttemptAt = sql.NullTime{Time: time.Now().UTC().Add(-time.Minute), Valid: true}
+
+		webhookRepository.On("GetDueDeliveries", ctx, mock.AnythingOfType("time.Time"), webhookRetryBatchSize).Return([]models.WebhookDelivery{due}, nil)
+		webhookRepository.On("UpdateDelivery", ctx, mock.MatchedBy(func(updated models.WebhookDelivery) bool {
+			return updated.ID == due.ID && updated.NextAttemptAt.Time.After(time.Now())
+		})).Return(nil)
+		queueService.On("Publish", QueueWebhookDelivery, mock.Anything).Return(nil)
+
+		retried, err := service.RetryDueDeliveries(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, retried)
+		webhookRepository.AssertExpectations(t)
+		queueService.AssertExpectations(t)
+	})
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	assert.Equal(t, 30*time.Second, webhookRetryDelay(1))
+	assert.Equal(t, time.Minute, webhookRetryDelay(2))
+	assert.Equal(t, 8*time.Minute, webhookRetryDelay(5))
+	assert.Equal(t, webhookRetryMaxDelay, webhookRetryDelay(20))
+	assert.Equal(t, webhookRetryMaxDelay, webhookRetryDelay(100))
+}
+
+// webhookEventOutbox matches the outbox written along with a domain change
+// when it carries a single event of the restaurant for the webhook worker.
+func webhookEventOutbox(restaurantID uuid.UUID, event models.WebhookEvent) any {
+	return mock.MatchedBy(func(messages []models.OutboxMessage) bool {
+		if len(messages) != 1 || messages[0].Queue != QueueWebhookEvent {
+			return false
+		}
+
+		var task models.WebhookEventTask
+		if err := jsoniter.UnmarshalFromString(messages[0].Payload, &task); err != nil {
+			return false
+		}
+
+		return task.RestaurantID == restaurantID && task.Event == event
+	})
+}
@@ -0,0 +1,58 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeys(t *testing.T) {
+	t.Run("should stop working once the creator leaves the restaurant", func(t *testing.T) {
+		server := newTestServer(t)
+		token := server.signInAsManager()
+
+		rec := server.request(http.MethodPost, "/v1/restaurants/api-keys", models.CreateAPIKeyPayload{
+			Name:   "POS",
+			Scopes: []models.Permission{models.ListOrdersPermission},
+		}, token)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+		var apiKey models.CreateAPIKeyResponse
+		server.decode(rec, &apiKey)
+
+		rec = server.request(http.MethodGet, "/v1/orders?page=1&limit=10", nil, apiKey.Key)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		require.NoError(t, server.db.Where("UserID = ?", server.manager.ID).Delete(&models.RestaurantMember{}).Error)
+
+		rec = server.request(http.MethodGet, "/v1/orders?page=1&limit=10", nil, apiKey.Key)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, rec.Body.String())
+	})
+
+	t.Run("should follow the grants of the creator's member role", func(t *testing.T) {
+		server := newTestServer(t)
+		token := server.signInAsManager()
+
+		rec := server.request(http.MethodPost, "/v1/restaurants/api-keys", models.CreateAPIKeyPayload{
+			Name:   "POS",
+			Scopes: []models.Permission{models.ListOrdersPermission},
+		}, token)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+		var apiKey models.CreateAPIKeyResponse
+		server.decode(rec, &apiKey)
+
+		rec = server.request(http.MethodPut, "/v1/restaurants/members/roles/kitchen/permissions", models.UpdateRolePermissionsPayload{
+			Permissions: []models.Permission{models.ApproveOrderPermission},
+		}, token)
+		require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+
+		require.NoError(t, server.db.Model(&models.RestaurantMember{}).Where("UserID = ?", server.manager.ID).Update("Role", models.KitchenMember).Error)
+
+		rec = server.request(http.MethodGet, "/v1/orders?page=1&limit=10", nil, apiKey.Key)
+		assert.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+	})
+}
@@ -0,0 +1,61 @@
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignIn(t *testing.T) {
+	t.Run("should refuse a manager who is not a member of any restaurant", func(t *testing.T) {
+		server := newTestServer(t)
+
+		removed := server.createUser("Removed", "removed@foodshop.com", models.Manager)
+
+		rec := server.request(http.MethodPost, "/v1/auth/sign-in", models.SignInPayload{Email: removed.Email}, "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		magicLink, err := url.Parse(server.lastEmailTo(removed.Email).Params["magic_link"])
+		require.NoError(t, err)
+
+		rec = server.request(http.MethodGet, "/v1/auth/link?mode=token&code="+url.QueryEscape(magicLink.Query().Get("code")), nil, "")
+
+		assert.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+	})
+
+	t.Run("should keep counting invalid codes after a new code is requested", func(t *testing.T) {
+		server := newTestServer(t)
+
+		rec := server.request(http.MethodPost, "/v1/auth/sign-in", models.SignInPayload{Email: server.customer.Email, Method: models.CodeSignIn}, "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		code := server.lastEmailTo(server.customer.Email).Params["code"]
+		wrongCode := "000000"
+		if code == wrongCode {
+			wrongCode = "111111"
+		}
+
+		for range 4 {
+			rec = server.request(http.MethodPost, "/v1/auth/code", models.VerifySignInCodePayload{Email: server.customer.Email, Code: wrongCode}, "")
+			require.Equal(t, http.StatusUnauthorized, rec.Code, rec.Body.String())
+		}
+
+		rec = server.request(http.MethodPost, "/v1/auth/sign-in", models.SignInPayload{Email: server.customer.Email, Method: models.CodeSignIn}, "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		code = server.lastEmailTo(server.customer.Email).Params["code"]
+		if code == wrongCode {
+			t.Skip("the new code matches the wrong one")
+		}
+
+		rec = server.request(http.MethodPost, "/v1/auth/code", models.VerifySignInCodePayload{Email: server.customer.Email, Code: wrongCode}, "")
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code, rec.Body.String())
+
+		rec = server.request(http.MethodPost, "/v1/auth/code", models.VerifySignInCodePayload{Email: server.customer.Email, Code: code}, "")
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code, rec.Body.String())
+	})
+}
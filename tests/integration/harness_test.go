@@ -0,0 +1,363 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/cache"
+	"github.com/G-Villarinho/food-shop-api/client"
+	"github.com/G-Villarinho/food-shop-api/cmd/api/providers"
+	"github.com/G-Villarinho/food-shop-api/cmd/api/router"
+	"github.com/G-Villarinho/food-shop-api/config"
+	"github.com/G-Villarinho/food-shop-api/database/migrations"
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/G-Villarinho/food-shop-api/services"
+	sqlitedriver "github.com/glebarez/go-sqlite"
+	"github.com/glebarez/sqlite"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+// migratedModels mirrors the migrations of database/migrations, which are
+// written for MySQL and can't run on SQLite.
+var migratedModels = []any{
+	&models.User{},
+	&models.Restaurant{},
+	&models.Product{},
+	&models.Order{},
+	&models.OrderItem{},
+	&models.Evaluation{},
+	&models.RestaurantMember{},
+	&models.RolePermission{},
+	&models.RestaurantRolePermission{},
+	&models.SeededRolePermission{},
+	&models.APIKey{},
+	&models.Webhook{},
+	&models.WebhookDelivery{},
+	&models.OutboxMessage{},
+}
+
+// testServer is the API wired as in cmd/api, on top of an in-memory SQLite
+// database and the in-memory cache and queue. Each test gets its own server,
+// so fixtures and sessions never leak between tests.
+type testServer struct {
+	t    *testing.T
+	db   *gorm.DB
+	echo *echo.Echo
+
+	customer   models.User
+	manager    models.User
+	courier    models.User
+	restaurant models.Restaurant
+	products   []models.Product
+}
+
+func TestMain(m *testing.M) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	privateKeyDER, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		panic(err)
+	}
+
+	config.Env.PrivateKey = string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privateKeyDER}))
+	config.Env.APIBaseURL = "http://localhost:8080"
+	config.Env.RedirectURL = "http://localhost:3000"
+	config.Env.CookieName = "food-shop.token"
+	config.Env.RefreshCookieName = "food-shop.refresh-token"
+	config.Env.Cache.Driver = config.MemoryDriver
+	config.Env.Cache.SessionExp = 24
+	config.Env.Cache.AccessTokenExp = 15
+	config.Env.Cache.CacheExp = 10
+	config.Env.Cache.Code2FADuration = 10
+	config.Env.Cache.Hash2FADuration = 10
+	config.Env.Cache.InvitationExp = 24
+	config.Env.Queue.Driver = config.MemoryDriver
+	config.Env.RateLimit.SignInIPLimit = -1
+	config.Env.RateLimit.SignInEmailLimit = -1
+	config.Env.Queue.MaxRetries = 3
+	config.Env.Queue.RetryDelay = 1
+
+	sqlitedriver.MustRegisterDeterministicScalarFunction("DATE_FORMAT", 2, dateFormat)
+
+	os.Exit(m.Run())
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	db := newTestDatabase(t)
+
+	di := internal.NewDi()
+
+	internal.Provide(di, func(d *internal.Di) (*gorm.DB, error) {
+		return db, nil
+	})
+
+	rabbitMQClient, err := client.NewMemoryRabbitMQClient(di)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		rabbitMQClient.Disconnect()
+	})
+
+	internal.Provide(di, func(d *internal.Di) (client.RabbitMQClient, error) {
+		return rabbitMQClient, nil
+	})
+
+	internal.Provide(di, cache.NewMemoryCache)
+	internal.Provide(di, cache.NewMemoryRateLimiter)
+
+	providers.SetupProviders(di)
+
+	e := echo.New()
+	router.SetupRoutes(e, di)
+
+	server := &testServer{
+		t:    t,
+		db:   db,
+		echo: e,
+	}
+	server.seed()
+
+	return server
+}
+
+// newTestDatabase opens a private in-memory database and migrates it. SQLite
+// has no enum type, so the enum columns are created as text.
+func newTestDatabase(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", uuid.NewString())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+
+	// A single connection keeps the database alive for the whole test and
+	// serializes writes, as SQLite only allows one writer at a time.
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() {
+		sqlDB.Close()
+	})
+
+	for _, model := range migratedModels {
+		statement := &gorm.Statement{DB: db}
+		require.NoError(t, statement.Parse(model))
+
+		for _, field := range statement.Schema.Fields {
+			if strings.HasPrefix(strings.ToLower(string(field.DataType)), "enum") {
+				field.DataType = schema.String
+			}
+		}
+	}
+
+	require.NoError(t, db.AutoMigrate(migratedModels...))
+
+	require.NoError(t, migrations.SyncRolePermissions(context.Background(), db))
+
+	return db
+}
+
+// dateFormatLayouts translates the MySQL DATE_FORMAT specifiers used by the
+// repositories into Go layouts.
+var dateFormatLayouts = strings.NewReplacer("%Y", "2006", "%m", "01", "%d", "02", "%H", "15", "%i", "04", "%s", "05")
+
+// dateFormat stands in for the MySQL DATE_FORMAT function, which SQLite lacks,
+// so the metrics queries run unchanged.
+func dateFormat(_ *sqlitedriver.FunctionContext, args []driver.Value) (driver.Value, error) {
+	format, ok := args[1].(string)
+	if !ok {
+		return nil, fmt.Errorf("DATE_FORMAT: invalid format %v", args[1])
+	}
+
+	var date time.Time
+	switch value := args[0].(type) {
+	case nil:
+		return nil, nil
+	case time.Time:
+		date = value
+	case string:
+		parsed, err := time.Parse("2006-01-02 15:04:05.999999999-07:00", value)
+		if err != nil {
+			return nil, fmt.Errorf("DATE_FORMAT: %w", err)
+		}
+		date = parsed
+	default:
+		return nil, fmt.Errorf("DATE_FORMAT: invalid date %v", value)
+	}
+
+	return date.Format(dateFormatLayouts.Replace(format)), nil
+}
+
+// seed creates a restaurant owned by the manager, with two products, plus a
+// customer and a courier.
+func (s *testServer) seed() {
+	s.customer = s.createUser("Customer", "customer@foodshop.com", models.Customer)
+	s.manager = s.createUser("Manager", "manager@foodshop.com", models.Manager)
+	s.courier = s.createUser("Courier", "courier@foodshop.com", models.Courier)
+
+	s.restaurant = models.Restaurant{
+		BaseModel: newBaseModel(),
+		Name:      "Pizzaria",
+		ManagerID: s.manager.ID,
+		OpensAt:   "00:00",
+		ClosesAt:  "23:59",
+		Status:    models.ActiveRestaurant,
+	}
+	require.NoError(s.t, s.db.Create(&s.restaurant).Error)
+	require.NoError(s.t, s.db.Create(models.NewRestaurantMember(s.restaurant.ID, s.manager.ID, models.OwnerMember)).Error)
+
+	s.products = []models.Product{
+		{BaseModel: newBaseModel(), Name: "Pizza", PriceInCents: 5000, RestaurantID: s.restaurant.ID},
+		{BaseModel: newBaseModel(), Name: "Refrigerante", PriceInCents: 800, RestaurantID: s.restaurant.ID},
+	}
+	require.NoError(s.t, s.db.Create(&s.products).Error)
+}
+
+func (s *testServer) createUser(fullName, email string, role models.Role) models.User {
+	user := models.User{
+		BaseModel: newBaseModel(),
+		FullName:  fullName,
+		Email:     email,
+		Status:    models.Active,
+		Role:      role,
+		Phone:     sql.NullString{String: "11999999999", Valid: true},
+	}
+	require.NoError(s.t, s.db.Create(&user).Error)
+
+	return user
+}
+
+func newBaseModel() models.BaseModel {
+	ID, _ := uuid.NewV7()
+	return models.BaseModel{ID: ID}
+}
+
+// createOrder stores an order of the customer in the restaurant, as if it had
+// been placed at createdAt.
+func (s *testServer) createOrder(status models.OrderStatus, tipInCents int, createdAt time.Time) uuid.UUID {
+	s.t.Helper()
+
+	order := models.Order{
+		BaseModel:    newBaseModel(),
+		CustommerID:  s.customer.ID,
+		RestaurantID: s.restaurant.ID,
+		Status:       status,
+		TotalInCents: 5000,
+		TipInCents:   tipInCents,
+	}
+	require.NoError(s.t, s.db.Create(&order).Error)
+	require.NoError(s.t, s.db.Model(&order).UpdateColumn("CreatedAt", createdAt).Error)
+
+	return order.ID
+}
+
+// request sends a request to the API, authenticated with token when it isn't
+// empty. A non-nil body is sent as JSON.
+func (s *testServer) request(method, path string, body any, token string) *httptest.ResponseRecorder {
+	s.t.Helper()
+
+	var reader bytes.Buffer
+	if body != nil {
+		require.NoError(s.t, jsoniter.NewEncoder(&reader).Encode(body))
+	}
+
+	req := httptest.NewRequest(method, path, &reader)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if token != "" {
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	}
+
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	return rec
+}
+
+// decode reads the JSON body of the response into target.
+func (s *testServer) decode(rec *httptest.ResponseRecorder, target any) {
+	s.t.Helper()
+
+	require.NoError(s.t, jsoniter.Unmarshal(rec.Body.Bytes(), target), rec.Body.String())
+}
+
+// signIn goes through the magic link flow: it asks for the link, reads it from
+// the e-mail left in the outbox and exchanges its code for an access token.
+func (s *testServer) signIn(email string) string {
+	s.t.Helper()
+
+	rec := s.request(http.MethodPost, "/v1/auth/sign-in", models.SignInPayload{Email: email}, "")
+	require.Equal(s.t, http.StatusOK, rec.Code, rec.Body.String())
+
+	magicLink, err := url.Parse(s.lastEmailTo(email).Params["magic_link"])
+	require.NoError(s.t, err)
+
+	rec = s.request(http.MethodGet, "/v1/auth/link?mode=token&code="+url.QueryEscape(magicLink.Query().Get("code")), nil, "")
+	require.Equal(s.t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var response models.AuthTokenResponse
+	s.decode(rec, &response)
+	require.NotEmpty(s.t, response.Token)
+
+	return response.Token
+}
+
+func (s *testServer) signInAsCustomer() string {
+	return s.signIn(s.customer.Email)
+}
+
+func (s *testServer) signInAsManager() string {
+	return s.signIn(s.manager.Email)
+}
+
+func (s *testServer) signInAsCourier() string {
+	return s.signIn(s.courier.Email)
+}
+
+// lastEmailTo returns the latest e-mail sent to the address through the outbox.
+func (s *testServer) lastEmailTo(email string) models.EmailQueueTask {
+	s.t.Helper()
+
+	var messages []models.OutboxMessage
+	require.NoError(s.t, s.db.Where("Queue = ?", services.QueueSendEmail).Order("Id DESC").Find(&messages).Error)
+
+	for _, message := range messages {
+		var task models.EmailQueueTask
+		require.NoError(s.t, jsoniter.UnmarshalFromString(message.Payload, &task))
+
+		if slices.Contains(task.To, email) {
+			return task
+		}
+	}
+
+	s.t.Fatalf("no e-mail was sent to %s", email)
+	return models.EmailQueueTask{}
+}
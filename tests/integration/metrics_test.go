@@ -0,0 +1,52 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonthlyTips(t *testing.T) {
+	t.Run("should sum the tips of delivered orders per month", func(t *testing.T) {
+		server := newTestServer(t)
+
+		now := time.Now().UTC()
+		lastMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+
+		server.createOrder(models.Delivered, 1000, now)
+		server.createOrder(models.Delivered, 500, now)
+		server.createOrder(models.Delivered, 0, now)
+		server.createOrder(models.Canceled, 700, now)
+		server.createOrder(models.Delivered, 1000, lastMonth)
+
+		rec := server.request(http.MethodGet, "/v1/metrics/orders/monthly-tips", nil, server.signInAsManager())
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var response models.MonthlyTipsMetricsResponse
+		server.decode(rec, &response)
+
+		assert.Equal(t, models.MonthlyTipsMetricsResponse{
+			TotalInCents:      1500,
+			OrdersWithTip:     2,
+			DiffFromLastMonth: 50,
+		}, response)
+	})
+
+	t.Run("should return zero when the restaurant has no tips", func(t *testing.T) {
+		server := newTestServer(t)
+
+		server.createOrder(models.Delivered, 0, time.Now().UTC())
+
+		rec := server.request(http.MethodGet, "/v1/metrics/orders/monthly-tips", nil, server.signInAsManager())
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var response models.MonthlyTipsMetricsResponse
+		server.decode(rec, &response)
+
+		assert.Equal(t, models.MonthlyTipsMetricsResponse{}, response)
+	})
+}
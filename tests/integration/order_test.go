@@ -0,0 +1,113 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/G-Villarinho/food-shop-api/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderFlow(t *testing.T) {
+	t.Run("should take an order from creation to delivery", func(t *testing.T) {
+		server := newTestServer(t)
+
+		customerToken := server.signInAsCustomer()
+		managerToken := server.signInAsManager()
+		courierToken := server.signInAsCourier()
+
+		rec := server.request(http.MethodPost, fmt.Sprintf("/v1/restaurants/%s/order", server.restaurant.ID), models.CreateOrderPayload{
+			Items: []models.CreateOrderItemPayload{
+				{ProductID: server.products[0].ID, Quantity: 2},
+				{ProductID: server.products[1].ID, Quantity: 1},
+			},
+		}, customerToken)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+		rec = server.request(http.MethodGet, "/v1/orders?page=1&limit=10", nil, managerToken)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var orders models.PaginatedResponse[models.OrderResponse]
+		server.decode(rec, &orders)
+		require.Len(t, orders.Data, 1)
+
+		order := orders.Data[0]
+		assert.Equal(t, models.Pending, order.Status)
+		assert.Equal(t, 10800, order.TotalInCents)
+
+		rec = server.request(http.MethodPatch, fmt.Sprintf("/v1/orders/%s/approve", order.ID), nil, managerToken)
+		require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+
+		rec = server.request(http.MethodPatch, fmt.Sprintf("/v1/orders/%s/dispatch", order.ID), models.DispatchOrderPayload{CourierID: &server.courier.ID}, managerToken)
+		require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+
+		rec = server.request(http.MethodPatch, fmt.Sprintf("/v1/couriers/me/deliveries/%s/complete", order.ID), nil, courierToken)
+		require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+
+		var delivered models.Order
+		require.NoError(t, server.db.First(&delivered, "Id = ?", order.ID).Error)
+		assert.Equal(t, models.Delivered, delivered.Status)
+		assert.Equal(t, server.courier.ID, delivered.CourierID.UUID)
+
+		var events int64
+		require.NoError(t, server.db.Model(&models.OutboxMessage{}).Where("Queue <> ?", services.QueueSendEmail).Count(&events).Error)
+		assert.Equal(t, int64(4), events)
+	})
+
+	t.Run("should not let a customer approve an order", func(t *testing.T) {
+		server := newTestServer(t)
+
+		customerToken := server.signInAsCustomer()
+
+		rec := server.request(http.MethodPost, fmt.Sprintf("/v1/restaurants/%s/order", server.restaurant.ID), models.CreateOrderPayload{
+			Items: []models.CreateOrderItemPayload{{ProductID: server.products[0].ID, Quantity: 1}},
+		}, customerToken)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+		var order models.Order
+		require.NoError(t, server.db.First(&order, "CustommerID = ?", server.customer.ID).Error)
+
+		rec = server.request(http.MethodPatch, fmt.Sprintf("/v1/orders/%s/approve", order.ID), nil, customerToken)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+
+		require.NoError(t, server.db.First(&order, "Id = ?", order.ID).Error)
+		assert.Equal(t, models.Pending, order.Status)
+	})
+
+	t.Run("should list scheduled orders only when filtered by status", func(t *testing.T) {
+		server := newTestServer(t)
+
+		managerToken := server.signInAsManager()
+
+		pendingID := server.createOrder(models.Pending, 0, time.Now().UTC())
+		scheduledID := server.createOrder(models.Scheduled, 0, time.Now().UTC())
+
+		rec := server.request(http.MethodGet, "/v1/orders?page=1&limit=10", nil, managerToken)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var orders models.PaginatedResponse[models.OrderResponse]
+		server.decode(rec, &orders)
+		require.Len(t, orders.Data, 1)
+		assert.Equal(t, pendingID, orders.Data[0].ID)
+
+		rec = server.request(http.MethodGet, "/v1/orders?page=1&limit=10&status=scheduled", nil, managerToken)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		orders = models.PaginatedResponse[models.OrderResponse]{}
+		server.decode(rec, &orders)
+		require.Len(t, orders.Data, 1)
+		assert.Equal(t, scheduledID, orders.Data[0].ID)
+	})
+
+	t.Run("should reject requests without a session", func(t *testing.T) {
+		server := newTestServer(t)
+
+		rec := server.request(http.MethodGet, "/v1/orders", nil, "")
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
@@ -0,0 +1,97 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/internal"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/G-Villarinho/food-shop-api/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestOutboxRelay(t *testing.T) {
+	newOutboxRepository := func(server *testServer) repositories.OutboxRepository {
+		di := internal.NewDi()
+		internal.Provide(di, func(d *internal.Di) (*gorm.DB, error) {
+			return server.db, nil
+		})
+
+		outboxRepository, err := repositories.NewOutboxRepository(di)
+		require.NoError(t, err)
+
+		return outboxRepository
+	}
+
+	createMessage := func(server *testServer, payload string, attempts int, createdAt time.Time) *models.OutboxMessage {
+		message, err := models.NewOutboxMessage("test", payload)
+		require.NoError(t, err)
+		message.Attempts = attempts
+		message.CreatedAt = createdAt
+		require.NoError(t, server.db.Create(message).Error)
+
+		return message
+	}
+
+	t.Run("should record the failure and stop at the first message that can't be published", func(t *testing.T) {
+		server := newTestServer(t)
+		outboxRepository := newOutboxRepository(server)
+
+		now := time.Now().UTC()
+		first := createMessage(server, "first", 0, now.Add(-2*time.Minute))
+		second := createMessage(server, "second", 0, now.Add(-time.Minute))
+
+		var published []string
+		relayed, err := outboxRepository.RelayPendingMessages(context.Background(), 10, 3, func(message models.OutboxMessage) error {
+			if message.ID == first.ID {
+				return errors.New("broker down")
+			}
+			published = append(published, message.Payload)
+			return nil
+		})
+
+		assert.EqualError(t, err, "broker down")
+		assert.Equal(t, 0, relayed)
+		assert.Empty(t, published)
+
+		var stored models.OutboxMessage
+		require.NoError(t, server.db.First(&stored, "Id = ?", first.ID).Error)
+		assert.Equal(t, 1, stored.Attempts)
+		assert.Equal(t, "broker down", stored.LastError.String)
+
+		var next models.OutboxMessage
+		require.NoError(t, server.db.First(&next, "Id = ?", second.ID).Error)
+		assert.False(t, next.PublishedAt.Valid)
+	})
+
+	t.Run("should skip parked messages and publish the ones written after them", func(t *testing.T) {
+		server := newTestServer(t)
+		outboxRepository := newOutboxRepository(server)
+
+		now := time.Now().UTC()
+		parked := createMessage(server, "parked", 3, now.Add(-2*time.Minute))
+		pending := createMessage(server, "pending", 0, now.Add(-time.Minute))
+
+		var published []string
+		relayed, err := outboxRepository.RelayPendingMessages(context.Background(), 10, 3, func(message models.OutboxMessage) error {
+			published = append(published, message.Payload)
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, relayed)
+		assert.Equal(t, []string{pending.Payload}, published)
+
+		var stored models.OutboxMessage
+		require.NoError(t, server.db.First(&stored, "Id = ?", pending.ID).Error)
+		assert.True(t, stored.PublishedAt.Valid)
+
+		var skipped models.OutboxMessage
+		require.NoError(t, server.db.First(&skipped, "Id = ?", parked.ID).Error)
+		assert.False(t, skipped.PublishedAt.Valid)
+	})
+}
@@ -0,0 +1,49 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestaurantRolePermissions(t *testing.T) {
+	t.Run("should apply the grants edited by the restaurant to its members only", func(t *testing.T) {
+		server := newTestServer(t)
+
+		kitchen := server.createUser("Kitchen", "kitchen@foodshop.com", models.Manager)
+		require.NoError(t, server.db.Create(models.NewRestaurantMember(server.restaurant.ID, kitchen.ID, models.KitchenMember)).Error)
+
+		kitchenToken := server.signIn(kitchen.Email)
+
+		rec := server.request(http.MethodGet, "/v1/orders?page=1&limit=10", nil, kitchenToken)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		rec = server.request(http.MethodPut, "/v1/restaurants/members/roles/kitchen/permissions", models.UpdateRolePermissionsPayload{
+			Permissions: []models.Permission{models.ApproveOrderPermission},
+		}, server.signInAsManager())
+		require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+
+		rec = server.request(http.MethodGet, "/v1/orders?page=1&limit=10", nil, kitchenToken)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+
+		var defaults []models.Permission
+		require.NoError(t, server.db.Model(&models.RolePermission{}).
+			Where("Scope = ? AND Role = ?", models.MemberScope, models.KitchenMember).
+			Pluck("Permission", &defaults).Error)
+		assert.Contains(t, defaults, models.ListOrdersPermission)
+	})
+
+	t.Run("should not let the owner role be edited", func(t *testing.T) {
+		server := newTestServer(t)
+
+		rec := server.request(http.MethodPut, "/v1/restaurants/members/roles/owner/permissions", models.UpdateRolePermissionsPayload{
+			Permissions: []models.Permission{models.ListOrdersPermission},
+		}, server.signInAsManager())
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "owner_role_not_editable")
+	})
+}
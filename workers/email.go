@@ -0,0 +1,42 @@
+package workers
+
+import (
+	"context"
+	"log"
+
+	"github.com/G-Villarinho/food-shop-api/client"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/G-Villarinho/food-shop-api/services"
+	"github.com/G-Villarinho/food-shop-api/services/email"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// SendEmails consumes the email queue until ctx is done, retrying the emails
+// that fail and dead-lettering the tasks that can't be read.
+func SendEmails(ctx context.Context, queueService services.QueueService, emailService email.EmailService) error {
+	return consume(ctx, queueService, services.QueueSendEmail, func(ctx context.Context, message *client.Message) {
+		var task models.EmailQueueTask
+		if err := jsoniter.Unmarshal(message.Body, &task); err != nil {
+			log.Println("error unmarshalling email task: ", err)
+			if err := queueService.DeadLetter(services.QueueSendEmail, message, err); err != nil {
+				log.Println("error dead-lettering email task: ", err)
+			}
+			return
+		}
+
+		if err := emailService.SendEmail(ctx, task); err != nil {
+			log.Println("error sending email: ", err)
+			if err := queueService.Retry(services.QueueSendEmail, message, err); err != nil {
+				log.Println("error retrying email task: ", err)
+			}
+			return
+		}
+
+		if err := message.Ack(); err != nil {
+			log.Println("error acknowledging email task: ", err)
+			return
+		}
+
+		log.Println("email sent successfully")
+	})
+}
@@ -0,0 +1,36 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/config"
+	"github.com/G-Villarinho/food-shop-api/services"
+)
+
+// ReleaseScheduledOrders moves the scheduled orders that are due to pending
+// every SCHEDULED_ORDER_JOB_INTERVAL seconds until ctx is done.
+func ReleaseScheduledOrders(ctx context.Context, orderService services.OrderService) error {
+	interval := time.Duration(config.Env.Scheduling.JobInterval) * time.Second
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	release := func(ctx context.Context) {
+		released, err := orderService.ReleaseScheduledOrders(ctx)
+		if err != nil {
+			log.Println("error releasing scheduled orders: ", err)
+			return
+		}
+
+		if released > 0 {
+			log.Printf("%d scheduled orders released to pending", released)
+		}
+	}
+
+	release(context.WithoutCancel(ctx))
+	every(ctx, interval, release)
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/config"
+	"github.com/G-Villarinho/food-shop-api/services"
+)
+
+const outboxCleanupInterval = time.Hour
+
+// RelayOutbox publishes the pending outbox messages every
+// OUTBOX_RELAY_INTERVAL seconds until ctx is done.
+func RelayOutbox(ctx context.Context, outboxService services.OutboxService) error {
+	interval := time.Duration(config.Env.Outbox.RelayInterval) * time.Second
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	relay := func(ctx context.Context) {
+		relayed, err := outboxService.RelayPendingMessages(ctx)
+		if err != nil {
+			log.Println("error relaying outbox messages: ", err)
+		}
+
+		if relayed > 0 {
+			log.Printf("%d outbox messages published", relayed)
+		}
+	}
+
+	relay(context.WithoutCancel(ctx))
+	every(ctx, interval, relay)
+
+	return nil
+}
+
+// DeletePublishedOutboxMessages keeps the outbox table small by removing the
+// messages published longer ago than the retention.
+func DeletePublishedOutboxMessages(ctx context.Context, outboxService services.OutboxService) error {
+	every(ctx, outboxCleanupInterval, func(ctx context.Context) {
+		deleted, err := outboxService.DeletePublishedMessages(ctx)
+		if err != nil {
+			log.Println("error deleting published outbox messages: ", err)
+			return
+		}
+
+		if deleted > 0 {
+			log.Printf("%d published outbox messages deleted", deleted)
+		}
+	})
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/client"
+	"github.com/G-Villarinho/food-shop-api/models"
+	"github.com/G-Villarinho/food-shop-api/services"
+	jsoniter "github.com/json-iterator/go"
+)
+
+const webhookRetryInterval = 15 * time.Second
+
+// DeliverWebhooks consumes the webhook delivery queue until ctx is done.
+func DeliverWebhooks(ctx context.Context, queueService services.QueueService, webhookService services.WebhookService) error {
+	return consume(ctx, queueService, services.QueueWebhookDelivery, func(ctx context.Context, message *client.Message) {
+		var task models.WebhookDeliveryTask
+		if err := jsoniter.Unmarshal(message.Body, &task); err != nil {
+			log.Println("error unmarshalling webhook delivery task: ", err)
+			if err := queueService.DeadLetter(services.QueueWebhookDelivery, message, err); err != nil {
+				log.Println("error dead-lettering webhook delivery task: ", err)
+			}
+			return
+		}
+
+		// Failed requests are retried by the delivery itself with backoff;
+		// only infrastructure errors go through the queue retries.
+		if err := webhookService.DeliverWebhook(ctx, task.DeliveryID); err != nil && !errors.Is(err, models.ErrWebhookDeliveryNotFound) {
+			log.Println("error delivering webhook: ", err)
+			if err := queueService.Retry(services.QueueWebhookDelivery, message, err); err != nil {
+				log.Println("error retrying webhook delivery task: ", err)
+			}
+			return
+		}
+
+		if err := message.Ack(); err != nil {
+			log.Println("error acknowledging webhook delivery task: ", err)
+		}
+	})
+}
+
+// DispatchWebhookEvents turns the events relayed by the outbox into one
+// delivery per subscribed webhook.
+func DispatchWebhookEvents(ctx context.Context, queueService services.QueueService, webhookService services.WebhookService) error {
+	return consume(ctx, queueService, services.QueueWebhookEvent, func(ctx context.Context, message *client.Message) {
+		var task models.WebhookEventTask
+		if err := jsoniter.Unmarshal(message.Body, &task); err != nil {
+			log.Println("error unmarshalling webhook event task: ", err)
+			if err := queueService.DeadLetter(services.QueueWebhookEvent, message, err); err != nil {
+				log.Println("error dead-lettering webhook event task: ", err)
+			}
+			return
+		}
+
+		if err := webhookService.Dispatch(ctx, task); err != nil {
+			log.Println("error dispatching webhook event: ", err)
+			if err := queueService.Retry(services.QueueWebhookEvent, message, err); err != nil {
+				log.Println("error retrying webhook event task: ", err)
+			}
+			return
+		}
+
+		if err := message.Ack(); err != nil {
+			log.Println("error acknowledging webhook event task: ", err)
+		}
+	})
+}
+
+// RetryDueWebhookDeliveries queues again the deliveries whose backoff has
+// elapsed and the ones that never reached the queue.
+func RetryDueWebhookDeliveries(ctx context.Context, webhookService services.WebhookService) error {
+	every(ctx, webhookRetryInterval, func(ctx context.Context) {
+		retried, err := webhookService.RetryDueDeliveries(ctx)
+		if err != nil {
+			log.Println("error retrying webhook deliveries: ", err)
+			return
+		}
+
+		if retried > 0 {
+			log.Printf("%d webhook deliveries queued for retry", retried)
+		}
+	})
+
+	return nil
+}
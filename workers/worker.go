@@ -0,0 +1,103 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/G-Villarinho/food-shop-api/client"
+	"github.com/G-Villarinho/food-shop-api/services"
+)
+
+var ErrShutdownTimeout = errors.New("workers did not stop before the shutdown timeout")
+
+// Worker runs until ctx is done, finishing the work in progress before it
+// returns.
+type Worker func(ctx context.Context) error
+
+// Run starts the workers and blocks until all of them return. When ctx is done
+// they get up to timeout to finish what they are doing. A worker that fails
+// stops the others.
+func Run(ctx context.Context, timeout time.Duration, workers ...Worker) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var runErr error
+
+	for _, worker := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := worker(ctx); err != nil {
+				once.Do(func() {
+					runErr = err
+					cancel()
+				})
+			}
+		}()
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return runErr
+	case <-ctx.Done():
+	}
+
+	select {
+	case <-stopped:
+		return runErr
+	case <-time.After(timeout):
+		return ErrShutdownTimeout
+	}
+}
+
+// consume hands the messages of the queue to handle, one at a time, until ctx
+// is done or the client disconnects. The message being handled when ctx is
+// done is finished with a context that is not canceled, so it is acknowledged
+// instead of redelivered.
+func consume(ctx context.Context, queueService services.QueueService, queueName string, handle func(ctx context.Context, message *client.Message)) error {
+	messages, err := queueService.Consume(queueName)
+	if err != nil {
+		return fmt.Errorf("consume %s: %w", queueName, err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case message, ok := <-messages:
+			if !ok {
+				return nil
+			}
+
+			handle(context.WithoutCancel(ctx), message)
+		}
+	}
+}
+
+// every calls fn on each tick of interval until ctx is done. Like consume, the
+// call in progress is not canceled.
+func every(ctx context.Context, interval time.Duration, fn func(ctx context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fn(context.WithoutCancel(ctx))
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	t.Run("should wait for the workers to finish after ctx is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		finished := make(chan struct{})
+		worker := func(ctx context.Context) error {
+			<-ctx.Done()
+			time.Sleep(20 * time.Millisecond)
+			close(finished)
+			return nil
+		}
+
+		go cancel()
+		err := Run(ctx, time.Second, worker, untilDone)
+
+		assert.NoError(t, err)
+		select {
+		case <-finished:
+		default:
+			t.Fatal("Run returned before the worker finished")
+		}
+	})
+
+	t.Run("should return ErrShutdownTimeout when a worker doesn't stop in time", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		release := make(chan struct{})
+		t.Cleanup(func() {
+			close(release)
+		})
+
+		stuck := func(ctx context.Context) error {
+			<-release
+			return nil
+		}
+
+		cancel()
+		start := time.Now()
+		err := Run(ctx, 50*time.Millisecond, stuck, untilDone)
+
+		assert.ErrorIs(t, err, ErrShutdownTimeout)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("should stop the other workers when one fails", func(t *testing.T) {
+		failure := errors.New("queue closed")
+
+		failing := func(ctx context.Context) error {
+			return failure
+		}
+
+		err := Run(context.Background(), time.Second, failing, untilDone)
+
+		assert.ErrorIs(t, err, failure)
+	})
+
+	t.Run("should return once every worker returns on its own", func(t *testing.T) {
+		done := func(ctx context.Context) error {
+			return nil
+		}
+
+		err := Run(context.Background(), time.Second, done, done)
+
+		assert.NoError(t, err)
+	})
+}
+
+// untilDone is a worker that stops as soon as ctx is done.
+func untilDone(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}